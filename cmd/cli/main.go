@@ -17,7 +17,18 @@
 // Package main load the Dependency CLI
 package main
 
-func main() {
+import (
+	"fmt"
+	"os"
+
+	"scanoss.com/dependencies/pkg/cmd"
+)
 
-	// TODO Add CLI entry point...
+// main runs the Dependency CLI.
+func main() {
+	if err := cmd.RunCli(os.Args[1:]); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
 }
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package cache keeps decorated components between requests, keyed by purl, requirement and the generation of the
+// data they were decorated with (KB snapshot and overlays), in a pluggable backend (an in-memory LRU by default).
+package cache
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/dtos"
+)
+
+// Backend stores encoded cache entries.
+// External backends (i.e. Redis or memcached) can be plugged in by implementing this interface.
+type Backend interface {
+	Get(key string) ([]byte, bool, error) // Returns false if the key is missing or expired
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// keyPrefix namespaces the keys of the decoration cache (i.e. in a shared external backend).
+const keyPrefix = "dependencies:decoration:"
+
+// DecorationCache keeps the decoration output of components. Entries are keyed by the generation of the data they
+// were decorated with, so a new generation (i.e. KB snapshot) is never served older entries, which expire in time.
+type DecorationCache struct {
+	s       *zap.SugaredLogger
+	backend Backend
+	ttl     time.Duration
+}
+
+// NewDecorationCache creates a new decoration cache on the given backend, with entries expiring after the TTL.
+func NewDecorationCache(s *zap.SugaredLogger, backend Backend, ttl time.Duration) *DecorationCache {
+	return &DecorationCache{s: s, backend: backend, ttl: ttl}
+}
+
+// key builds the cache key of the given component for a data generation.
+func key(generation, purl, requirement string) string {
+	return keyPrefix + strings.Join([]string{generation, purl, requirement}, "|")
+}
+
+// Get returns the cached decoration output of the given component for a data generation (if any).
+func (c *DecorationCache) Get(generation, purl, requirement string) (dtos.DependenciesOutput, bool) {
+	data, found, err := c.backend.Get(key(generation, purl, requirement))
+	if err != nil {
+		c.s.Warnf("Problem reading %v from the decoration cache: %v", purl, err)
+		return dtos.DependenciesOutput{}, false
+	}
+	if !found {
+		return dtos.DependenciesOutput{}, false
+	}
+	var output dtos.DependenciesOutput
+	if err = json.Unmarshal(data, &output); err != nil {
+		c.s.Warnf("Ignoring invalid decoration cache entry for %v: %v", purl, err)
+		return dtos.DependenciesOutput{}, false
+	}
+	return output, true
+}
+
+// Set caches the decoration output of the given component for a data generation.
+func (c *DecorationCache) Set(generation, purl, requirement string, output dtos.DependenciesOutput) {
+	data, err := json.Marshal(output)
+	if err == nil {
+		err = c.backend.Set(key(generation, purl, requirement), data, c.ttl)
+	}
+	if err != nil {
+		c.s.Warnf("Problem writing %v to the decoration cache: %v", purl, err)
+	}
+}
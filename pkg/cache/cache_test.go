@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/dependencies/pkg/dtos"
+)
+
+func TestLRUBackend(t *testing.T) {
+	backend := NewLRUBackend(2)
+	_ = backend.Set("a", []byte("1"), 0)
+	_ = backend.Set("b", []byte("2"), 0)
+	if _, found, _ := backend.Get("a"); !found { // a is now the most recently used
+		t.Fatalf("Get() expected a to be cached")
+	}
+	_ = backend.Set("c", []byte("3"), 0)
+	if _, found, _ := backend.Get("b"); found {
+		t.Errorf("Set() expected the least recently used entry to be evicted")
+	}
+	if value, found, _ := backend.Get("a"); !found || string(value) != "1" {
+		t.Errorf("Get() = %s, %v, want 1, true", value, found)
+	}
+	_ = backend.Set("d", []byte("4"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, found, _ := backend.Get("d"); found {
+		t.Errorf("Get() expected the expired entry to be missing")
+	}
+}
+
+func TestDecorationCache(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	backend := NewLRUBackend(10)
+	cache := NewDecorationCache(zlog.S, backend, time.Hour)
+	output := dtos.DependenciesOutput{Purl: "pkg:npm/isbinaryfile", Requirement: "^4.0.8", Version: "4.0.10",
+		Licenses: []dtos.DependencyLicense{{Name: "MIT", SpdxID: "MIT", IsSpdx: true}}}
+	cache.Set("2026.10.01", output.Purl, output.Requirement, output)
+	got, found := cache.Get("2026.10.01", output.Purl, output.Requirement)
+	if !found || got.Version != output.Version || len(got.Licenses) != 1 || got.Licenses[0] != output.Licenses[0] {
+		t.Errorf("Get() = %#v, %v, want %#v", got, found, output)
+	}
+	if _, found = cache.Get("2026.10.01", output.Purl, ""); found {
+		t.Errorf("Get() expected a miss for a different requirement")
+	}
+	if _, found = cache.Get("2026.11.01", output.Purl, output.Requirement); found {
+		t.Errorf("Get() expected a miss for a new KB snapshot")
+	}
+	if backend.Len() != 1 {
+		t.Errorf("Get() expected the entries of other generations to be left in the (shared) backend, got %v entries", backend.Len())
+	}
+}
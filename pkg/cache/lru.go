@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRUBackend keeps cache entries in memory, evicting the least recently used ones beyond its capacity
+// (lost on restart, and not shared between instances).
+type LRUBackend struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List               // Most recently used first
+	entries  map[string]*list.Element // Elements hold *lruEntry values
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time // Zero if the entry never expires
+}
+
+// NewLRUBackend creates an empty in-memory LRU backend holding up to capacity entries.
+func NewLRUBackend(capacity int) *LRUBackend {
+	return &LRUBackend{capacity: max(capacity, 1), order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (b *LRUBackend) Get(key string) ([]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	element, ok := b.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		b.order.Remove(element)
+		delete(b.entries, key)
+		return nil, false, nil
+	}
+	b.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (b *LRUBackend) Set(key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	if element, ok := b.entries[key]; ok {
+		element.Value = &lruEntry{key: key, value: value, expires: expires}
+		b.order.MoveToFront(element)
+		return nil
+	}
+	b.entries[key] = b.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for b.order.Len() > b.capacity {
+		oldest := b.order.Back()
+		b.order.Remove(oldest)
+		delete(b.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of entries held (including expired ones not yet evicted).
+func (b *LRUBackend) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.order.Len()
+}
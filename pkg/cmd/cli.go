@@ -16,4 +16,400 @@
 
 package cmd
 
-// TODO Add CLI code processing here
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golobby/config/v3"
+	"github.com/golobby/config/v3/pkg/feeder"
+	"github.com/package-url/packageurl-go"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/kbimport"
+	"scanoss.com/dependencies/pkg/models"
+	"scanoss.com/dependencies/pkg/transdep"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// cliCommand describes a CLI sub-command.
+type cliCommand struct {
+	description string
+	run         func(args []string) error
+}
+
+// cliCommands lists the sub-commands supported by the CLI.
+var cliCommands = map[string]cliCommand{
+	"init-sqlite": {description: "Create an empty SQLite knowledge base", run: runInitSQLite},
+	"migrate":     {description: "Apply pending knowledge base schema migrations", run: runMigrate},
+	"import":      {description: "Import a JSONL/CSV dump into a knowledge base table", run: runImport},
+	"snapshot":    {description: "Show or set the knowledge base snapshot marker", run: runSnapshot},
+	"graph":       {description: "Export the transitive dependency graph of a set of purls", run: runGraph},
+}
+
+// RunCli runs the Dependency CLI with the given command line arguments (excluding the program name).
+func RunCli(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printCliUsage()
+		return nil
+	}
+	command, ok := cliCommands[args[0]]
+	if !ok {
+		printCliUsage()
+		return fmt.Errorf("unknown command: %v", args[0])
+	}
+	return command.run(args[1:])
+}
+
+// printCliUsage prints the list of supported sub-commands.
+func printCliUsage() {
+	fmt.Println("Usage: scanoss-dependencies-cli <command> [options]")
+	fmt.Println("Commands:")
+	names := make([]string, 0, len(cliCommands))
+	for name := range cliCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-15s %v\n", name, cliCommands[name].description)
+	}
+}
+
+// runInitSQLite creates (or completes) the knowledge base schema in an SQLite database file.
+func runInitSQLite(args []string) error {
+	flags := flag.NewFlagSet("init-sqlite", flag.ContinueOnError)
+	dbFile := flags.String("db", "", "SQLite database file to initialise")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(*dbFile) == 0 {
+		return errors.New("please specify the SQLite database file (-db)")
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	cfg.Database.Driver = string(models.DialectSQLite)
+	cfg.Database.Dsn = *dbFile
+	db, err := models.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	if _, err = models.MigrateSchema(context.Background(), db); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "SQLite knowledge base ready: %v\n", *dbFile)
+	return nil
+}
+
+// addConfigFlags registers the flags selecting the knowledge base to use, and returns a function loading the resulting config.
+func addConfigFlags(flags *flag.FlagSet) func() (*myconfig.ServerConfig, error) {
+	jsonConfig := flags.String("json-config", "", "Application JSON config")
+	envConfig := flags.String("env-config", "", "Application dot-ENV config")
+	dbFile := flags.String("db", "", "SQLite database file to use (instead of the configured database)")
+	return func() (*myconfig.ServerConfig, error) {
+		var feeders []config.Feeder
+		if len(*jsonConfig) > 0 {
+			feeders = append(feeders, feeder.Json{Path: *jsonConfig})
+		}
+		if len(*envConfig) > 0 {
+			feeders = append(feeders, feeder.DotEnv{Path: *envConfig})
+		}
+		cfg, err := myconfig.NewServerConfig(feeders)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %v", err)
+		}
+		if len(*dbFile) > 0 {
+			cfg.Database.Driver = string(models.DialectSQLite)
+			cfg.Database.Dsn = *dbFile
+		}
+		return cfg, nil
+	}
+}
+
+// runMigrate applies any pending schema migration to the configured knowledge base (or reports its status).
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	loadConfig := addConfigFlags(flags)
+	status := flags.Bool("status", false, "Only report the applied and expected schema versions")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	db, err := models.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	ctx := context.Background()
+	if !*status {
+		applied, err := models.MigrateSchema(ctx, db)
+		for _, migration := range applied {
+			_, _ = fmt.Fprintf(os.Stdout, "Applied migration %v\n", migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	current, err := models.GetSchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	expected, err := models.ExpectedSchemaVersion(models.GetDBDialect(db))
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "Schema version: %v (expected: %v)\n", current, expected)
+	return nil
+}
+
+// runImport streams a JSONL/CSV dump into a knowledge base table, recording its progress in a checkpoint file.
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	loadConfig := addConfigFlags(flags)
+	table := flags.String("table", "", "Table to import into: "+strings.Join(models.GetImportTargets(), ", "))
+	file := flags.String("file", "", "Dump file to import (.jsonl or .csv, optionally gzip compressed)")
+	format := flags.String("format", "", "Dump format: jsonl or csv (default: from the file extension)")
+	batchSize := flags.Int("batch-size", kbimport.DefaultBatchSize, "Records written per transaction")
+	resume := flags.Bool("resume", false, "Resume a previous import from its checkpoint")
+	checkpointFile := flags.String("checkpoint", "", "Checkpoint file (default: <file>.checkpoint)")
+	skipInvalid := flags.Bool("skip-invalid", false, "Reject invalid records instead of aborting the import")
+	dryRun := flags.Bool("dry-run", false, "Only validate the dump, without writing to the database")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(*table) == 0 || len(*file) == 0 {
+		return errors.New("please specify the table (-table) and dump file (-file) to import")
+	}
+	if len(*format) == 0 {
+		*format = kbimport.FormatJSONL
+		if strings.HasSuffix(strings.TrimSuffix(strings.ToLower(*file), ".gz"), ".csv") {
+			*format = kbimport.FormatCSV
+		}
+	}
+	if len(*checkpointFile) == 0 {
+		*checkpointFile = *file + ".checkpoint"
+	}
+	options := kbimport.Options{Table: *table, Format: *format, BatchSize: *batchSize, SkipInvalid: *skipInvalid, DryRun: *dryRun}
+	if *resume {
+		checkpoint, err := kbimport.LoadCheckpoint(*checkpointFile)
+		if err != nil {
+			return err
+		}
+		if len(checkpoint.Table) > 0 && checkpoint.Table != *table {
+			return fmt.Errorf("checkpoint %v belongs to an import into %v, not %v", *checkpointFile, checkpoint.Table, *table)
+		}
+		options.Skip = checkpoint.Records
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err = zlog.SetupAppLogger(cfg.App.Mode, cfg.Logging.ConfigFile, cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := models.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	ctx := context.Background()
+	if err = verifySchemaVersion(ctx, db); err != nil {
+		return err
+	}
+	input, err := openDump(*file)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	importer, err := kbimport.NewImporter(ctx, zlog.S, db, options)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	progress, err := importer.Import(input, func(progress kbimport.Progress) {
+		_, _ = fmt.Fprintf(os.Stderr, "%v: %v records read, %v validated, %v imported, %v rejected (%v)\n",
+			*table, progress.Records, progress.Validated, progress.Imported, progress.Rejected, time.Since(start).Round(time.Second))
+		if !*dryRun {
+			checkpoint := kbimport.Checkpoint{Table: *table, File: *file, Records: progress.Records}
+			if cpErr := kbimport.SaveCheckpoint(*checkpointFile, checkpoint); cpErr != nil {
+				zlog.S.Warnf("Failed to save the import checkpoint: %v", cpErr)
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("import stopped after %v records (rerun with -resume to continue): %w", progress.Records, err)
+	}
+	if *dryRun {
+		_, _ = fmt.Fprintf(os.Stdout, "Dry run: validated %v records for %v (%v rejected), nothing was written\n",
+			progress.Validated, *table, progress.Rejected)
+		return nil
+	}
+	_ = os.Remove(*checkpointFile) // The import is complete, so there is nothing left to resume
+	_, _ = fmt.Fprintf(os.Stdout, "Imported %v records into %v (%v rejected)\n", progress.Imported, *table, progress.Rejected)
+	return nil
+}
+
+// runSnapshot reports the snapshot marker of the configured knowledge base (or records a new one).
+func runSnapshot(args []string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	loadConfig := addConfigFlags(flags)
+	set := flags.String("set", "", "Snapshot marker to record (i.e. 2026.10.01)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err = zlog.SetupAppLogger(cfg.App.Mode, cfg.Logging.ConfigFile, cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	db, err := models.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	ctx := context.Background()
+	if err = verifySchemaVersion(ctx, db); err != nil {
+		return err
+	}
+	model := models.NewKBMetadataModel(ctx, zlog.S, db)
+	if len(*set) > 0 {
+		if err = model.SetSnapshot(*set); err != nil {
+			return err
+		}
+	}
+	snapshot, err := model.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	if len(snapshot) == 0 {
+		snapshot = "(not set)"
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "Knowledge base snapshot: %v\n", snapshot)
+	return nil
+}
+
+// runGraph collects the transitive dependency graph of the given purls (with an optional version or requirement),
+// and writes it in the selected export format.
+func runGraph(args []string) error {
+	flags := flag.NewFlagSet("graph", flag.ContinueOnError)
+	loadConfig := addConfigFlags(flags)
+	format := flags.String("format", transdep.ExportFormatDOT, "Export format: "+strings.Join(transdep.ExportFormats, ", "))
+	depth := flags.Int("depth", 0, "Maximum depth of the graph (default: from the config)")
+	limit := flags.Int("limit", 0, "Maximum number of dependencies in the graph (default: from the config)")
+	outputFile := flags.String("output", "", "File to write the graph to (default: stdout)")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "Usage: scanoss-dependencies-cli graph [options] <purl[@requirement]>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if !slices.Contains(transdep.ExportFormats, *format) {
+		return fmt.Errorf("unsupported export format %v, expected one of: %v", *format, strings.Join(transdep.ExportFormats, ", "))
+	}
+	dto, err := toGraphRequest(flags.Args())
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err = zlog.SetupAppLogger(cfg.App.Mode, cfg.Logging.ConfigFile, cfg.App.Debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	depthLimit := transdep.GetMaxLimit(cfg.TransitiveResources.MaxDepth, cfg.TransitiveResources.DefaultDepth, depth)
+	responseLimit := transdep.GetMaxLimit(cfg.TransitiveResources.MaxResponseSize, cfg.TransitiveResources.DefaultResponseSize, limit)
+	dto.Depth, dto.Limit = &depthLimit, &responseLimit
+	db, err := models.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	ctx := context.Background()
+	if err = verifySchemaVersion(ctx, db); err != nil {
+		return err
+	}
+	export, err := usecase.NewTransitiveDependencies(ctx, zlog.S, db, cfg).ExportTransitiveDependencies(zlog.S, dto)
+	if err != nil {
+		return err
+	}
+	if export.KBSnapshot, err = models.NewKBMetadataModel(ctx, zlog.S, db).GetSnapshot(); err != nil {
+		return err
+	}
+	output := io.Writer(os.Stdout)
+	if len(*outputFile) > 0 {
+		f, err := os.Create(*outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create %v: %v", *outputFile, err)
+		}
+		defer f.Close()
+		output = f
+	}
+	return export.Write(output, *format)
+}
+
+// toGraphRequest converts purls (with an optional version or requirement, i.e. "pkg:npm/scanoss@^0.15.0")
+// of a single ecosystem into a transitive dependency request.
+func toGraphRequest(purls []string) (dtos.TransitiveDependencyDTO, error) {
+	if len(purls) == 0 {
+		return dtos.TransitiveDependencyDTO{}, errors.New("please specify the purls to export the graph of")
+	}
+	var dto dtos.TransitiveDependencyDTO
+	for _, purl := range purls {
+		p, err := packageurl.FromString(purl)
+		if err != nil {
+			return dtos.TransitiveDependencyDTO{}, fmt.Errorf("invalid purl %v: %v", purl, err)
+		}
+		if len(dto.Ecosystem) > 0 && p.Type != dto.Ecosystem {
+			return dtos.TransitiveDependencyDTO{}, fmt.Errorf("purls of a single ecosystem expected, got %v and %v", dto.Ecosystem, p.Type)
+		}
+		dto.Ecosystem = p.Type
+		requirement := p.Version
+		p.Version = ""
+		dto.Components = append(dto.Components, componenthelper.ComponentDTO{Purl: p.ToString(), Requirement: requirement})
+	}
+	return dto, nil
+}
+
+// openDump opens the given dump file, decompressing it if required.
+func openDump(file string) (io.ReadCloser, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v: %v", file, err)
+	}
+	if !strings.HasSuffix(strings.ToLower(file), ".gz") {
+		return f, nil
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to decompress %v: %v", file, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{gz, f}, nil
+}
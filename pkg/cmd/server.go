@@ -19,24 +19,30 @@ package cmd
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/golobby/config/v3"
 	"github.com/golobby/config/v3/pkg/feeder"
+	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/scanoss/go-grpc-helper/pkg/files"
 	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
 	gs "github.com/scanoss/go-grpc-helper/pkg/grpc/server"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	_ "modernc.org/sqlite"
+	"scanoss.com/dependencies/pkg/cache"
 	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/models"
 	"scanoss.com/dependencies/pkg/protocol/grpc"
 	"scanoss.com/dependencies/pkg/protocol/rest"
 	"scanoss.com/dependencies/pkg/service"
+	"scanoss.com/dependencies/pkg/usecase"
 )
 
 //go:generate bash ../../get_version.sh
@@ -77,6 +83,34 @@ func getConfig() (*myconfig.ServerConfig, error) {
 	return myConfig, err
 }
 
+// checkDBSchema applies any pending schema migration (if requested) and verifies the schema version is the expected one.
+func checkDBSchema(db *sqlx.DB, cfg *myconfig.ServerConfig) error {
+	ctx := context.Background()
+	if cfg.Database.Migrate {
+		applied, err := models.MigrateSchema(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range applied {
+			zlog.S.Infof("Applied schema migration: %v", migration.Name)
+		}
+	}
+	if !cfg.Database.Check {
+		return nil
+	}
+	return verifySchemaVersion(ctx, db)
+}
+
+// verifySchemaVersion checks the schema version, only warning about databases that predate schema migrations.
+func verifySchemaVersion(ctx context.Context, db *sqlx.DB) error {
+	err := models.CheckSchemaVersion(ctx, db)
+	if errors.Is(err, models.ErrSchemaUntracked) {
+		zlog.S.Warnf("Skipping schema version check: %v", err)
+		return nil
+	}
+	return err
+}
+
 // RunServer runs the gRPC Dependency Server.
 func RunServer() error {
 	// Load command line options and config
@@ -102,24 +136,49 @@ func RunServer() error {
 	}
 	zlog.S.Infof("Starting SCANOSS Dependency Service: %v", strings.TrimSpace(version))
 	// Setup database connection pool
-	db, err := gd.OpenDBConnection(cfg.Database.Dsn, cfg.Database.Driver, cfg.Database.User, cfg.Database.Passwd,
-		cfg.Database.Host, cfg.Database.Schema, cfg.Database.SslMode)
+	db, err := models.OpenDB(cfg)
 	if err != nil {
 		return err
 	}
-	if err = gd.SetDBOptionsAndPing(db); err != nil {
+	if err = models.SetDBOptions(db, cfg); err != nil {
 		return err
 	}
 	defer gd.CloseDBConnection(db)
+	if err = checkDBSchema(db, cfg); err != nil {
+		return err
+	}
 	// Setup dynamic logging (if necessary)
 	zlog.SetupAppDynamicLogging(cfg.Logging.DynamicPort, cfg.Logging.DynamicLogging)
-	// Register the dependency service
-	v2API := service.NewDependencyServer(db, cfg)
 	ctx := context.Background()
+	// Cache the reference tables (if requested)
+	if cfg.ReferenceCache.Enabled {
+		_, err = models.StartReferenceCache(ctx, zlog.S, db, models.ReferenceCacheOptions{
+			Preload:         cfg.ReferenceCache.Preload,
+			RefreshInterval: time.Duration(cfg.ReferenceCache.Refresh) * time.Minute,
+			MaxVersions:     cfg.ReferenceCache.MaxVersions,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// Cache decorated components (if requested)
+	if cfg.ResponseCache.Enabled {
+		usecase.SetDecorationCache(cache.NewDecorationCache(zlog.S, cache.NewLRUBackend(cfg.ResponseCache.Size),
+			time.Duration(cfg.ResponseCache.TTL)*time.Minute))
+	}
+	// Register the dependency service
+	// All requests (gRPC and REST) share the same decoration slots
+	pool := usecase.NewDecorationPool(cfg.Components.MaxWorkers)
+	v2API := service.NewDependencyServer(db, cfg, pool)
 	// Start the REST grpc-gateway if requested
 	var srv *http.Server
 	if len(cfg.App.RESTPort) > 0 {
-		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, allowedIPs, deniedIPs, startTLS); err != nil {
+		jobManager, err := service.NewTransitiveJobManager(ctx, db, cfg)
+		if err != nil {
+			return err
+		}
+		httpAPI := service.NewDependencyHTTPServer(db, cfg, jobManager, pool)
+		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, allowedIPs, deniedIPs, startTLS, httpAPI); err != nil {
 			return err
 		}
 	}
@@ -54,10 +54,44 @@ type ServerConfig struct {
 		Schema  string `env:"DB_SCHEMA"`
 		SslMode string `env:"DB_SSL_MODE"` // enable/disable
 		Dsn     string `env:"DB_DSN"`
-		Trace   bool   `env:"DB_TRACE"` // true/false
+		Trace   bool   `env:"DB_TRACE"`        // true/false
+		Migrate bool   `env:"DB_MIGRATE"`      // Apply any pending schema migration on startup
+		Check   bool   `env:"DB_SCHEMA_CHECK"` // Refuse to start if the schema is not the expected version
+	}
+	Curation struct {
+		File string `env:"CURATION_FILE"` // JSON file of curations applied on top of the KB (in addition to the curations table)
+	}
+	PrivatePackages struct {
+		File string `env:"PRIVATE_PACKAGES_FILE"` // JSON file of internal packages served instead of the KB for their purl prefixes
 	}
 	Components struct {
-		CommitMissing bool `env:"COMP_COMMIT_MISSING"` // Write component details to the DB if they are looked up live
+		CommitMissing  bool `env:"COMP_COMMIT_MISSING"`   // Write component details to the DB if they are looked up live
+		StreamChunkMax int  `env:"COMP_STREAM_CHUNK_MAX"` // Maximum purls in each chunk of a streamed decoration request
+		Workers        int  `env:"COMP_WORKERS"`          // Components decorated concurrently for each request
+		MaxWorkers     int  `env:"COMP_MAX_WORKERS"`      // Components decorated concurrently across all requests
+	}
+	ReferenceCache struct {
+		Enabled     bool `env:"REF_CACHE_ENABLED"`      // Cache the licenses, versions and mines tables in memory
+		Preload     bool `env:"REF_CACHE_PRELOAD"`      // Load the licenses and mines tables into the cache on startup
+		Refresh     int  `env:"REF_CACHE_REFRESH"`      // Minutes between cache invalidations (0 to never invalidate automatically)
+		MaxVersions int  `env:"REF_CACHE_MAX_VERSIONS"` // Versions cached before the version cache is emptied (0 for no limit)
+		Admin       bool `env:"REF_CACHE_ADMIN"`        // Serve the unauthenticated cache invalidation endpoint
+	}
+	ResponseCache struct {
+		Enabled bool `env:"RESPONSE_CACHE_ENABLED"` // Cache decorated components (keyed by purl, requirement and KB snapshot)
+		Size    int  `env:"RESPONSE_CACHE_SIZE"`    // Decorated components kept in the in-memory LRU cache
+		TTL     int  `env:"RESPONSE_CACHE_TTL"`     // Minutes a decorated component is cached for (0 to keep it until evicted)
+	}
+	LiveMetadata struct {
+		Enabled      bool   `env:"LIVE_METADATA_ENABLED"`        // Query upstream registries for components without license data (pkg.go.dev is always queried)
+		Offline      bool   `env:"LIVE_METADATA_OFFLINE"`        // Air-gapped mode: never contact any upstream registry (including pkg.go.dev)
+		Timeout      int    `env:"LIVE_METADATA_TIMEOUT"`        // Timeout in seconds for each upstream registry request
+		PkgGoDevURL  string `env:"LIVE_METADATA_PKG_GO_DEV_URL"` // Base URL of pkg.go.dev (Go modules)
+		NpmURL       string `env:"LIVE_METADATA_NPM_URL"`        // Base URL of the npm registry
+		MavenURL     string `env:"LIVE_METADATA_MAVEN_URL"`      // Base URL of the Maven Central repository
+		CratesURL    string `env:"LIVE_METADATA_CRATES_URL"`     // Base URL of crates.io
+		PackagistURL string `env:"LIVE_METADATA_PACKAGIST_URL"`  // Base URL of the Packagist repository
+		RubyGemsURL  string `env:"LIVE_METADATA_RUBYGEMS_URL"`   // Base URL of RubyGems
 	}
 	TLS struct {
 		CertFile string `env:"DEPS_TLS_CERT"` // TLS Certificate
@@ -92,6 +126,15 @@ type ServerConfig struct {
 		// Timeout in seconds
 		TimeOut int `env:"TRANSITIVE_RESOURCES_TIMEOUT"`
 	}
+	TransitiveJobs struct {
+		Workers   int    `env:"TRANSITIVE_JOBS_WORKERS"`    // Background workers running asynchronous transitive jobs
+		QueueSize int    `env:"TRANSITIVE_JOBS_QUEUE_SIZE"` // Jobs waiting for a worker before new submissions are rejected
+		TimeOut   int    `env:"TRANSITIVE_JOBS_TIMEOUT"`    // Timeout in seconds of the dependency collection of each job
+		Store     string `env:"TRANSITIVE_JOBS_STORE"`      // Job state store: memory or sql (transitive_jobs table)
+		Retention int    `env:"TRANSITIVE_JOBS_RETENTION"`  // Minutes finished jobs (and their results) are kept for
+		Instance  string `env:"TRANSITIVE_JOBS_INSTANCE"`   // Identifier of this replica in a shared job store (random if empty)
+		Lease     int    `env:"TRANSITIVE_JOBS_LEASE"`      // Seconds an unfinished job is kept without a heartbeat from its replica
+	}
 }
 
 // NewServerConfig loads all config options and return a struct for use.
@@ -125,7 +168,29 @@ func setServerConfigDefaults(cfg *ServerConfig) {
 	cfg.Database.Schema = "scanoss"
 	cfg.Database.SslMode = "disable"
 	cfg.Database.Trace = false
+	cfg.Database.Migrate = false
+	cfg.Database.Check = true
 	cfg.Components.CommitMissing = false
+	cfg.Components.StreamChunkMax = 1000
+	cfg.Components.Workers = 5
+	cfg.Components.MaxWorkers = 50
+	cfg.ReferenceCache.Enabled = false
+	cfg.ReferenceCache.Preload = false
+	cfg.ReferenceCache.Refresh = 60
+	cfg.ReferenceCache.MaxVersions = 100000
+	cfg.ReferenceCache.Admin = false
+	cfg.ResponseCache.Enabled = false
+	cfg.ResponseCache.Size = 10000
+	cfg.ResponseCache.TTL = 60
+	cfg.LiveMetadata.Enabled = false
+	cfg.LiveMetadata.Offline = false
+	cfg.LiveMetadata.Timeout = 10
+	cfg.LiveMetadata.PkgGoDevURL = "https://pkg.go.dev"
+	cfg.LiveMetadata.NpmURL = "https://registry.npmjs.org"
+	cfg.LiveMetadata.MavenURL = "https://repo1.maven.org/maven2"
+	cfg.LiveMetadata.CratesURL = "https://crates.io"
+	cfg.LiveMetadata.PackagistURL = "https://repo.packagist.org"
+	cfg.LiveMetadata.RubyGemsURL = "https://rubygems.org"
 	cfg.Logging.DynamicLogging = true
 	cfg.Logging.DynamicPort = "localhost:60051"
 	cfg.Telemetry.Enabled = false
@@ -137,4 +202,11 @@ func setServerConfigDefaults(cfg *ServerConfig) {
 	cfg.TransitiveResources.TimeOut = 600
 	cfg.TransitiveResources.MaxDepth = 10
 	cfg.TransitiveResources.DefaultDepth = 3
+	cfg.TransitiveJobs.Workers = 2
+	cfg.TransitiveJobs.QueueSize = 100
+	cfg.TransitiveJobs.TimeOut = 3600
+	cfg.TransitiveJobs.Store = "memory"
+	cfg.TransitiveJobs.Retention = 1440
+	cfg.TransitiveJobs.Instance = ""
+	cfg.TransitiveJobs.Lease = 300
 }
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// ComponentVersionsInput selects a page of the versions of a component, optionally limited to a version constraint.
+type ComponentVersionsInput struct {
+	Purl       string `json:"purl"`       // Purl of the component (any version in it is ignored)
+	Constraint string `json:"constraint"` // Version range (i.e. ^1.2.0 or >=1.0.0,<2.0.0). Empty matches all versions
+	Page       int    `json:"page"`       // 1-based page number
+	PageSize   int    `json:"page_size"`
+}
+
+// ComponentVersionsOutput holds a page of the versions of a component, newest first.
+type ComponentVersionsOutput struct {
+	Purl       string                   `json:"purl"`
+	Versions   []ComponentVersionOutput `json:"versions"`
+	Total      int                      `json:"total"` // Number of versions matching the constraint (across all pages)
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	KBSnapshot string                   `json:"kb_snapshot,omitempty"`
+	Status     StatusOutput             `json:"status"`
+}
+
+// ComponentVersionOutput holds the details of one version of a component.
+type ComponentVersionOutput struct {
+	Version         string              `json:"version"`
+	Date            string              `json:"date,omitempty"` // Release date (if known)
+	Licenses        []DependencyLicense `json:"licenses,omitempty"`
+	HasDependencies bool                `json:"has_dependencies"`
+}
@@ -28,8 +28,10 @@ import (
 
 // DependencyInput deprecated.
 type DependencyInput struct {
-	Files []DependencyFileInput `json:"files"`
-	Depth int                   `json:"depth"`
+	Files           []DependencyFileInput `json:"files"`
+	Depth           int                   `json:"depth"`
+	Outdated        bool                  `json:"outdated,omitempty"`         // Report how far each dependency is behind the latest versions
+	LicenseWarnings bool                  `json:"license_warnings,omitempty"` // Warn when a license differs from the latest version's
 }
 
 // DependencyFileInput deprecated.
@@ -43,6 +45,15 @@ type TransitiveDependencyDTO struct {
 	Ecosystem  string                         `json:"ecosystem,omitempty"`
 	Components []componenthelper.ComponentDTO `json:"components"`
 	Limit      *int                           `json:"limit,omitempty"`
+	Pins       []DependencyPinDTO             `json:"pins,omitempty"`
+}
+
+// DependencyPinDTO pins the packages matching a purl pattern (i.e. "pkg:npm/@types/*") to a version or constraint,
+// or excludes them, wherever they appear below the requested components.
+type DependencyPinDTO struct {
+	Purl    string `json:"purl"`
+	Version string `json:"version,omitempty"`
+	Exclude bool   `json:"exclude,omitempty"`
 }
 
 type DependencyJobDTO struct {
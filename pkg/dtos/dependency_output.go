@@ -37,14 +37,41 @@ type DependencyFileOutput struct {
 }
 
 type DependenciesOutput struct {
-	Component   string              `json:"component"`
-	Purl        string              `json:"purl"`
-	Version     string              `json:"version"`
-	Requirement string              `json:"requirement"`
-	URL         string              `json:"url"`
-	Comment     string              `json:"comment"`
-	Licenses    []DependencyLicense `json:"licenses"`
-	Status      domain.ComponentStatus
+	Component      string                `json:"component"`
+	Purl           string                `json:"purl"`
+	Version        string                `json:"version"`
+	Requirement    string                `json:"requirement"`
+	URL            string                `json:"url"`
+	Comment        string                `json:"comment"`
+	Licenses       []DependencyLicense   `json:"licenses"`
+	Outdated       *OutdatedOutput       `json:"outdated,omitempty"`
+	LicenseWarning *LicenseWarningOutput `json:"license_warning,omitempty"`
+	Status         domain.ComponentStatus
+}
+
+// OutdatedOutput reports how far a dependency is behind the latest (non pre-release) versions known to the KB.
+type OutdatedOutput struct {
+	Latest           string `json:"latest"`
+	LatestSatisfying string `json:"latest_satisfying,omitempty"` // Latest version satisfying the requirement (if any)
+	Outdated         bool   `json:"outdated"`
+	MajorsBehind     int    `json:"majors_behind"`  // Newer major versions
+	MinorsBehind     int    `json:"minors_behind"`  // Newer minor versions of the same major version
+	PatchesBehind    int    `json:"patches_behind"` // Newer patch versions of the same minor version
+}
+
+// LicenseWarningOutput reports a dependency whose license differs from the license of the latest version of its component.
+type LicenseWarningOutput struct {
+	LatestVersion  string              `json:"latest_version"`
+	LatestLicenses []DependencyLicense `json:"latest_licenses"`
+	Message        string              `json:"message"`
+}
+
+// DependencyDecorationOutput is the response of the REST-only decoration endpoint, carrying the details
+// (i.e. the outdated version report and license warnings) the gRPC dependency response has no fields for.
+type DependencyDecorationOutput struct {
+	Files      []DependencyFileOutput `json:"files"`
+	KBSnapshot string                 `json:"kb_snapshot,omitempty"`
+	Status     StatusOutput           `json:"status"`
 }
 
 type DependencyLicense struct {
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// LicenseChangesInput selects the versions of a component to check for license changes, by version range and/or
+// from/to versions (both inclusive). Empty values match all versions.
+type LicenseChangesInput struct {
+	Purl       string `json:"purl"` // Purl of the component (any version in it is ignored)
+	Constraint string `json:"constraint"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+// LicenseChangesOutput lists the versions of a component whose license differs from the previous version's, oldest first.
+type LicenseChangesOutput struct {
+	Purl       string                `json:"purl"`
+	Versions   int                   `json:"versions"` // Number of versions with a known license compared
+	Changes    []LicenseChangeOutput `json:"changes"`
+	KBSnapshot string                `json:"kb_snapshot,omitempty"`
+	Status     StatusOutput          `json:"status"`
+}
+
+// LicenseChangeOutput holds a change of license between two consecutive versions of a component.
+type LicenseChangeOutput struct {
+	Version          string              `json:"version"`
+	Date             string              `json:"date,omitempty"` // Release date of the version (if known)
+	Licenses         []DependencyLicense `json:"licenses"`
+	PreviousVersion  string              `json:"previous_version"`
+	PreviousLicenses []DependencyLicense `json:"previous_licenses"`
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// ReferenceCacheOutput reports the contents of the reference table cache (licenses, versions and mines).
+type ReferenceCacheOutput struct {
+	Licenses  int          `json:"licenses"`
+	Versions  int          `json:"versions"`
+	PurlTypes int          `json:"purl_types"`
+	LoadedAt  string       `json:"loaded_at"` // RFC 3339 time the cache was last (re)loaded
+	Status    StatusOutput `json:"status"`
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// TransitiveDependencyOutput is the detailed result of a transitive dependency collection.
+type TransitiveDependencyOutput struct {
+	Dependencies       []TransitiveComponentOutput `json:"dependencies"`
+	Resolved           []TransitiveComponentOutput `json:"resolved"`
+	ResolutionStrategy string                      `json:"resolution_strategy"`
+	Unresolved         []UnresolvedComponentOutput `json:"unresolved"`
+	KBSnapshot         string                      `json:"kb_snapshot,omitempty"` // Snapshot marker of the knowledge base used
+	Status             StatusOutput                `json:"status"`
+}
+
+// TransitiveComponentOutput describes a single node of the transitive dependency graph.
+type TransitiveComponentOutput struct {
+	Purl        string `json:"purl"`
+	Version     string `json:"version"`
+	Requirement string `json:"requirement,omitempty"`
+	Curated     bool   `json:"curated,omitempty"` // Added by a curation
+}
+
+// UnresolvedComponentOutput describes a declared dependency whose requirement could not be resolved.
+type UnresolvedComponentOutput struct {
+	Purl        string                    `json:"purl"`
+	Requirement string                    `json:"requirement"`
+	Reason      string                    `json:"reason"`
+	Parent      TransitiveComponentOutput `json:"parent"`
+}
+
+// StatusOutput is the request status returned by REST-only endpoints.
+type StatusOutput struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
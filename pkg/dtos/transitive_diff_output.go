@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// TransitiveDiffOutput holds the differences between the transitive dependencies resolved for a "before" and
+// an "after" set of components.
+type TransitiveDiffOutput struct {
+	Added          []TransitiveDiffComponentOutput `json:"added"`
+	Removed        []TransitiveDiffComponentOutput `json:"removed"`
+	Changed        []TransitiveDiffComponentOutput `json:"changed"`         // Packages resolved to other versions
+	LicenseChanges int                             `json:"license_changes"` // Changed packages whose licenses changed
+	Complete       bool                            `json:"complete"`        // False if either collection was cut short (i.e. by the response limit)
+	KBSnapshot     string                          `json:"kb_snapshot,omitempty"`
+	Status         StatusOutput                    `json:"status"`
+}
+
+// TransitiveDiffComponentOutput describes a package added, removed or changed between the two sets of dependencies.
+type TransitiveDiffComponentOutput struct {
+	Purl           string                        `json:"purl"`
+	Before         []TransitiveDiffVersionOutput `json:"before,omitempty"`
+	After          []TransitiveDiffVersionOutput `json:"after,omitempty"`
+	LicenseChanged bool                          `json:"license_changed,omitempty"`
+}
+
+// TransitiveDiffVersionOutput holds a version of a package in one of the sets, with its licenses.
+type TransitiveDiffVersionOutput struct {
+	Version  string              `json:"version"`
+	Licenses []DependencyLicense `json:"licenses"`
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+import "time"
+
+// TransitiveJobOutput describes the state of an asynchronous transitive dependency job.
+type TransitiveJobOutput struct {
+	JobID           string                   `json:"job_id"`
+	State           string                   `json:"state"`
+	Progress        TransitiveProgressOutput `json:"progress"`
+	Error           string                   `json:"error,omitempty"`
+	CancelRequested bool                     `json:"cancel_requested,omitempty"` // Waiting for the instance running the job
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+	Status          StatusOutput             `json:"status"`
+}
+
+// TransitiveProgressOutput reports how far the dependency collection of a job has gone.
+type TransitiveProgressOutput struct {
+	NodesExplored int `json:"nodes_explored"`
+	QueueDepth    int `json:"queue_depth"`
+}
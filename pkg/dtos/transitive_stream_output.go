@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// TransitiveStreamOutput is one message of a streamed transitive dependency collection.
+// Exactly one of Node, Edge, Unresolved or Summary is set, matching its Type.
+type TransitiveStreamOutput struct {
+	Type       string                     `json:"type"` // node, edge, unresolved or summary
+	Node       *TransitiveComponentOutput `json:"node,omitempty"`
+	Edge       *TransitiveEdgeOutput      `json:"edge,omitempty"`
+	Unresolved *UnresolvedComponentOutput `json:"unresolved,omitempty"`
+	Summary    *TransitiveSummaryOutput   `json:"summary,omitempty"`
+}
+
+// TransitiveEdgeOutput links a node of the transitive dependency graph to one of its dependencies.
+type TransitiveEdgeOutput struct {
+	From TransitiveComponentOutput `json:"from"`
+	To   TransitiveComponentOutput `json:"to"`
+}
+
+// TransitiveSummaryOutput is the final message of a streamed transitive dependency collection.
+type TransitiveSummaryOutput struct {
+	Completion         string                      `json:"completion"` // complete, limit_reached, timed_out or cancelled
+	Dependencies       int                         `json:"dependencies"`
+	Unresolved         int                         `json:"unresolved"`
+	Resolved           []TransitiveComponentOutput `json:"resolved"`
+	ResolutionStrategy string                      `json:"resolution_strategy"`
+	KBSnapshot         string                      `json:"kb_snapshot,omitempty"`
+	Status             StatusOutput                `json:"status"`
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// UpgradeImpactOutput holds the differences between the transitive dependencies resolved for a set of components,
+// and those resolved with a set of packages pinned (overridden) to other versions, with the resulting policy impact.
+type UpgradeImpactOutput struct {
+	TransitiveDiffOutput
+	Overrides    []OverrideOutput   `json:"overrides"`
+	PolicyImpact PolicyImpactOutput `json:"policy_impact"`
+}
+
+// OverrideOutput describes an override requested for the simulation.
+type OverrideOutput struct {
+	Purl     string `json:"purl"`
+	Override string `json:"override"` // Version or constraint
+	Applied  bool   `json:"applied"`  // True if the package was found in the simulated graph
+}
+
+// PolicyImpactOutput holds the license changes across the whole resolved graph caused by the overrides.
+type PolicyImpactOutput struct {
+	NewLicenses          []string                `json:"new_licenses"`     // Licenses only found with the overrides
+	RemovedLicenses      []string                `json:"removed_licenses"` // Licenses no longer found with the overrides
+	IntroducedViolations []PolicyViolationOutput `json:"introduced_violations"`
+	ResolvedViolations   []PolicyViolationOutput `json:"resolved_violations"`
+}
+
+// PolicyViolationOutput describes a resolved package version under a denied license.
+type PolicyViolationOutput struct {
+	Purl    string `json:"purl"`
+	Version string `json:"version"`
+	License string `json:"license"`
+}
@@ -74,6 +74,26 @@ func NewNotFoundError(resource string) *ServiceError {
 	}
 }
 
+// NewConflictError Use for: requests conflicting with the current state of a resource (i.e. a finished job).
+func NewConflictError(message string, err error) *ServiceError {
+	return &ServiceError{
+		Message:      message,
+		HTTPCode:     http.StatusConflict,
+		InternalCode: "CONFLICT",
+		Err:          err,
+	}
+}
+
+// NewPreconditionFailedError Use for: request preconditions not met by the service (i.e. an outdated knowledge base).
+func NewPreconditionFailedError(message string, err error) *ServiceError {
+	return &ServiceError{
+		Message:      message,
+		HTTPCode:     http.StatusPreconditionFailed,
+		InternalCode: "PRECONDITION_FAILED",
+		Err:          err,
+	}
+}
+
 // NewInternalError Use for: unexpected errors, programming errors, unhandled exceptions.
 func NewInternalError(message string, err error) *ServiceError {
 	return &ServiceError{
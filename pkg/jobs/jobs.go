@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package jobs runs long requests (i.e. large transitive resolutions) as asynchronous jobs on a bounded
+// background worker pool, keeping their state in a pluggable store.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// State is the lifecycle state of a job.
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateCompleted State = "completed"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+// Finished reports whether the state is final.
+func (s State) Finished() bool {
+	return s == StateCompleted || s == StateFailed || s == StateCancelled
+}
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrQueueFull    = errors.New("job queue is full")
+	ErrJobFinished  = errors.New("job has already finished")
+	ErrStateChanged = errors.New("job state was changed by another instance")
+)
+
+// progressInterval limits how often the progress of a running job is written to the store.
+const progressInterval = time.Second
+
+// defaultLease is how long an unfinished job is kept by its owner without a heartbeat, unless configured.
+const defaultLease = 5 * time.Minute
+
+// Progress reports how far a running job has gone.
+type Progress struct {
+	NodesExplored int `json:"nodes_explored"` // Graph nodes whose dependencies have been collected
+	QueueDepth    int `json:"queue_depth"`    // Graph nodes waiting to be explored
+}
+
+// Job is a request run in the background.
+type Job struct {
+	ID              string    `json:"job_id"`
+	State           State     `json:"state"`
+	Progress        Progress  `json:"progress"`
+	Error           string    `json:"error,omitempty"`
+	Owner           string    `json:"-"`                          // Instance (manager) that queued and runs the job
+	CancelRequested bool      `json:"cancel_requested,omitempty"` // Cancellation left to the owner instance to carry out
+	Request         []byte    `json:"-"`                          // Request payload, as submitted
+	Result          []byte    `json:"-"`                          // Result payload, once completed
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Runner executes the request of a job, reporting its progress, and returns the result payload.
+// It must stop (returning the context error) once the given context is cancelled.
+type Runner func(ctx context.Context, job Job, progress func(Progress)) ([]byte, error)
+
+// Options controls the worker pool of a job manager.
+type Options struct {
+	Workers   int           // Jobs run concurrently
+	QueueSize int           // Jobs waiting for a worker before new submissions are rejected
+	Retention time.Duration // How long finished jobs are kept (0 keeps them forever)
+	Instance  string        // Identifier of this manager in a shared store (a random one if empty)
+	Lease     time.Duration // How long unfinished jobs are kept without a heartbeat from their owner (defaultLease if 0)
+}
+
+// Manager queues submitted jobs and runs them on a bounded pool of background workers.
+type Manager struct {
+	ctx     context.Context
+	s       *zap.SugaredLogger
+	store   Store
+	runner  Runner
+	options Options
+	queue   chan string
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// NewManager creates a job manager and starts its workers, which stop once the given context is done.
+// Unfinished jobs owned by this instance (i.e. left over by a restart) are marked as failed, while the ones owned by
+// other instances sharing the store are only failed once their owner stops renewing their lease.
+func NewManager(ctx context.Context, s *zap.SugaredLogger, store Store, runner Runner, options Options) (*Manager, error) {
+	if options.Workers <= 0 {
+		options.Workers = 1
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = options.Workers
+	}
+	if options.Lease <= 0 {
+		options.Lease = defaultLease
+	}
+	if len(options.Instance) == 0 {
+		instance, err := newJobID()
+		if err != nil {
+			return nil, err
+		}
+		options.Instance = instance
+	}
+	m := &Manager{ctx: ctx, s: s, store: store, runner: runner, options: options,
+		queue: make(chan string, options.QueueSize), cancels: make(map[string]context.CancelFunc)}
+	if err := m.failAbandoned(true); err != nil {
+		return nil, err
+	}
+	for i := 0; i < options.Workers; i++ {
+		go m.worker()
+	}
+	go m.heartbeat()
+	return m, nil
+}
+
+// Submit queues a new job for the given request payload.
+func (m *Manager) Submit(request []byte) (Job, error) {
+	m.prune()
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, err
+	}
+	now := time.Now().UTC()
+	job := Job{ID: id, State: StateQueued, Owner: m.options.Instance, Request: request, CreatedAt: now, UpdatedAt: now}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err = m.store.Create(job); err != nil {
+		return Job{}, err
+	}
+	select {
+	case m.queue <- id:
+		return job, nil
+	default:
+		if err = m.store.Delete(id); err != nil {
+			m.s.Warnf("Failed to remove rejected job %v: %v", id, err)
+		}
+		return Job{}, ErrQueueFull
+	}
+}
+
+// Get returns the current state of the given job.
+func (m *Manager) Get(id string) (Job, error) {
+	return m.store.Get(id)
+}
+
+// Cancel stops the given job. A queued job is cancelled straight away, and a running job once its runner returns.
+// A job running on another instance is flagged for cancellation, which its owner carries out (see CancelRequested).
+func (m *Manager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, err := m.store.Get(id)
+	if err != nil {
+		return Job{}, err
+	}
+	switch {
+	case job.State.Finished():
+		return job, ErrJobFinished
+	case job.State == StateQueued:
+		if err = m.finish(job, StateCancelled, "", nil); !errors.Is(err, ErrStateChanged) {
+			job, _ = m.store.Get(id)
+			return job, err
+		}
+		// Started in the meantime, so it is cancelled like a running job
+	}
+	if cancel, ok := m.cancels[id]; ok {
+		cancel()
+		return m.store.Get(id)
+	}
+	if err = m.store.RequestCancel(id); err != nil {
+		return Job{}, err
+	}
+	m.s.Infof("Requested the cancellation of job %v from its instance", id)
+	return m.store.Get(id)
+}
+
+// worker runs queued jobs until the manager context is done.
+func (m *Manager) worker() {
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case id := <-m.queue:
+			m.run(id)
+		}
+	}
+}
+
+// run executes the given job (unless it was cancelled while queued) and records its outcome.
+func (m *Manager) run(id string) {
+	m.mu.Lock()
+	job, err := m.store.Get(id)
+	if err != nil || job.State != StateQueued {
+		m.mu.Unlock()
+		if err != nil {
+			m.s.Warnf("Skipping job %v: %v", id, err)
+		}
+		return
+	}
+	if job.CancelRequested {
+		if err = m.finish(job, StateCancelled, "", nil); err != nil {
+			m.s.Errorf("Failed to cancel job %v: %v", id, err)
+		}
+		m.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+	m.cancels[id] = cancel
+	job.State, job.UpdatedAt = StateRunning, time.Now().UTC()
+	err = m.store.Update(job, StateQueued)
+	if err != nil {
+		delete(m.cancels, id)
+	}
+	m.mu.Unlock()
+	if err != nil {
+		m.s.Errorf("Failed to start job %v: %v", id, err)
+		return
+	}
+	m.s.Infof("Running job %v", id)
+	lastUpdate := time.Now()
+	result, err := m.runner(ctx, job, func(progress Progress) {
+		job.Progress = progress
+		if time.Since(lastUpdate) >= progressInterval {
+			lastUpdate = time.Now()
+			job.UpdatedAt = lastUpdate.UTC()
+			if updateErr := m.store.Update(job, StateRunning); updateErr != nil {
+				m.s.Warnf("Failed to record the progress of job %v: %v", id, updateErr)
+				if errors.Is(updateErr, ErrStateChanged) {
+					cancel() // i.e. failed by another instance as abandoned
+				}
+			}
+			if current, getErr := m.store.Get(id); getErr == nil && current.CancelRequested {
+				cancel()
+			}
+		}
+	})
+	m.mu.Lock()
+	delete(m.cancels, id)
+	m.mu.Unlock()
+	switch {
+	case m.ctx.Err() != nil:
+		err = m.finish(job, StateFailed, "interrupted by a service shutdown", nil)
+	case ctx.Err() != nil:
+		err = m.finish(job, StateCancelled, "", nil)
+	case err != nil:
+		m.s.Warnf("Job %v failed: %v", id, err)
+		err = m.finish(job, StateFailed, err.Error(), nil)
+	default:
+		err = m.finish(job, StateCompleted, "", result)
+	}
+	if err != nil {
+		m.s.Errorf("Failed to record the outcome of job %v: %v", id, err)
+	}
+}
+
+// heartbeat renews the lease of the unfinished jobs of this instance (stopping the ones cancelled by other instances),
+// and fails the ones abandoned by other instances, until the manager context is done.
+func (m *Manager) heartbeat() {
+	ticker := time.NewTicker(m.options.Lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.store.Renew(m.options.Instance, time.Now().UTC()); err != nil {
+				m.s.Warnf("Failed to renew the lease of the jobs of instance %v: %v", m.options.Instance, err)
+			}
+			if err := m.cancelRequested(); err != nil {
+				m.s.Warnf("Failed to check for cancelled jobs: %v", err)
+			}
+			if err := m.failAbandoned(false); err != nil {
+				m.s.Warnf("Failed to check for abandoned jobs: %v", err)
+			}
+		}
+	}
+}
+
+// cancelRequested stops the jobs running on this instance whose cancellation was requested by another instance.
+func (m *Manager) cancelRequested() error {
+	running, err := m.store.ListByState(StateRunning)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range running {
+		if cancel, ok := m.cancels[job.ID]; ok && job.CancelRequested {
+			cancel()
+		}
+	}
+	return nil
+}
+
+// failAbandoned marks as failed the unfinished jobs whose owner has not renewed their lease in time,
+// and on startup the ones left over by a previous run of this instance.
+func (m *Manager) failAbandoned(startup bool) error {
+	unfinished, err := m.store.ListByState(StateQueued, StateRunning)
+	if err != nil {
+		return err
+	}
+	expired := time.Now().UTC().Add(-m.options.Lease)
+	for _, job := range unfinished {
+		var message string
+		switch {
+		case job.Owner == m.options.Instance:
+			if !startup {
+				continue // Queued or running on this instance
+			}
+			message = "interrupted by a service restart"
+		case job.UpdatedAt.Before(expired):
+			message = "abandoned by its service instance"
+		default:
+			continue
+		}
+		m.s.Warnf("Marking job %v of instance %v as failed (%v)", job.ID, job.Owner, message)
+		if err = m.finish(job, StateFailed, message, nil); err != nil && !errors.Is(err, ErrStateChanged) {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish records the final state of the given job, unless another instance has changed its state in the meantime.
+func (m *Manager) finish(job Job, state State, message string, result []byte) error {
+	from := job.State
+	job.State, job.Error, job.Result, job.UpdatedAt = state, message, result, time.Now().UTC()
+	return m.store.Update(job, from)
+}
+
+// prune removes the finished jobs older than the retention period.
+func (m *Manager) prune() {
+	if m.options.Retention <= 0 {
+		return
+	}
+	removed, err := m.store.DeleteFinishedBefore(time.Now().UTC().Add(-m.options.Retention))
+	if err != nil {
+		m.s.Warnf("Failed to remove expired jobs: %v", err)
+	} else if removed > 0 {
+		m.s.Debugf("Removed %v expired jobs", removed)
+	}
+}
+
+// newJobID generates a random job identifier.
+func newJobID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate a job id: %v", err)
+	}
+	return hex.EncodeToString(id), nil
+}
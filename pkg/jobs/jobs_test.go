@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+// waitForState polls the manager until the given job reaches the expected state.
+func waitForState(t *testing.T, m *Manager, id string, state State) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := m.Get(id)
+		if err != nil {
+			t.Fatalf("Get() unexpected error = %v", err)
+		}
+		if job.State == state {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	job, _ := m.Get(id)
+	t.Fatalf("job %v did not reach state %v (currently %v)", id, state, job.State)
+	return Job{}
+}
+
+func TestManager(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(chan string, 10)
+	runner := func(ctx context.Context, job Job, progress func(Progress)) ([]byte, error) {
+		progress(Progress{NodesExplored: 1, QueueDepth: 2})
+		switch string(job.Request) {
+		case "block":
+			started <- job.ID
+			<-ctx.Done()
+			return nil, ctx.Err()
+		case "fail":
+			return nil, errors.New("resolution failed")
+		}
+		return append([]byte("result:"), job.Request...), nil
+	}
+	m, err := NewManager(ctx, zlog.S, NewMemoryStore(), runner, Options{Workers: 1, QueueSize: 1})
+	if err != nil {
+		t.Fatalf("NewManager() unexpected error = %v", err)
+	}
+
+	job, err := m.Submit([]byte("ok"))
+	if err != nil {
+		t.Fatalf("Submit() unexpected error = %v", err)
+	}
+	job = waitForState(t, m, job.ID, StateCompleted)
+	if string(job.Result) != "result:ok" || job.Progress.NodesExplored != 1 || job.Progress.QueueDepth != 2 {
+		t.Errorf("expected the runner result and progress, got %#v", job)
+	}
+	if _, err = m.Cancel(job.ID); !errors.Is(err, ErrJobFinished) {
+		t.Errorf("Cancel() of a completed job error = %v, want %v", err, ErrJobFinished)
+	}
+
+	job, _ = m.Submit([]byte("fail"))
+	if job = waitForState(t, m, job.ID, StateFailed); job.Error != "resolution failed" {
+		t.Errorf("expected the runner error to be recorded, got %q", job.Error)
+	}
+
+	// Fill the worker and the queue
+	running, _ := m.Submit([]byte("block"))
+	<-started
+	queued, err := m.Submit([]byte("ok"))
+	if err != nil {
+		t.Fatalf("Submit() unexpected error = %v", err)
+	}
+	if _, err = m.Submit([]byte("ok")); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Submit() error = %v, want %v", err, ErrQueueFull)
+	}
+	if job, err = m.Cancel(queued.ID); err != nil || job.State != StateCancelled {
+		t.Errorf("Cancel() of a queued job = %v, %v, want it cancelled", job.State, err)
+	}
+	if _, err = m.Cancel(running.ID); err != nil {
+		t.Errorf("Cancel() of a running job unexpected error = %v", err)
+	}
+	waitForState(t, m, running.ID, StateCancelled)
+	if _, err = m.Get("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, ErrJobNotFound)
+	}
+}
+
+func TestManagerInterruptedJobs(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore()
+	now := time.Now().UTC()
+	_ = store.Create(Job{ID: "left-running", State: StateRunning, Owner: "replica-1", CreatedAt: now, UpdatedAt: now})
+	_ = store.Create(Job{ID: "other-running", State: StateRunning, Owner: "replica-2", CreatedAt: now, UpdatedAt: now})
+	_ = store.Create(Job{ID: "other-abandoned", State: StateQueued, Owner: "replica-3", CreatedAt: now,
+		UpdatedAt: now.Add(-time.Hour)})
+	_ = store.Create(Job{ID: "expired", State: StateCompleted, CreatedAt: now, UpdatedAt: now.Add(-2 * time.Hour)})
+	m, err := NewManager(ctx, zlog.S, store, func(context.Context, Job, func(Progress)) ([]byte, error) { return nil, nil },
+		Options{Workers: 1, Retention: time.Hour, Instance: "replica-1", Lease: 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("NewManager() unexpected error = %v", err)
+	}
+	if job, _ := m.Get("left-running"); job.State != StateFailed || len(job.Error) == 0 {
+		t.Errorf("expected the interrupted job to be marked as failed, got %#v", job)
+	}
+	if job, _ := m.Get("other-running"); job.State != StateRunning {
+		t.Errorf("expected the job of another live instance to be left running, got %#v", job)
+	}
+	if job, _ := m.Get("other-abandoned"); job.State != StateFailed || len(job.Error) == 0 {
+		t.Errorf("expected the job with an expired lease to be marked as failed, got %#v", job)
+	}
+	if job, err := m.Cancel("other-running"); err != nil || job.State != StateRunning || !job.CancelRequested {
+		t.Errorf("Cancel() of a job of another instance = %#v, %v, want a cancellation request", job, err)
+	}
+	if _, err = m.Submit(nil); err != nil {
+		t.Fatalf("Submit() unexpected error = %v", err)
+	}
+	if _, err = m.Get("expired"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected the expired job to be removed, got error %v", err)
+	}
+}
+
+func TestManagerCancelOnAnotherInstance(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(chan string, 1)
+	runner := func(ctx context.Context, job Job, progress func(Progress)) ([]byte, error) {
+		started <- job.ID
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	// Both instances share the store, as replicas do with the database
+	store := NewMemoryStore()
+	options := Options{Workers: 1, Lease: 30 * time.Millisecond}
+	options.Instance = "replica-1"
+	owner, err := NewManager(ctx, zlog.S, store, runner, options)
+	if err != nil {
+		t.Fatalf("NewManager() unexpected error = %v", err)
+	}
+	options.Instance = "replica-2"
+	other, err := NewManager(ctx, zlog.S, store, runner, options)
+	if err != nil {
+		t.Fatalf("NewManager() unexpected error = %v", err)
+	}
+	job, err := owner.Submit([]byte("block"))
+	if err != nil {
+		t.Fatalf("Submit() unexpected error = %v", err)
+	}
+	<-started
+	if job, err = other.Cancel(job.ID); err != nil || job.State != StateRunning || !job.CancelRequested {
+		t.Errorf("Cancel() = %#v, %v, want a cancellation request", job, err)
+	}
+	waitForState(t, other, job.ID, StateCancelled)
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SQLStore keeps the state of jobs in the transitive_jobs table, so it survives restarts and can be shared.
+type SQLStore struct {
+	ctx context.Context
+	db  *sqlx.DB
+}
+
+// sqlJob is a row of the transitive_jobs table.
+type sqlJob struct {
+	ID            string `db:"id"`
+	State         string `db:"state"`
+	Request       string `db:"request"`
+	Result        string `db:"result"`
+	Error         string `db:"error"`
+	Owner         string `db:"owner"`
+	Cancel        bool   `db:"cancel_requested"`
+	NodesExplored int    `db:"nodes_explored"`
+	QueueDepth    int    `db:"queue_depth"`
+	CreatedAt     string `db:"created_at"`
+	UpdatedAt     string `db:"updated_at"`
+}
+
+const sqlJobColumns = "id, state, request, result, error, owner, cancel_requested, nodes_explored, queue_depth, created_at, updated_at"
+
+// sqlTimeFormat stores timestamps as fixed width UTC text, so they sort (and compare) as strings.
+const sqlTimeFormat = "2006-01-02T15:04:05.000000Z"
+
+// NewSQLStore creates a job store backed by the transitive_jobs table of the given database.
+func NewSQLStore(ctx context.Context, db *sqlx.DB) *SQLStore {
+	return &SQLStore{ctx: ctx, db: db}
+}
+
+func toSQLJob(job Job) sqlJob {
+	return sqlJob{ID: job.ID, State: string(job.State), Request: string(job.Request), Result: string(job.Result),
+		Error: job.Error, Owner: job.Owner, Cancel: job.CancelRequested, NodesExplored: job.Progress.NodesExplored, QueueDepth: job.Progress.QueueDepth,
+		CreatedAt: job.CreatedAt.UTC().Format(sqlTimeFormat), UpdatedAt: job.UpdatedAt.UTC().Format(sqlTimeFormat)}
+}
+
+func (j sqlJob) toJob() Job {
+	job := Job{ID: j.ID, State: State(j.State), Error: j.Error, Owner: j.Owner, CancelRequested: j.Cancel,
+		Progress: Progress{NodesExplored: j.NodesExplored, QueueDepth: j.QueueDepth}}
+	if len(j.Request) > 0 {
+		job.Request = []byte(j.Request)
+	}
+	if len(j.Result) > 0 {
+		job.Result = []byte(j.Result)
+	}
+	job.CreatedAt, _ = time.Parse(sqlTimeFormat, j.CreatedAt)
+	job.UpdatedAt, _ = time.Parse(sqlTimeFormat, j.UpdatedAt)
+	return job
+}
+
+func (m *SQLStore) Create(job Job) error {
+	row := toSQLJob(job)
+	_, err := m.db.ExecContext(m.ctx, "INSERT INTO transitive_jobs ("+sqlJobColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+		row.ID, row.State, row.Request, row.Result, row.Error, row.Owner, row.Cancel, row.NodesExplored, row.QueueDepth, row.CreatedAt, row.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job %v: %v", job.ID, err)
+	}
+	return nil
+}
+
+func (m *SQLStore) Get(id string) (Job, error) {
+	var row sqlJob
+	err := m.db.QueryRowxContext(m.ctx, "SELECT "+sqlJobColumns+" FROM transitive_jobs WHERE id = $1", id).StructScan(&row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Job{}, ErrJobNotFound
+		}
+		return Job{}, fmt.Errorf("failed to query job %v: %v", id, err)
+	}
+	return row.toJob(), nil
+}
+
+func (m *SQLStore) Update(job Job, from State) error {
+	row := toSQLJob(job)
+	result, err := m.db.ExecContext(m.ctx, "UPDATE transitive_jobs SET state = $1, result = $2, error = $3,"+
+		" nodes_explored = $4, queue_depth = $5, updated_at = $6 WHERE id = $7 AND state = $8",
+		row.State, row.Result, row.Error, row.NodesExplored, row.QueueDepth, row.UpdatedAt, row.ID, string(from))
+	if err != nil {
+		return fmt.Errorf("failed to update job %v: %v", job.ID, err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		if _, err = m.Get(job.ID); err != nil {
+			return err
+		}
+		return ErrStateChanged
+	}
+	return nil
+}
+
+func (m *SQLStore) Delete(id string) error {
+	if _, err := m.db.ExecContext(m.ctx, "DELETE FROM transitive_jobs WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to delete job %v: %v", id, err)
+	}
+	return nil
+}
+
+func (m *SQLStore) ListByState(states ...State) ([]Job, error) {
+	if len(states) == 0 {
+		return nil, nil
+	}
+	placeholders := make([]string, len(states))
+	args := make([]any, len(states))
+	for i, state := range states {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = string(state)
+	}
+	var rows []sqlJob
+	err := m.db.SelectContext(m.ctx, &rows, "SELECT "+sqlJobColumns+" FROM transitive_jobs WHERE state IN ("+
+		strings.Join(placeholders, ", ")+") ORDER BY created_at", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the transitive_jobs table: %v", err)
+	}
+	jobs := make([]Job, 0, len(rows))
+	for _, row := range rows {
+		jobs = append(jobs, row.toJob())
+	}
+	return jobs, nil
+}
+
+func (m *SQLStore) DeleteFinishedBefore(before time.Time) (int, error) {
+	result, err := m.db.ExecContext(m.ctx, "DELETE FROM transitive_jobs WHERE state IN ($1, $2, $3) AND updated_at < $4",
+		string(StateCompleted), string(StateFailed), string(StateCancelled), before.UTC().Format(sqlTimeFormat))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired jobs: %v", err)
+	}
+	removed, _ := result.RowsAffected()
+	return int(removed), nil
+}
+
+func (m *SQLStore) RequestCancel(id string) error {
+	result, err := m.db.ExecContext(m.ctx, "UPDATE transitive_jobs SET cancel_requested = $1 WHERE id = $2 AND state IN ($3, $4)",
+		true, id, string(StateQueued), string(StateRunning))
+	if err != nil {
+		return fmt.Errorf("failed to request the cancellation of job %v: %v", id, err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		if _, err = m.Get(id); err != nil {
+			return err
+		}
+		return ErrJobFinished
+	}
+	return nil
+}
+
+func (m *SQLStore) Renew(owner string, at time.Time) error {
+	_, err := m.db.ExecContext(m.ctx, "UPDATE transitive_jobs SET updated_at = $1 WHERE owner = $2 AND state IN ($3, $4)",
+		at.UTC().Format(sqlTimeFormat), owner, string(StateQueued), string(StateRunning))
+	if err != nil {
+		return fmt.Errorf("failed to renew the jobs of %v: %v", owner, err)
+	}
+	return nil
+}
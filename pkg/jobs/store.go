@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jobs
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store keeps the state of jobs.
+type Store interface {
+	Create(job Job) error
+	Get(id string) (Job, error)       // Returns ErrJobNotFound if the job does not exist
+	Update(job Job, from State) error // Returns ErrStateChanged if the job is no longer in the given state
+	Delete(id string) error
+	ListByState(states ...State) ([]Job, error)
+	DeleteFinishedBefore(before time.Time) (int, error) // Returns the number of jobs removed
+	Renew(owner string, at time.Time) error             // Sets the update time of the unfinished jobs of the given owner
+	RequestCancel(id string) error                      // Flags an unfinished job to be cancelled by its owner
+}
+
+// MemoryStore keeps the state of jobs in memory (lost on restart, and not shared between instances).
+type MemoryStore struct {
+	mu   sync.RWMutex
+	jobs map[string]Job
+}
+
+// NewMemoryStore creates an empty in-memory job store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]Job)}
+}
+
+func (m *MemoryStore) Create(job Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *MemoryStore) Get(id string) (Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (m *MemoryStore) Update(job Job, from State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.jobs[job.ID]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if current.State != from {
+		return ErrStateChanged
+	}
+	job.CancelRequested = current.CancelRequested
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *MemoryStore) ListByState(states ...State) ([]Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var jobs []Job
+	for _, job := range m.jobs {
+		if slices.Contains(states, job.State) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (m *MemoryStore) DeleteFinishedBefore(before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	removed := 0
+	for id, job := range m.jobs {
+		if job.State.Finished() && job.UpdatedAt.Before(before) {
+			delete(m.jobs, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (m *MemoryStore) RequestCancel(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if job.State.Finished() {
+		return ErrJobFinished
+	}
+	job.CancelRequested = true
+	m.jobs[id] = job
+	return nil
+}
+
+func (m *MemoryStore) Renew(owner string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, job := range m.jobs {
+		if job.Owner == owner && !job.State.Finished() {
+			job.UpdatedAt = at
+			m.jobs[id] = job
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+func TestStores(t *testing.T) {
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	ctx := context.Background()
+	if _, err = models.MigrateSchema(ctx, db); err != nil {
+		t.Fatalf("MigrateSchema() unexpected error = %v", err)
+	}
+	stores := map[string]Store{"memory": NewMemoryStore(), "sql": NewSQLStore(ctx, db)}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().UTC().Truncate(time.Microsecond)
+			job := Job{ID: "job-1", State: StateQueued, Request: []byte(`{"components": []}`), CreatedAt: now, UpdatedAt: now}
+			if err := store.Create(job); err != nil {
+				t.Fatalf("Create() unexpected error = %v", err)
+			}
+			job.State, job.Progress, job.Result = StateCompleted, Progress{NodesExplored: 5, QueueDepth: 1}, []byte(`{}`)
+			job.UpdatedAt = now.Add(-time.Hour)
+			if err := store.Update(job, StateQueued); err != nil {
+				t.Fatalf("Update() unexpected error = %v", err)
+			}
+			if err := store.Update(job, StateRunning); !errors.Is(err, ErrStateChanged) {
+				t.Errorf("Update() of a job in another state error = %v, want %v", err, ErrStateChanged)
+			}
+			got, err := store.Get(job.ID)
+			if err != nil {
+				t.Fatalf("Get() unexpected error = %v", err)
+			}
+			if got.State != StateCompleted || got.Progress != job.Progress || string(got.Request) != string(job.Request) ||
+				string(got.Result) != "{}" || !got.CreatedAt.Equal(now) {
+				t.Errorf("Get() = %#v, want %#v", got, job)
+			}
+			if err := store.Update(Job{ID: "missing", State: StateRunning}, StateQueued); !errors.Is(err, ErrJobNotFound) {
+				t.Errorf("Update() error = %v, want %v", err, ErrJobNotFound)
+			}
+			_ = store.Create(Job{ID: "job-2", State: StateRunning, Owner: "replica-1", CreatedAt: now, UpdatedAt: now})
+			if jobs, err := store.ListByState(StateQueued, StateRunning); err != nil || len(jobs) != 1 || jobs[0].ID != "job-2" ||
+				jobs[0].Owner != "replica-1" {
+				t.Errorf("ListByState() = %v, %v, want only job-2", jobs, err)
+			}
+			if err := store.RequestCancel("job-2"); err != nil {
+				t.Fatalf("RequestCancel() unexpected error = %v", err)
+			}
+			if err := store.Update(Job{ID: "job-2", State: StateRunning, Owner: "replica-1", CreatedAt: now, UpdatedAt: now},
+				StateRunning); err != nil {
+				t.Fatalf("Update() unexpected error = %v", err)
+			}
+			if got, _ := store.Get("job-2"); !got.CancelRequested {
+				t.Errorf("expected the cancellation request of job-2 to be kept, got %#v", got)
+			}
+			if err := store.RequestCancel(job.ID); !errors.Is(err, ErrJobFinished) {
+				t.Errorf("RequestCancel() of a finished job error = %v, want %v", err, ErrJobFinished)
+			}
+			if err := store.RequestCancel("missing"); !errors.Is(err, ErrJobNotFound) {
+				t.Errorf("RequestCancel() error = %v, want %v", err, ErrJobNotFound)
+			}
+			renewed := now.Add(time.Minute)
+			if err := store.Renew("replica-1", renewed); err != nil {
+				t.Fatalf("Renew() unexpected error = %v", err)
+			}
+			if got, _ := store.Get("job-2"); !got.UpdatedAt.Equal(renewed) {
+				t.Errorf("Renew() expected the job update time to be %v, got %v", renewed, got.UpdatedAt)
+			}
+			if got, _ := store.Get(job.ID); !got.UpdatedAt.Equal(job.UpdatedAt) {
+				t.Errorf("Renew() unexpectedly changed the update time of a finished job: %v", got.UpdatedAt)
+			}
+			if removed, err := store.DeleteFinishedBefore(now.Add(-time.Minute)); err != nil || removed != 1 {
+				t.Errorf("DeleteFinishedBefore() = %v, %v, want 1 job removed", removed, err)
+			}
+			if err := store.Delete("job-2"); err != nil {
+				t.Fatalf("Delete() unexpected error = %v", err)
+			}
+			if _, err := store.Get("job-2"); !errors.Is(err, ErrJobNotFound) {
+				t.Errorf("Get() error = %v, want %v", err, ErrJobNotFound)
+			}
+		})
+	}
+}
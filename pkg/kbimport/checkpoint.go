@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package kbimport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Checkpoint records how far the import of a dump has gone, so that an interrupted import can be resumed.
+type Checkpoint struct {
+	Table   string `json:"table"`
+	File    string `json:"file"`
+	Records int    `json:"records"`
+}
+
+// LoadCheckpoint reads the checkpoint stored in the given file. A missing file returns an empty checkpoint.
+func LoadCheckpoint(path string) (Checkpoint, error) {
+	var checkpoint Checkpoint
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checkpoint, nil
+		}
+		return checkpoint, fmt.Errorf("failed to read checkpoint %v: %v", path, err)
+	}
+	if err = json.Unmarshal(data, &checkpoint); err != nil {
+		return checkpoint, fmt.Errorf("invalid checkpoint %v: %v", path, err)
+	}
+	return checkpoint, nil
+}
+
+// SaveCheckpoint writes the given checkpoint to the given file (via a rename, so it is never left half written).
+func SaveCheckpoint(path string, checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %v", err)
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint %v: %v", tmp, err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write checkpoint %v: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package kbimport streams JSONL/CSV knowledge base dumps into the database in batches.
+package kbimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+// DefaultBatchSize is the number of records written per transaction when none is specified.
+const DefaultBatchSize = 1000
+
+// RecordError reports a record that could not be read or validated.
+type RecordError struct {
+	Line int
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("line %v: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Options controls a bulk import.
+type Options struct {
+	Table       string // Target table (i.e. npmjs_dependencies, all_urls or golang_projects)
+	Format      string // Dump format (jsonl or csv)
+	BatchSize   int    // Records written per transaction
+	Skip        int    // Records to skip from the start of the dump (i.e. already imported by a previous run)
+	SkipInvalid bool   // Reject invalid records instead of aborting the import
+	DryRun      bool   // Only validate the dump, without writing to the database
+}
+
+// Progress reports how far an import has gone.
+type Progress struct {
+	Records   int // Records read from the dump (including the skipped ones). Safe to resume from once reported
+	Validated int // Valid records read (written to the database, unless in a dry run)
+	Imported  int // Records written to the database
+	Rejected  int // Invalid records rejected
+}
+
+// Importer streams a dump into one knowledge base table.
+type Importer struct {
+	s       *zap.SugaredLogger
+	model   *models.KBImportModel
+	options Options
+}
+
+// NewImporter creates a new importer for the table and format requested in the given options.
+func NewImporter(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, options Options) (*Importer, error) {
+	model, err := models.NewKBImportModel(ctx, s, db, options.Table)
+	if err != nil {
+		return nil, err
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+	if options.BatchSize > model.MaxBatchSize() {
+		s.Warnf("Reducing the batch size to %v (maximum for %v)", model.MaxBatchSize(), options.Table)
+		options.BatchSize = model.MaxBatchSize()
+	}
+	if err = checkFormat(options.Format); err != nil {
+		return nil, err
+	}
+	return &Importer{s: s, model: model, options: options}, nil
+}
+
+// Import streams the given dump into the database, calling onProgress after each batch is committed.
+// It returns the progress achieved, which is also valid (to resume from) when an error is returned.
+func (i *Importer) Import(r io.Reader, onProgress func(Progress)) (Progress, error) {
+	progress := Progress{Records: i.options.Skip}
+	reader, err := newRecordReader(i.options.Format, r)
+	if err != nil {
+		return progress, err
+	}
+	batch := make([]models.ImportRecord, 0, i.options.BatchSize)
+	flush := func(records int) error {
+		if len(batch) > 0 && !i.options.DryRun {
+			if err := i.model.UpsertRecords(batch); err != nil {
+				return err
+			}
+			progress.Imported += len(batch)
+		}
+		progress.Validated += len(batch)
+		progress.Records = records
+		batch = batch[:0]
+		if onProgress != nil {
+			onProgress(progress)
+		}
+		return nil
+	}
+	records := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var recordErr *RecordError
+		if err != nil && !errors.As(err, &recordErr) {
+			return progress, err // The dump itself cannot be read any further
+		}
+		records++
+		if records <= i.options.Skip {
+			continue
+		}
+		if err == nil {
+			err = i.model.ValidateRecord(record)
+		}
+		if err != nil {
+			if !i.options.SkipInvalid {
+				return progress, fmt.Errorf("invalid record %v: %w", records, err)
+			}
+			i.s.Warnf("Rejecting record %v: %v", records, err)
+			progress.Rejected++
+			continue
+		}
+		batch = append(batch, record)
+		if len(batch) >= i.options.BatchSize {
+			if err = flush(records); err != nil {
+				return progress, err
+			}
+		}
+	}
+	if err = flush(records); err != nil {
+		return progress, err
+	}
+	return progress, nil
+}
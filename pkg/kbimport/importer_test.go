@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package kbimport
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+// setupImportDB creates an empty, fully migrated SQLite knowledge base.
+func setupImportDB(t *testing.T) (context.Context, *sqlx.DB) {
+	t.Helper()
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Database.Driver = "sqlite"
+	myConfig.Database.Dsn = filepath.Join(t.TempDir(), "kb.db")
+	db, err := models.OpenDB(myConfig)
+	if err != nil {
+		t.Fatalf("OpenDB() unexpected error = %v", err)
+	}
+	t.Cleanup(func() { models.CloseDB(db) })
+	if _, err = models.MigrateSchema(ctx, db); err != nil {
+		t.Fatalf("MigrateSchema() unexpected error = %v", err)
+	}
+	return ctx, db
+}
+
+func TestImportDependencies(t *testing.T) {
+	ctx, db := setupImportDB(t)
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctx).Sugar()
+	dump := `{"purl_name": "a", "version": "1.0.0", "dep_data": [{"dep_purl_name": "b", "dep_ver": "^1.0.0"}]}
+{"purl_name": "b", "version": "1.0.0", "dep_data": "[]"}
+
+{"purl_name": "c", "version": "1.0.0", "dep_data": [{"name": "d"}]}
+{"purl_name": "d", "version": "1.0.0", "dep_data": []}
+{"purl_name": "a", "version": "1.0.0", "dep_data": [{"dep_purl_name": "d", "dep_ver": "1.0.0"}]}
+`
+	options := Options{Table: "npmjs_dependencies", Format: FormatJSONL, BatchSize: 2}
+	importer, err := NewImporter(ctx, s, db, options)
+	if err != nil {
+		t.Fatalf("NewImporter() unexpected error = %v", err)
+	}
+	var reports []Progress
+	progress, err := importer.Import(strings.NewReader(dump), func(p Progress) { reports = append(reports, p) })
+	if err == nil || progress.Records != 2 || progress.Imported != 2 {
+		t.Fatalf("Import() expected to stop at the invalid record after 2 records, got %#v, err = %v", progress, err)
+	}
+	// Resume from where it stopped, rejecting the invalid record this time
+	options.Skip = progress.Records
+	options.SkipInvalid = true
+	importer, err = NewImporter(ctx, s, db, options)
+	if err != nil {
+		t.Fatalf("NewImporter() unexpected error = %v", err)
+	}
+	progress, err = importer.Import(strings.NewReader(dump), func(p Progress) { reports = append(reports, p) })
+	if err != nil {
+		t.Fatalf("Import() unexpected error = %v", err)
+	}
+	if progress.Records != 5 || progress.Imported != 2 || progress.Rejected != 1 {
+		t.Errorf("Import() unexpected progress %#v", progress)
+	}
+	if len(reports) == 0 || reports[len(reports)-1] != progress {
+		t.Errorf("Import() expected progress reports ending with %#v, got %#v", progress, reports)
+	}
+	dependencyModel := models.NewDependencyModel(ctx, s, db, nil, nil)
+	deps, err := dependencyModel.GetDependencies("a", "1.0.0", "npm")
+	if err != nil || len(deps) != 1 || deps[0].Purl != "d" {
+		t.Errorf("GetDependencies() expected the upserted dependency on d, got %#v, err = %v", deps, err)
+	}
+	deps, err = dependencyModel.GetDependencies("c", "1.0.0", "npm")
+	if err != nil || len(deps) != 0 {
+		t.Errorf("GetDependencies() expected the invalid record to be rejected, got %#v, err = %v", deps, err)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	ctx, db := setupImportDB(t)
+	defer zlog.SyncZap()
+	s := ctxzap.Extract(ctx).Sugar()
+	if _, err := db.ExecContext(ctx, "INSERT INTO mines (id, mine_name, purl_type) VALUES (45, 'golang', 'golang')"); err != nil {
+		t.Fatalf("failed to insert mine: %v", err)
+	}
+	tests := []struct {
+		name    string
+		table   string
+		dump    string
+		query   string
+		want    string
+		dryRun  bool
+		wantErr bool
+	}{
+		{
+			name:  "all_urls with license lookup",
+			table: "all_urls",
+			dump: "package_hash,url,url_hash,purl_name,version,license,mine_id\n" +
+				"h1,https://example.org/a-1.0.tgz,u1,a,1.0,MIT,2\n",
+			query: "SELECT l.license_name FROM all_urls au JOIN licenses l ON au.license_id = l.id WHERE au.purl_name = 'a'",
+			want:  "MIT",
+		},
+		{
+			name:  "golang_projects with mine lookup",
+			table: "golang_projects",
+			dump: "purl_name,version,version_date,license,is_module\n" +
+				"example.com/mod,v1.0.0,2023-01-01T00:00:00Z,Apache-2.0,true\n",
+			query: "SELECT CAST(mine_id AS TEXT) FROM golang_projects WHERE purl_name = 'example.com/mod'",
+			want:  "45",
+		},
+		{
+			name:    "unknown column",
+			table:   "all_urls",
+			dump:    "package_hash,url,url_hash,purl_name,colour\nh2,u,u2,b,red\n",
+			wantErr: true,
+		},
+		{
+			name:   "dry run",
+			table:  "crates_dependencies",
+			dump:   "purl_name,version,dep_data\nserde,1.0.0,\"[{\"\"dep_purl_name\"\": \"\"serde_derive\"\", \"\"dep_ver\"\": \"\"1\"\"}]\"\n",
+			query:  "SELECT CAST(COUNT(*) AS TEXT) FROM crates_dependencies",
+			want:   "0",
+			dryRun: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importer, err := NewImporter(ctx, s, db, Options{Table: tt.table, Format: FormatCSV, DryRun: tt.dryRun})
+			if err != nil {
+				t.Fatalf("NewImporter() unexpected error = %v", err)
+			}
+			progress, err := importer.Import(strings.NewReader(tt.dump), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			wantImported := 1
+			if tt.dryRun {
+				wantImported = 0
+			}
+			if progress.Validated != 1 || progress.Imported != wantImported {
+				t.Errorf("Import() expected 1 validated and %v imported records, got %#v", wantImported, progress)
+			}
+			var got string
+			if err = db.QueryRowxContext(ctx, tt.query).Scan(&got); err != nil || got != tt.want {
+				t.Errorf("%v = %v, want %v (err = %v)", tt.query, got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.jsonl.checkpoint")
+	checkpoint, err := LoadCheckpoint(path)
+	if err != nil || checkpoint.Records != 0 {
+		t.Errorf("LoadCheckpoint() expected an empty checkpoint, got %#v, err = %v", checkpoint, err)
+	}
+	want := Checkpoint{Table: "all_urls", File: "dump.jsonl", Records: 42}
+	if err = SaveCheckpoint(path, want); err != nil {
+		t.Fatalf("SaveCheckpoint() unexpected error = %v", err)
+	}
+	checkpoint, err = LoadCheckpoint(path)
+	if err != nil || checkpoint != want {
+		t.Errorf("LoadCheckpoint() = %#v, want %#v (err = %v)", checkpoint, want, err)
+	}
+}
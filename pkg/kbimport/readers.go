@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package kbimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"scanoss.com/dependencies/pkg/models"
+)
+
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// maxJSONLLineSize is the longest JSONL line accepted (dep_data of large packages can be sizeable).
+const maxJSONLLineSize = 64 * 1024 * 1024
+
+// recordReader streams the records of a dump one at a time. Read returns io.EOF once all records have been read.
+type recordReader interface {
+	Read() (models.ImportRecord, error)
+}
+
+// checkFormat verifies the given dump format is supported.
+func checkFormat(format string) error {
+	switch strings.ToLower(format) {
+	case FormatJSONL, "ndjson", "json", FormatCSV:
+		return nil
+	}
+	return fmt.Errorf("unsupported import format %q (expected %v or %v)", format, FormatJSONL, FormatCSV)
+}
+
+// newRecordReader creates a record reader for the given dump format.
+func newRecordReader(format string, r io.Reader) (recordReader, error) {
+	switch strings.ToLower(format) {
+	case FormatJSONL, "ndjson", "json":
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineSize)
+		return &jsonlReader{scanner: scanner}, nil
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CSV header: %v", err)
+		}
+		for i := range header {
+			header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+		}
+		return &csvReader{reader: reader, header: header}, nil
+	}
+	return nil, checkFormat(format)
+}
+
+// jsonlReader reads one JSON object per line. Non-string values (i.e. dep_data arrays) are kept as raw JSON.
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *jsonlReader) Read() (models.ImportRecord, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue // Skip blank lines
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(line, &fields); err != nil {
+			return nil, &RecordError{Line: r.line, Err: fmt.Errorf("invalid JSON object: %v", err)}
+		}
+		record := make(models.ImportRecord, len(fields))
+		for name, value := range fields {
+			var text string
+			switch {
+			case bytes.Equal(value, []byte("null")):
+				continue
+			case len(value) > 0 && value[0] == '"':
+				if err := json.Unmarshal(value, &text); err != nil {
+					return nil, &RecordError{Line: r.line, Err: fmt.Errorf("invalid value for %q: %v", name, err)}
+				}
+			default:
+				text = string(value)
+			}
+			record[name] = text
+		}
+		return record, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read line %v: %v", r.line+1, err)
+	}
+	return nil, io.EOF
+}
+
+// csvReader reads CSV rows, using the first row as the column names.
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (r *csvReader) Read() (models.ImportRecord, error) {
+	row, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RecordError{Line: parseErr.StartLine, Err: err}
+		}
+		return nil, err
+	}
+	line, _ := r.reader.FieldPos(0)
+	if len(row) != len(r.header) {
+		return nil, &RecordError{Line: line, Err: fmt.Errorf("expected %v fields, got %v", len(r.header), len(row))}
+	}
+	record := make(models.ImportRecord, len(row))
+	for i, value := range row {
+		if len(value) > 0 {
+			record[r.header[i]] = value
+		}
+	}
+	return record, nil
+}
@@ -37,6 +37,9 @@ type AllUrlsModel struct {
 	golangProj *GolangProjects
 	mineModel  *MineModel
 	q          *database.DBQueryContext
+	live       *LiveMetadataModel
+	curation   *CurationModel
+	private    *PrivatePackageModel
 }
 
 type AllURL struct {
@@ -49,8 +52,35 @@ type AllURL struct {
 	PurlName  string `db:"purl_name"`
 	MineID    int32  `db:"mine_id"`
 	URL       string `db:"-"`
+	// Fields overridden by a curation (and the reasons given for them)
+	Curated         []string `db:"-"`
+	CurationReasons []string `db:"-"`
+	Private         bool     `db:"-"` // Served by the private package registry
 }
 
+// ComponentVersion holds the KB details of one version of a component.
+type ComponentVersion struct {
+	Version   string `db:"version"`
+	Date      string `db:"date"`
+	License   string `db:"license"`
+	LicenseID string `db:"license_id"`
+	IsSpdx    bool   `db:"is_spdx"`
+	MineID    int32  `db:"mine_id"`
+}
+
+// URLKey identifies a component version to look up in the KB (an empty version means the latest one).
+type URLKey struct {
+	PurlType string
+	PurlName string
+	Version  string
+}
+
+// URLBatch holds the KB details of many components, looked up together by GetURLsBatch.
+type URLBatch map[URLKey]AllURL
+
+// urlBatchSize is the maximum number of purl names bound to a single batch query.
+const urlBatchSize = 500
+
 // SQL Query constants.
 const (
 	purlSQLQuerySelect = "SELECT component, v.version_name AS version, v.semver AS semver,"
@@ -68,6 +98,9 @@ func NewAllURLModel(ctx context.Context,
 	golangProj *GolangProjects,
 	mineModel *MineModel,
 	q *database.DBQueryContext,
+	live *LiveMetadataModel,
+	curation *CurationModel,
+	private *PrivatePackageModel,
 ) *AllUrlsModel {
 	return &AllUrlsModel{
 		ctx:        ctx,
@@ -77,22 +110,45 @@ func NewAllURLModel(ctx context.Context,
 		golangProj: golangProj,
 		mineModel:  mineModel,
 		q:          q,
+		live:       live,
+		curation:   curation,
+		private:    private,
 	}
 }
 
 // GetURLsByPurlString searches for component details of the specified Purl string (and optional requirement).
+// Any curation of the component is applied on top of the details found.
 func (m *AllUrlsModel) GetURLsByPurlString(component componentHelper.Component) (AllURL, error) {
-	if len(component.Version) > 0 {
-		result, err := m.GetURLsByPurlNameTypeVersion(component.Name, component.PurlType, component.Version)
-		if err != nil {
-			return AllURL{}, err
-		}
-		if result.PurlName == "" && component.PurlType == "golang" {
-			return m.getURLsByGolangPurl(component)
+	return m.GetURLsByPurlStringFromBatch(component, nil)
+}
+
+// GetURLsByPurlStringFromBatch behaves like GetURLsByPurlString, taking the KB details of the component from the
+// given batch (see GetURLsBatch) instead of querying them, if present.
+func (m *AllUrlsModel) GetURLsByPurlStringFromBatch(component componentHelper.Component, batch URLBatch) (AllURL, error) {
+	result, err := m.getURLsByPurlString(component, batch)
+	if err != nil || m.curation == nil {
+		return result, err
+	}
+	version := result.Version
+	if len(version) == 0 {
+		version = component.Version
+	}
+	result = m.curation.CurateURL(result, component.PurlType, component.Name, version)
+	if len(result.Curated) > 0 && len(result.PurlName) == 0 {
+		result.Component, result.PurlName = component.Name, component.Name
+	}
+	return result, nil
+}
+
+// getURLsByPurlString searches the KB for component details of the specified Purl string (and optional requirement).
+// Components covered by the private package registry are served from it instead.
+func (m *AllUrlsModel) getURLsByPurlString(component componentHelper.Component, batch URLBatch) (AllURL, error) {
+	if m.private != nil {
+		if result, isPrivate := m.private.GetURL(component); isPrivate {
+			return result, nil
 		}
-		return result, nil
 	}
-	result, err := m.GetURLsByPurlNameType(component.Name, component.PurlType)
+	result, err := m.lookupURL(batch, URLKey{PurlType: component.PurlType, PurlName: component.Name, Version: component.Version})
 	if err != nil {
 		return AllURL{}, err
 	}
@@ -102,6 +158,118 @@ func (m *AllUrlsModel) GetURLsByPurlString(component componentHelper.Component)
 	return result, nil
 }
 
+// lookupURL returns the KB details of the given component version, from the batch if present or the KB otherwise.
+func (m *AllUrlsModel) lookupURL(batch URLBatch, key URLKey) (AllURL, error) {
+	if url, found := batch[key]; found {
+		return m.addLiveMetadata(url, key.PurlName, key.PurlType, key.Version), nil
+	}
+	if len(key.Version) > 0 {
+		return m.GetURLsByPurlNameTypeVersion(key.PurlName, key.PurlType, key.Version)
+	}
+	return m.GetURLsByPurlNameType(key.PurlName, key.PurlType)
+}
+
+// GetURLsBatch looks up the KB details of many components together, using a few queries for each purl type
+// (and batch of purl names) instead of several queries per component.
+// Private components are left out, and live metadata is only added when a component is read from the batch.
+func (m *AllUrlsModel) GetURLsBatch(components []componentHelper.Component) (URLBatch, error) {
+	keysByType := make(map[string][]URLKey)
+	seen := make(map[URLKey]struct{}, len(components))
+	batch := make(URLBatch, len(components))
+	for _, component := range components {
+		key := URLKey{PurlType: component.PurlType, PurlName: component.Name, Version: component.Version}
+		if _, exists := seen[key]; exists || len(key.PurlType) == 0 || len(key.PurlName) == 0 {
+			continue
+		}
+		seen[key] = struct{}{}
+		if m.private != nil && m.private.Covers(key.PurlType, key.PurlName) {
+			continue
+		}
+		keysByType[key.PurlType] = append(keysByType[key.PurlType], key)
+	}
+	for purlType, keys := range keysByType {
+		for start := 0; start < len(keys); start += urlBatchSize {
+			if err := m.getURLsBatch(batch, purlType, keys[start:min(start+urlBatchSize, len(keys))]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	m.s.Debugf("Looked up %v components in a batch", len(batch))
+	return batch, nil
+}
+
+// getURLsBatch adds the KB details of the given (unique) component versions, all of the same purl type, to the batch.
+func (m *AllUrlsModel) getURLsBatch(batch URLBatch, purlType string, keys []URLKey) error {
+	var latestNames []string
+	var versionedArgs []any // Pairs of purl name and version
+	for _, key := range keys {
+		if len(key.Version) == 0 {
+			latestNames = append(latestNames, key.PurlName)
+		} else {
+			versionedArgs = append(versionedArgs, key.PurlName, key.Version)
+		}
+	}
+	// Keep the first (most recent) match of each component version, as a single lookup does
+	matches := make(map[URLKey]AllURL, len(keys))
+	query := purlSQLQuerySelect + licSpdxSQLQuery + " purl_name, mine_id FROM all_urls u" +
+		mineLeftJoinSQL + licLeftJoinSQL + verLeftJoinSQL +
+		" WHERE m.purl_type = $1 AND %s ORDER BY date DESC"
+	if len(latestNames) > 0 {
+		var allUrls []AllURL
+		args := append([]any{purlType}, toAnySlice(latestNames)...)
+		nameFilter := "u.purl_name IN (" + sqlPlaceholders(2, len(latestNames)) + ")"
+		err := m.q.SelectContext(m.ctx, &allUrls, fmt.Sprintf(query, nameFilter), args...)
+		if err != nil {
+			m.s.Errorf("Failed to query all urls table for %v %v components: %v", len(latestNames), purlType, err)
+			return fmt.Errorf("failed to query the all urls table: %v", err)
+		}
+		for _, url := range allUrls {
+			key := URLKey{PurlType: purlType, PurlName: url.PurlName}
+			if _, exists := matches[key]; !exists {
+				matches[key] = url
+			}
+		}
+	}
+	if len(versionedArgs) > 0 {
+		var allUrls []AllURL
+		args := append([]any{purlType}, versionedArgs...)
+		versionFilter := "(u.purl_name, v.version_name) IN (" + sqlPlaceholderPairs(2, len(versionedArgs)/2) + ")"
+		err := m.q.SelectContext(m.ctx, &allUrls, fmt.Sprintf(query, versionFilter), args...)
+		if err != nil {
+			m.s.Errorf("Failed to query all urls table for %v %v component versions: %v", len(versionedArgs)/2, purlType, err)
+			return fmt.Errorf("failed to query the all urls table: %v", err)
+		}
+		for _, url := range allUrls {
+			key := URLKey{PurlType: purlType, PurlName: url.PurlName, Version: url.Version}
+			if _, exists := matches[key]; !exists {
+				matches[key] = url
+			}
+		}
+	}
+	// Components without a licensed match fall back to their project details, also looked up together
+	var fallbackNames []string
+	fallbackSeen := make(map[string]struct{})
+	for _, key := range keys {
+		if url, found := matches[key]; !found || len(url.License) == 0 {
+			if _, exists := fallbackSeen[key.PurlName]; !exists {
+				fallbackSeen[key.PurlName] = struct{}{}
+				fallbackNames = append(fallbackNames, key.PurlName)
+			}
+		}
+	}
+	fallbacks := buildFallbackURLs(m.s, m.project, m.mineModel, fallbackNames, purlType)
+	for _, key := range keys {
+		url, found := matches[key]
+		if !found || len(url.License) == 0 {
+			batch[key] = fallbacks[key.PurlName]
+			continue
+		}
+		url.URL, _ = purlutils.ProjectUrl(key.PurlName, purlType)
+		batch[key] = url
+	}
+	return nil
+}
+
 // getURLsByGolangPurl searches golang_projects table first, then converts a golang purl to a GitHub purl and searches all_urls.
 func (m *AllUrlsModel) getURLsByGolangPurl(component componentHelper.Component) (AllURL, error) {
 	// First try the golang_projects table
@@ -150,7 +318,11 @@ func (m *AllUrlsModel) GetURLsByPurlNameType(purlName, purlType string) (AllURL,
 	}
 	m.s.Debugf("Found %v results for %v, %v.", len(allUrls), purlType, purlName)
 	// Pick one URL to return (checking for license details also)
-	return pickOneURL(m.s, m.project, m.mineModel, allUrls, purlName, purlType)
+	url, err := pickOneURL(m.s, m.project, m.mineModel, allUrls, purlName, purlType)
+	if err != nil {
+		return url, err
+	}
+	return m.addLiveMetadata(url, purlName, purlType, ""), nil
 }
 
 // GetURLsByPurlNameTypeVersion searches for component details of the specified Purl Name/Type and version.
@@ -178,7 +350,137 @@ func (m *AllUrlsModel) GetURLsByPurlNameTypeVersion(purlName, purlType, purlVers
 	}
 	m.s.Debugf("Found %v results for %v, %v.", len(allUrls), purlType, purlName)
 	// Pick one URL to return (checking for license details also)
-	return pickOneURL(m.s, m.project, m.mineModel, allUrls, purlName, purlType)
+	url, err := pickOneURL(m.s, m.project, m.mineModel, allUrls, purlName, purlType)
+	if err != nil {
+		return url, err
+	}
+	return m.addLiveMetadata(url, purlName, purlType, purlVersion), nil
+}
+
+// GetVersionsByPurlNameType returns the (unsorted) versions of the specified Purl Name/Type known to the KB.
+// The versions of private packages are taken from the private package registry instead.
+func (m *AllUrlsModel) GetVersionsByPurlNameType(purlName, purlType string) ([]string, error) {
+	if len(purlName) == 0 || len(purlType) == 0 {
+		m.s.Errorf("Please specify a valid Purl Name/Type to query: %v, %v", purlName, purlType)
+		return nil, errors.New("please specify a valid Purl Name/Type to query")
+	}
+	if m.private != nil {
+		if versions, isPrivate := m.private.GetVersions(purlType, purlName); isPrivate {
+			return versions, nil
+		}
+	}
+	var versions []string
+	err := m.q.SelectContext(m.ctx, &versions,
+		"SELECT DISTINCT v.version_name FROM all_urls u"+mineLeftJoinSQL+verLeftJoinSQL+
+			" WHERE m.purl_type = $1 AND u.purl_name = $2 AND v.version_name != ''",
+		purlType, purlName)
+	if err != nil {
+		m.s.Errorf("Failed to query all urls table for versions of %v - %v: %v", purlType, purlName, err)
+		return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	return versions, nil
+}
+
+// GetComponentVersions returns the (unsorted) versions of the specified Purl Name/Type known to the KB, with their
+// release date and (curated) license, falling back to the project license for versions without one.
+// The versions of private packages are taken from the private package registry instead.
+func (m *AllUrlsModel) GetComponentVersions(purlName, purlType string) ([]ComponentVersion, error) {
+	if len(purlName) == 0 || len(purlType) == 0 {
+		m.s.Errorf("Please specify a valid Purl Name/Type to query: %v, %v", purlName, purlType)
+		return nil, errors.New("please specify a valid Purl Name/Type to query")
+	}
+	if m.private != nil {
+		if versions, isPrivate := m.private.GetVersions(purlType, purlName); isPrivate {
+			componentVersions := make([]ComponentVersion, 0, len(versions))
+			for _, version := range versions {
+				url, _ := m.private.GetURL(componentHelper.Component{Name: purlName, PurlType: purlType, Version: version})
+				componentVersions = append(componentVersions, ComponentVersion{Version: version, License: url.License,
+					LicenseID: url.LicenseID, IsSpdx: url.IsSpdx})
+			}
+			return m.curateComponentVersions(componentVersions, purlName, purlType), nil
+		}
+	}
+	var rows []ComponentVersion
+	err := m.q.SelectContext(m.ctx, &rows,
+		"SELECT v.version_name AS version, COALESCE(CAST(u.date AS TEXT), '') AS date, COALESCE(l.license_name, '') AS license,"+
+			" COALESCE(l.spdx_id, '') AS license_id, COALESCE(l.is_spdx, false) AS is_spdx, u.mine_id FROM all_urls u"+
+			mineLeftJoinSQL+licLeftJoinSQL+verLeftJoinSQL+
+			" WHERE m.purl_type = $1 AND u.purl_name = $2 AND v.version_name != '' ORDER BY date DESC",
+		purlType, purlName)
+	if err != nil {
+		m.s.Errorf("Failed to query all urls table for versions of %v - %v: %v", purlType, purlName, err)
+		return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	// Keep one entry per version, filling in any missing details from its other URLs
+	var versions []ComponentVersion
+	indexes := make(map[string]int)
+	for _, row := range rows {
+		i, found := indexes[row.Version]
+		if !found {
+			indexes[row.Version] = len(versions)
+			versions = append(versions, row)
+			continue
+		}
+		if len(versions[i].Date) == 0 {
+			versions[i].Date = row.Date
+		}
+		if len(versions[i].License) == 0 {
+			versions[i].License, versions[i].LicenseID, versions[i].IsSpdx = row.License, row.LicenseID, row.IsSpdx
+		}
+	}
+	return m.curateComponentVersions(versions, purlName, purlType), nil
+}
+
+// curateComponentVersions fills in the license of versions without one from their project (looked up once per mine),
+// then applies the curations matching each version.
+func (m *AllUrlsModel) curateComponentVersions(versions []ComponentVersion, purlName, purlType string) []ComponentVersion {
+	projects := make(map[int32]Project)
+	for i, version := range versions {
+		url := AllURL{License: version.License, LicenseID: version.LicenseID, IsSpdx: version.IsSpdx, MineID: version.MineID}
+		if len(url.License) == 0 && m.project != nil && version.MineID != 0 {
+			project, found := projects[version.MineID]
+			if !found {
+				var err error
+				if project, err = m.project.GetProjectByPurlName(purlName, version.MineID); err != nil {
+					m.s.Warnf("Problem searching projects table for %v, %v: %v", purlName, purlType, err)
+				}
+				projects[version.MineID] = project
+			}
+			addProjectLicense(m.s, &url, project)
+		}
+		if m.curation != nil {
+			url = m.curation.CurateURL(url, purlType, purlName, version.Version)
+		}
+		versions[i].License, versions[i].LicenseID, versions[i].IsSpdx = url.License, url.LicenseID, url.IsSpdx
+	}
+	return versions
+}
+
+// addLiveMetadata fills in the details of a component without license data from its upstream registry (if enabled).
+func (m *AllUrlsModel) addLiveMetadata(url AllURL, purlName, purlType, purlVersion string) AllURL {
+	if m.live == nil || len(url.License) > 0 {
+		return url
+	}
+	liveURL, err := m.live.GetLiveURL(purlName, purlType, purlVersion)
+	if err != nil {
+		if !errors.Is(err, ErrLiveMetadataUnavailable) {
+			m.s.Infof("Ran into an issue looking up live info for: %v - %v@%v. Ignoring", purlType, purlName, purlVersion)
+		}
+		return url
+	}
+	m.s.Debugf("Retrieved live data for %v - %v: %#v", purlType, purlName, liveURL)
+	if len(url.PurlName) == 0 {
+		url.MineID = liveURL.MineID
+	}
+	url.Component, url.PurlName = liveURL.Component, liveURL.PurlName
+	url.License, url.LicenseID, url.IsSpdx = liveURL.License, liveURL.LicenseID, liveURL.IsSpdx
+	if len(url.Version) == 0 {
+		url.Version = liveURL.Version
+	}
+	if len(liveURL.URL) > 0 {
+		url.URL = liveURL.URL
+	}
+	return url
 }
 
 // pickOneURL takes the potential matching component/versions and selects the most appropriate one.
@@ -228,9 +530,16 @@ func buildFallbackURL(s *zap.SugaredLogger, projModel *ProjectModel, mineModel *
 func GetURLFromProject(s *zap.SugaredLogger, projModel *ProjectModel, url *AllURL, purlName, purlType string) {
 	project, err := projModel.GetProjectByPurlName(purlName, url.MineID)
 	s.Debugf("Getting URL from projects: %v", project)
-	switch {
-	case err != nil:
+	if err != nil {
 		s.Warnf("Problem searching projects table for %v, %v", purlName, purlType)
+		return
+	}
+	addProjectLicense(s, url, project)
+}
+
+// addProjectLicense fills in the license details of a URL from its project (or the project git license).
+func addProjectLicense(s *zap.SugaredLogger, url *AllURL, project Project) {
+	switch {
 	case len(project.License) > 0:
 		s.Debugf("Adding project license data to %v from %v", url, project)
 		url.License = project.License
@@ -243,3 +552,49 @@ func GetURLFromProject(s *zap.SugaredLogger, projModel *ProjectModel, url *AllUR
 		url.LicenseID = project.GitLicenseID
 	}
 }
+
+// buildFallbackURLs behaves like buildFallbackURL for many purl names of the same type, looking up their projects together.
+func buildFallbackURLs(s *zap.SugaredLogger, projModel *ProjectModel, mineModel *MineModel, purlNames []string, purlType string) map[string]AllURL {
+	urls := make(map[string]AllURL, len(purlNames))
+	for _, purlName := range purlNames {
+		projectURL, err := purlutils.ProjectUrl(purlName, purlType)
+		if err != nil {
+			s.Errorf("Failed to retrieve project URL for %v, %v: %v", purlName, purlType, err)
+		}
+		urls[purlName] = AllURL{URL: projectURL}
+	}
+	if len(purlNames) == 0 || projModel == nil || mineModel == nil {
+		return urls
+	}
+	mineIds, err := mineModel.GetMineIdsByPurlType(purlType)
+	if err != nil {
+		s.Errorf("No component match (in urls) found for %v %v components: %v", len(purlNames), purlType, err)
+		return urls
+	}
+	projects, err := projModel.GetProjectsByPurlNames(purlNames, mineIds)
+	if err != nil {
+		s.Warnf("Problem searching projects table for %v %v components", len(purlNames), purlType)
+	}
+	for _, purlName := range purlNames {
+		url := urls[purlName]
+		url.PurlName = purlName
+		for _, mineID := range mineIds {
+			url.MineID = mineID
+			addProjectLicense(s, &url, projects[ProjectKey{PurlName: purlName, MineID: mineID}])
+			if len(url.License) > 0 {
+				break
+			}
+		}
+		urls[purlName] = url
+	}
+	return urls
+}
+
+// toAnySlice converts a list of strings into query arguments.
+func toAnySlice(values []string) []any {
+	args := make([]any, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return args
+}
@@ -19,6 +19,8 @@ package models
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
@@ -51,7 +53,7 @@ func TestAllUrlsSearch(t *testing.T) {
 	myConfig.Components.CommitMissing = true
 	myConfig.Database.Trace = true
 	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db),
-		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace))
+		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace), nil, nil, nil)
 
 	allUrls, err := allUrlsModel.GetURLsByPurlNameType("tablestyle", "gem")
 	if err != nil {
@@ -150,7 +152,7 @@ func TestAllUrlsSearchVersion(t *testing.T) {
 	myConfig.Components.CommitMissing = true
 	myConfig.Database.Trace = true
 	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db),
-		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace))
+		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace), nil, nil, nil)
 
 	allUrls, err := allUrlsModel.GetURLsByPurlNameTypeVersion("tablestyle", "gem", "0.0.12")
 	if err != nil {
@@ -196,6 +198,70 @@ func TestAllUrlsSearchVersion(t *testing.T) {
 	if len(allUrls.PurlName) == 0 && len(allUrls.License) == 0 {
 		t.Errorf("all_urls.GetURLsByPurlString() No fallback URL returned from query")
 	}
+
+	versions, err := allUrlsModel.GetVersionsByPurlNameType("tablestyle", "gem")
+	if err != nil {
+		t.Errorf("all_urls.GetVersionsByPurlNameType() error = %v", err)
+	}
+	if !slices.Contains(versions, "0.0.12") || !slices.Contains(versions, "0.0.7") {
+		t.Errorf("all_urls.GetVersionsByPurlNameType() expected the known versions, got %v", versions)
+	}
+	if _, err = allUrlsModel.GetVersionsByPurlNameType("", "gem"); err == nil {
+		t.Errorf("all_urls.GetVersionsByPurlNameType() error = did not get an error")
+	}
+
+	componentVersions, err := allUrlsModel.GetComponentVersions("isbinaryfile", "npm")
+	if err != nil {
+		t.Errorf("all_urls.GetComponentVersions() error = %v", err)
+	}
+	found := false
+	for _, version := range componentVersions {
+		if version.Version == "4.0.8" {
+			found = version.Date == "2021-04-29" && len(version.License) > 0
+		}
+	}
+	if len(componentVersions) == 0 || !found {
+		t.Errorf("all_urls.GetComponentVersions() expected version 4.0.8 with its date and license, got %v", componentVersions)
+	}
+	if _, err = allUrlsModel.GetComponentVersions("", "npm"); err == nil {
+		t.Errorf("all_urls.GetComponentVersions() error = did not get an error")
+	}
+}
+
+func TestAllUrlsComponentVersionsPostgres(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := postgresSetup(t, ctx)
+	// One version with a release date, and one without
+	for _, statement := range []string{
+		"INSERT INTO mines (id, mine_name, purl_type) VALUES (1, 'npm', 'npm')",
+		"INSERT INTO licenses (id, license_name, spdx_id, is_spdx) VALUES (1, 'MIT', 'MIT', true)",
+		"INSERT INTO versions (id, version_name) VALUES (1, '1.0.0'), (2, '2.0.0')",
+		"INSERT INTO all_urls (package_hash, url, url_hash, mine_id, purl_name, version_id, license_id, date) VALUES" +
+			" ('h1', 'u1', 'uh1', 1, 'pg-test', 1, 1, '2021-04-29'), ('h2', 'u2', 'uh2', 1, 'pg-test', 2, 1, NULL)",
+	} {
+		if _, err = db.ExecContext(ctx, statement); err != nil {
+			t.Fatalf("an error '%s' was not expected when loading the test data", err)
+		}
+	}
+	allUrlsModel := NewAllURLModel(ctx, s, db, nil, nil, NewMineModel(ctx, s, db),
+		database.NewDBSelectContext(s, db, nil, false), nil, nil, nil)
+	componentVersions, err := allUrlsModel.GetComponentVersions("pg-test", "npm")
+	if err != nil {
+		t.Fatalf("all_urls.GetComponentVersions() error = %v", err)
+	}
+	want := []ComponentVersion{
+		{Version: "1.0.0", Date: "2021-04-29", License: "MIT", LicenseID: "MIT", IsSpdx: true, MineID: 1},
+		{Version: "2.0.0", License: "MIT", LicenseID: "MIT", IsSpdx: true, MineID: 1},
+	}
+	if !reflect.DeepEqual(componentVersions, want) {
+		t.Errorf("all_urls.GetComponentVersions() = %+v, want %+v", componentVersions, want)
+	}
 }
 
 func TestAllUrlsSearchVersionRequirement(t *testing.T) {
@@ -221,7 +287,7 @@ func TestAllUrlsSearchVersionRequirement(t *testing.T) {
 	myConfig.Components.CommitMissing = true
 	myConfig.Database.Trace = true
 	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db),
-		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace))
+		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace), nil, nil, nil)
 
 	allUrls, err := allUrlsModel.GetURLsByPurlString(componentHelper.Component{Purl: "pkg:gem/tablestyle", Name: "tablestyle", PurlType: "gem", Requirement: ">0.0.4"})
 	if err != nil {
@@ -263,7 +329,7 @@ func TestAllUrlsSearchNoProject(t *testing.T) {
 	}
 	myConfig.Components.CommitMissing = true
 	myConfig.App.Trace = true
-	allUrlsModel := NewAllURLModel(ctx, s, db, nil, NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace)) //nolint:lll // test setup
+	allUrlsModel := NewAllURLModel(ctx, s, db, nil, NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace), nil, nil, nil) //nolint:lll // test setup
 
 	allUrls, err := allUrlsModel.GetURLsByPurlNameType("tablestyle", "gem")
 	if err != nil {
@@ -298,7 +364,7 @@ func TestAllUrlsSearchNoLicense(t *testing.T) {
 	myConfig.Components.CommitMissing = true
 	myConfig.App.Trace = true
 	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db),
-		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace))
+		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace), nil, nil, nil)
 
 	allUrls, err := allUrlsModel.GetURLsByPurlString(componentHelper.Component{Purl: "pkg:gem/tablestyle", Name: "tablestyle", PurlType: "gem", Version: "0.0.8"})
 	if err != nil {
@@ -329,7 +395,7 @@ func TestAllUrlsSearchBadSql(t *testing.T) {
 	myConfig.Components.CommitMissing = true
 	myConfig.App.Trace = true
 	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db),
-		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace))
+		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, conn, myConfig.Database.Trace), nil, nil, nil)
 	_, err = allUrlsModel.GetURLsByPurlString(componentHelper.Component{Purl: "pkg:gem/tablestyle", Name: "tablestyle", PurlType: "gem"})
 	if err == nil {
 		t.Errorf("all_urls.GetURLsByPurlString() error = did not get an error")
@@ -357,3 +423,55 @@ func TestAllUrlsSearchBadSql(t *testing.T) {
 	}
 	fmt.Printf("All Urls: %v\n", allUrls)
 }
+
+func TestAllUrlsBatch(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db),
+		NewGolangProjectModel(ctx, s, db, myConfig), NewMineModel(ctx, s, db), database.NewDBSelectContext(s, db, nil, false), nil, nil, nil)
+
+	components := []componentHelper.Component{
+		{Purl: "pkg:gem/tablestyle", Name: "tablestyle", PurlType: "gem"},
+		{Purl: "pkg:gem/tablestyle@0.0.12", Name: "tablestyle", PurlType: "gem", Version: "0.0.12"},
+		{Purl: "pkg:gem/tablestyle@0.0.7", Name: "tablestyle", PurlType: "gem", Version: "0.0.7"},
+		{Purl: "pkg:gem/tablestyle@22.22.22", Name: "tablestyle", PurlType: "gem", Version: "22.22.22"}, // Fallback URL
+		{Purl: "pkg:npm/%40leaflink/stash@31.1.2", Name: "%40leaflink/stash", PurlType: "npm", Version: "31.1.2"},
+		{Purl: "pkg:npm/NONEXISTENT", Name: "NONEXISTENT", PurlType: "npm"},
+		{Purl: "pkg:gem/tablestyle@0.0.7", Name: "tablestyle", PurlType: "gem", Version: "0.0.7"}, // Duplicate
+	}
+	batch, err := allUrlsModel.GetURLsBatch(components)
+	if err != nil {
+		t.Fatalf("all_urls.GetURLsBatch() error = %v", err)
+	}
+	if len(batch) != len(components)-1 {
+		t.Errorf("all_urls.GetURLsBatch() returned %v components, want %v", len(batch), len(components)-1)
+	}
+	for _, component := range components {
+		want, err := allUrlsModel.GetURLsByPurlString(component)
+		if err != nil {
+			t.Fatalf("all_urls.GetURLsByPurlString() error = %v", err)
+		}
+		got, err := allUrlsModel.GetURLsByPurlStringFromBatch(component, batch)
+		if err != nil {
+			t.Fatalf("all_urls.GetURLsByPurlStringFromBatch() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("all_urls.GetURLsByPurlStringFromBatch(%v) = %#v, want %#v", component.Purl, got, want)
+		}
+	}
+}
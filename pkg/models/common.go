@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
@@ -50,7 +51,9 @@ func loadSQLData(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn, filename str
 func LoadTestSQLData(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn) error {
 	files := []string{"../models/tests/mines.sql", "../models/tests/all_urls.sql", "../models/tests/projects.sql",
 		"../models/tests/licenses.sql", "../models/tests/versions.sql", "../models/tests/npmjs_dependencies.sql",
-		"../models/tests/golang_projects.sql",
+		"../models/tests/golang_projects.sql", "../models/tests/maven_dependencies.sql", "../models/tests/maven_metadata.sql",
+		"../models/tests/live_components.sql", "../models/tests/curations.sql",
+		"../models/tests/kb_metadata.sql",
 	}
 	return loadTestSQLDataFiles(db, ctx, conn, files)
 }
@@ -97,3 +100,22 @@ func CloseRows(rows *sqlx.Rows) {
 		}
 	}
 }
+
+// sqlPlaceholders returns a comma separated list of count positional parameters, starting at $first.
+func sqlPlaceholders(first, count int) string {
+	placeholders := make([]string, count)
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// sqlPlaceholderPairs returns a comma separated list of count row values of two positional parameters
+// (i.e. ($2, $3), ($4, $5)), starting at $first.
+func sqlPlaceholderPairs(first, count int) string {
+	pairs := make([]string, count)
+	for i := range pairs {
+		pairs[i] = fmt.Sprintf("($%d, $%d)", first+2*i, first+2*i+1)
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -18,9 +18,13 @@ package models
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
 	_ "modernc.org/sqlite"
 )
 
@@ -33,6 +37,34 @@ func sqliteSetup(t *testing.T) *sqlx.DB {
 	return db
 }
 
+// postgresSetup connects to the PostgreSQL DB given in DB_TEST_POSTGRES_DSN (skipping the test if it is not set),
+// and migrates a temporary schema for the test, dropped once the test completes.
+func postgresSetup(t *testing.T, ctx context.Context) *sqlx.DB {
+	dsn := os.Getenv("DB_TEST_POSTGRES_DSN")
+	if len(dsn) == 0 {
+		t.Skip("DB_TEST_POSTGRES_DSN is not set")
+	}
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a PostgreSQL database connection", err)
+	}
+	db.SetMaxOpenConns(1) // The search path only applies to the current connection
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err = db.ExecContext(ctx, "CREATE SCHEMA "+schema+"; SET search_path TO "+schema); err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the test schema", err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Errorf("an error '%s' was not expected when dropping the test schema", err)
+		}
+		CloseDB(db)
+	})
+	if _, err = MigrateSchema(ctx, db); err != nil {
+		t.Fatalf("MigrateSchema() unexpected error = %v", err)
+	}
+	return db
+}
+
 // sqliteConn sets up a connection to a test DB.
 func sqliteConn(t *testing.T, ctx context.Context, db *sqlx.DB) *sqlx.Conn {
 	conn, err := db.Connx(ctx) // Get a connection from the pool
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the curations table and file
+
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	purlutils "github.com/scanoss/go-purl-helper/pkg"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// Names of the component fields that can be overridden by a curation.
+const (
+	CuratedLicense = "license"
+	CuratedURL     = "url"
+)
+
+// Curation overrides the knowledge base details of the versions of a component matching its version range.
+type Curation struct {
+	Purl               string                 `json:"purl"`          // Purl without version (file curations only)
+	VersionRange       string                 `json:"version_range"` // Empty (or "*") matches all versions
+	License            string                 `json:"license"`
+	URL                string                 `json:"url"`
+	AddDependencies    []UnresolvedDependency `json:"add_dependencies"`
+	RemoveDependencies []string               `json:"remove_dependencies"` // Purl names of the dependencies to remove
+	Reason             string                 `json:"reason"`
+	PurlType           string                 `json:"-"`
+	PurlName           string                 `json:"-"`
+}
+
+type CurationModel struct {
+	ctx    context.Context
+	s      *zap.SugaredLogger
+	db     *sqlx.DB
+	config *myconfig.ServerConfig
+	lic    *LicenseModel
+}
+
+// curationFile caches the contents of the curation file, keyed by purl type and name, until it is modified.
+var curationFile struct {
+	sync.Mutex
+	path      string
+	modTime   time.Time
+	curations map[string][]Curation
+}
+
+// NewCurationModel creates a new instance of the Curation Model.
+func NewCurationModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, config *myconfig.ServerConfig) *CurationModel {
+	return &CurationModel{ctx: ctx, s: s, db: db, config: config, lic: NewLicenseModel(ctx, s, db)}
+}
+
+// GetCurations returns the curations applying to the given component version, in the order they should be applied
+// (the curation file first, then the curations table). An empty version only matches curations for all versions.
+func (m *CurationModel) GetCurations(purlType, purlName, version string) []Curation {
+	if len(purlType) == 0 || len(purlName) == 0 {
+		return nil
+	}
+	var matches []Curation
+	fileCurations, err := m.getFileCurations()
+	if err != nil {
+		m.s.Warnf("Ignoring curation file %v: %v", m.config.Curation.File, err)
+	}
+	for _, curation := range fileCurations[purlType+"/"+purlName] {
+		if curationMatches(purlType, version, curation.VersionRange) {
+			matches = append(matches, curation)
+		}
+	}
+	for _, curation := range m.getStoredCurations(purlType, purlName) {
+		if curationMatches(purlType, version, curation.VersionRange) {
+			matches = append(matches, curation)
+		}
+	}
+	return matches
+}
+
+// curationMatches reports whether the given version is covered by a curation version range.
+func curationMatches(purlType, version, versionRange string) bool {
+	if len(versionRange) == 0 || versionRange == "*" {
+		return true
+	}
+	return len(version) > 0 && shared.VersionInRange(purlType, version, versionRange)
+}
+
+// getStoredCurations retrieves the curations of the given component from the curations table.
+func (m *CurationModel) getStoredCurations(purlType, purlName string) []Curation {
+	if m.db == nil {
+		return nil
+	}
+	var rows []struct {
+		VersionRange       string `db:"version_range"`
+		License            string `db:"license"`
+		URL                string `db:"url"`
+		AddDependencies    []byte `db:"add_dependencies"`
+		RemoveDependencies []byte `db:"remove_dependencies"`
+		Reason             string `db:"reason"`
+	}
+	err := m.db.SelectContext(m.ctx, &rows,
+		"SELECT version_range, license, url, add_dependencies, remove_dependencies, reason FROM curations"+
+			" WHERE purl_type = $1 AND purl_name = $2 ORDER BY id",
+		purlType, purlName)
+	if err != nil {
+		m.s.Warnf("Problem encountered searching curations table for %v - %v: %v", purlType, purlName, err)
+		return nil
+	}
+	curations := make([]Curation, 0, len(rows))
+	for _, row := range rows {
+		curation := Curation{PurlType: purlType, PurlName: purlName, VersionRange: row.VersionRange,
+			License: row.License, URL: row.URL, Reason: row.Reason}
+		if err = unmarshalCurationList(row.AddDependencies, &curation.AddDependencies); err == nil {
+			err = unmarshalCurationList(row.RemoveDependencies, &curation.RemoveDependencies)
+		}
+		if err != nil {
+			m.s.Warnf("Ignoring invalid curation for %v - %v: %v", purlType, purlName, err)
+			continue
+		}
+		curations = append(curations, curation)
+	}
+	return curations
+}
+
+// Generation identifies the current curation file and curations table contents, and changes whenever they do.
+// The table generation is kept in kb_metadata, and bumped by a trigger on every change of the table.
+func (m *CurationModel) Generation() (string, error) {
+	generation := fileGeneration(m.config.Curation.File)
+	if m.db != nil {
+		table, err := NewKBMetadataModel(m.ctx, m.s, m.db).GetValue(KBCurationsKey)
+		if err != nil {
+			return "", err
+		}
+		generation += "|" + table
+	}
+	return generation, nil
+}
+
+// fileGeneration identifies the current version of an overlay file by its modification time and size.
+func fileGeneration(path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return path + "@missing"
+	}
+	return fmt.Sprintf("%s@%d/%d", path, info.ModTime().UnixNano(), info.Size())
+}
+
+// unmarshalCurationList decodes a (possibly empty) JSON list column of the curations table.
+func unmarshalCurationList(data []byte, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// getFileCurations returns the curations of the configured curation file, reloading it when it changes.
+func (m *CurationModel) getFileCurations() (map[string][]Curation, error) {
+	path := m.config.Curation.File
+	if len(path) == 0 {
+		return nil, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	curationFile.Lock()
+	defer curationFile.Unlock()
+	if curationFile.path == path && curationFile.modTime.Equal(info.ModTime()) {
+		return curationFile.curations, nil
+	}
+	curations, err := LoadCurationFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m.s.Infof("Loaded %v curated components from %v", len(curations), path)
+	curationFile.path, curationFile.modTime, curationFile.curations = path, info.ModTime(), curations
+	return curations, nil
+}
+
+// LoadCurationFile reads a JSON array of curations from the given file, keyed by purl type and name.
+func LoadCurationFile(path string) (map[string][]Curation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var curations []Curation
+	if err = json.Unmarshal(data, &curations); err != nil {
+		return nil, fmt.Errorf("invalid curation file: %v", err)
+	}
+	keyed := make(map[string][]Curation)
+	for i, curation := range curations {
+		purl, err := purlutils.PurlFromString(curation.Purl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid purl in curation %v (%q): %v", i+1, curation.Purl, err)
+		}
+		purlName, err := purlutils.PurlNameFromString(curation.Purl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid purl in curation %v (%q): %v", i+1, curation.Purl, err)
+		}
+		curation.PurlType, curation.PurlName = purl.Type, purlName
+		key := curation.PurlType + "/" + curation.PurlName
+		keyed[key] = append(keyed[key], curation)
+	}
+	return keyed, nil
+}
+
+// CurateURL overrides the license and URL of the given component details with the curations matching its version.
+// The overridden fields are recorded in the Curated list of the result.
+func (m *CurationModel) CurateURL(url AllURL, purlType, purlName, version string) AllURL {
+	for _, curation := range m.GetCurations(purlType, purlName, version) {
+		if len(curation.License) > 0 {
+			url.License, url.LicenseID, url.IsSpdx = curation.License, curation.License, false
+			license, _ := m.lic.GetLicenseByName(curation.License, false)
+			if len(license.LicenseName) > 0 {
+				url.License, url.LicenseID, url.IsSpdx = license.LicenseName, license.LicenseID, license.IsSpdx
+			}
+			url.Curated = appendCurated(url.Curated, CuratedLicense)
+		}
+		if len(curation.URL) > 0 {
+			url.URL = curation.URL
+			url.Curated = appendCurated(url.Curated, CuratedURL)
+		}
+		if len(curation.Reason) > 0 && !slices.Contains(url.CurationReasons, curation.Reason) {
+			url.CurationReasons = append(url.CurationReasons, curation.Reason)
+		}
+	}
+	return url
+}
+
+// CurateDependencies removes and adds the curated dependencies of the given component version.
+// Added dependencies replace any existing dependency on the same package and are flagged as curated.
+func (m *CurationModel) CurateDependencies(dependencies []UnresolvedDependency, purlType, purlName, version string) []UnresolvedDependency {
+	for _, curation := range m.GetCurations(purlType, purlName, version) {
+		remove := append([]string{}, curation.RemoveDependencies...)
+		for _, added := range curation.AddDependencies {
+			remove = append(remove, added.Purl)
+		}
+		if len(remove) > 0 {
+			kept := make([]UnresolvedDependency, 0, len(dependencies))
+			for _, dependency := range dependencies {
+				if !slices.Contains(remove, dependency.Purl) {
+					kept = append(kept, dependency)
+				}
+			}
+			dependencies = kept
+		}
+		for _, added := range curation.AddDependencies {
+			added.Curated = true
+			dependencies = append(dependencies, added)
+		}
+	}
+	return dependencies
+}
+
+// appendCurated adds the given field to the list of curated fields (if not already present).
+func appendCurated(curated []string, field string) []string {
+	if slices.Contains(curated, field) {
+		return curated
+	}
+	return append(curated, field)
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	componentHelper "github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/dependencies/pkg/config"
+)
+
+const testCurationFile = `[
+  {"purl": "pkg:npm/react", "url": "https://react.dev", "reason": "Canonical homepage"},
+  {"purl": "pkg:npm/%40leaflink/stash", "version_range": ">=31.0.0", "remove_dependencies": ["jsdom"]}
+]`
+
+func TestCurations(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Curation.File = filepath.Join(t.TempDir(), "curations.json")
+	if err = os.WriteFile(myConfig.Curation.File, []byte(testCurationFile), 0o600); err != nil {
+		t.Fatalf("failed to write curation file: %v", err)
+	}
+	curationModel := NewCurationModel(ctx, s, db, myConfig)
+
+	tests := []struct {
+		name        string
+		version     string
+		wantLicense string
+		wantCurated []string
+	}{
+		{name: "file and table curations", version: "17.0.2", wantLicense: "Apache-2.0", wantCurated: []string{CuratedURL, CuratedLicense}},
+		{name: "outside the curated range", version: "0.3.5", wantLicense: "MIT", wantCurated: []string{CuratedURL}},
+	}
+	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db), nil, NewMineModel(ctx, s, db),
+		database.NewDBSelectContext(s, db, nil, false), nil, curationModel, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, err := allUrlsModel.GetURLsByPurlString(componentHelper.Component{Name: "react", PurlType: "npm", Version: tt.version})
+			if err != nil {
+				t.Fatalf("GetURLsByPurlString() unexpected error = %v", err)
+			}
+			if url.License != tt.wantLicense || !slices.Equal(url.Curated, tt.wantCurated) {
+				t.Errorf("GetURLsByPurlString() license = %v, curated = %v, want %v, %v", url.License, url.Curated, tt.wantLicense, tt.wantCurated)
+			}
+			if url.URL != "https://react.dev" || !slices.Contains(url.CurationReasons, "Canonical homepage") {
+				t.Errorf("GetURLsByPurlString() expected the curated URL and reason, got %#v", url)
+			}
+		})
+	}
+
+	generation, err := curationModel.Generation()
+	if err != nil {
+		t.Fatalf("Generation() unexpected error = %v", err)
+	}
+	_, err = db.Exec("INSERT INTO curations (purl_type, purl_name, license, reason) VALUES ('npm', 'left-pad', 'MIT', 'test')")
+	if err != nil {
+		t.Fatalf("failed to add a curation: %v", err)
+	}
+	if updated, err := curationModel.Generation(); err != nil || updated == generation {
+		t.Errorf("Generation() = %v, %v, expected it to change with the curations table", updated, err)
+	}
+
+	dependencies, err := NewDependencyModel(ctx, s, db, curationModel, nil).GetDependencies("%40leaflink/stash", "31.1.2", "npm")
+	if err != nil {
+		t.Fatalf("GetDependencies() unexpected error = %v", err)
+	}
+	var added bool
+	for _, dependency := range dependencies {
+		switch dependency.Purl {
+		case "sass", "vite", "jsdom":
+			t.Errorf("GetDependencies() expected %v to be removed by a curation", dependency.Purl)
+		case "curated-dep":
+			added = dependency.Curated && dependency.Requirement == "^1.0.0"
+		}
+	}
+	if !added {
+		t.Errorf("GetDependencies() expected curated-dep to be added by a curation, got %#v", dependencies)
+	}
+
+	myConfig.Curation.File = filepath.Join(t.TempDir(), "invalid.json")
+	if err = os.WriteFile(myConfig.Curation.File, []byte(`[{"purl": "not a purl"}]`), 0o600); err != nil {
+		t.Fatalf("failed to write curation file: %v", err)
+	}
+	if _, err = LoadCurationFile(myConfig.Curation.File); err == nil {
+		t.Errorf("LoadCurationFile() expected an error for an invalid purl")
+	}
+	// An invalid curation file is ignored, but the curations table still applies
+	if curations := curationModel.GetCurations("npm", "react", "17.0.2"); len(curations) != 1 {
+		t.Errorf("GetCurations() expected only the table curation, got %#v", curations)
+	}
+}
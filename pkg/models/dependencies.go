@@ -31,19 +31,25 @@ import (
 )
 
 type DependencyModel struct {
-	ctx context.Context
-	s   *zap.SugaredLogger
-	db  *sqlx.DB
+	ctx      context.Context
+	s        *zap.SugaredLogger
+	db       *sqlx.DB
+	curation *CurationModel
+	private  *PrivatePackageModel
 }
 
 type UnresolvedDependency struct {
 	Purl        string `json:"dep_purl_name"`
 	Requirement string `json:"dep_ver"`
+	Curated     bool   `json:"-"` // Added by a curation
 }
 
 // NewDependencyModel create a new instance of the Dependency Model.
-func NewDependencyModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *DependencyModel {
-	return &DependencyModel{ctx: ctx, s: s, db: db}
+// If a curation model is supplied, curated dependencies are added/removed from the ones stored in the KB.
+// If a private package model is supplied, the dependencies of private packages are served from it instead of the KB.
+func NewDependencyModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, curation *CurationModel,
+	private *PrivatePackageModel) *DependencyModel {
+	return &DependencyModel{ctx: ctx, s: s, db: db, curation: curation, private: private}
 }
 
 func (m *DependencyModel) GetDependencies(purl string, version string, ecosystem string) ([]UnresolvedDependency, error) {
@@ -52,6 +58,11 @@ func (m *DependencyModel) GetDependencies(purl string, version string, ecosystem
 	if _, isEcosystemSupported := shared.RegisteredEcosystems[ecosystem]; !isEcosystemSupported {
 		return nil, errors.New("ecosystem not supported")
 	}
+	if m.private != nil {
+		if dependencies, isPrivate := m.private.GetDependencies(ecosystem, purl, version); isPrivate {
+			return m.curateDependencies(dependencies, purl, version, ecosystem), nil
+		}
+	}
 
 	var dependencies []UnresolvedDependency
 
@@ -63,7 +74,7 @@ func (m *DependencyModel) GetDependencies(purl string, version string, ecosystem
 	err := m.db.QueryRowxContext(m.ctx, query, purl, version).Scan(&jsonData)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return []UnresolvedDependency{}, nil
+			return m.curateDependencies([]UnresolvedDependency{}, purl, version, ecosystem), nil
 		}
 		m.s.Errorf("Error: Failed to query dependency table for %v_dependencies, purl: %v, version: %v:. Error:%#v", ecosystem, purl, version, err)
 		return dependencies, err
@@ -74,5 +85,32 @@ func (m *DependencyModel) GetDependencies(purl string, version string, ecosystem
 	if err != nil {
 		return dependencies, fmt.Errorf("failed to unmarshal dependency data: %v", err)
 	}
-	return dependencies, nil
+	return m.curateDependencies(dependencies, purl, version, ecosystem), nil
+}
+
+// GetDependencyVersions returns the versions of the given package with dependency data (stored or private).
+func (m *DependencyModel) GetDependencyVersions(purl string, ecosystem string) ([]string, error) {
+	if _, isEcosystemSupported := shared.RegisteredEcosystems[ecosystem]; !isEcosystemSupported {
+		return nil, errors.New("ecosystem not supported")
+	}
+	if m.private != nil {
+		if versions, isPrivate := m.private.GetVersions(ecosystem, purl); isPrivate {
+			return versions, nil
+		}
+	}
+	var versions []string
+	query := fmt.Sprintf("SELECT version FROM %s_dependencies WHERE purl_name = $1", shared.RegisteredEcosystems[ecosystem].Table)
+	if err := m.db.SelectContext(m.ctx, &versions, query, purl); err != nil {
+		m.s.Errorf("Error: Failed to query dependency table for %v_dependencies, purl: %v: %v", ecosystem, purl, err)
+		return nil, err
+	}
+	return versions, nil
+}
+
+// curateDependencies applies any curation of the given package version to its dependencies.
+func (m *DependencyModel) curateDependencies(dependencies []UnresolvedDependency, purl, version, ecosystem string) []UnresolvedDependency {
+	if m.curation == nil {
+		return dependencies
+	}
+	return m.curation.CurateDependencies(dependencies, ecosystem, purl, version)
 }
@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	_ "fmt"
+	"slices"
 	"testing"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
@@ -37,7 +38,7 @@ func TestGolangDependencies(t *testing.T) {
 	myConfig.Database.Trace = true
 
 	// Invalid ecosystem
-	dependenciesModel := NewDependencyModel(ctx, s, db)
+	dependenciesModel := NewDependencyModel(ctx, s, db, nil, nil)
 	_, err = dependenciesModel.GetDependencies("vue-phone", "1.0.8", "notExists")
 	if err == nil {
 		t.Errorf("FAILED: Expected an error when passing an invalid ecosystem, got err = nil")
@@ -50,4 +51,15 @@ func TestGolangDependencies(t *testing.T) {
 	if len(unresolvedDependencies) == 0 {
 		t.Errorf("FAILED: Expected dependencies, got 0, err = %v", err)
 	}
+
+	versions, err := dependenciesModel.GetDependencyVersions("vue-phone", "npm")
+	if err != nil {
+		t.Errorf("FAILED: Expected no errors, got err = %v", err)
+	}
+	if len(versions) != 3 || !slices.Contains(versions, "1.0.10") {
+		t.Errorf("FAILED: Expected the 3 versions with dependencies, got %v", versions)
+	}
+	if _, err = dependenciesModel.GetDependencyVersions("vue-phone", "notExists"); err == nil {
+		t.Errorf("FAILED: Expected an error when passing an invalid ecosystem, got err = nil")
+	}
 }
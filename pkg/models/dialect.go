@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle the differences between the supported database engines (PostgreSQL and SQLite)
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	myconfig "scanoss.com/dependencies/pkg/config"
+)
+
+// Dialect identifies the SQL flavour spoken by the knowledge base database.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// sqliteBusyTimeout is how long (in milliseconds) SQLite waits on a locked database before failing.
+const sqliteBusyTimeout = 5000
+
+// GetDialect returns the SQL dialect of the given database driver name.
+func GetDialect(driver string) Dialect {
+	switch strings.ToLower(driver) {
+	case "sqlite", "sqlite3":
+		return DialectSQLite
+	}
+	return DialectPostgres
+}
+
+// GetDBDialect returns the SQL dialect of the given database connection.
+func GetDBDialect(db *sqlx.DB) Dialect {
+	return GetDialect(db.DriverName())
+}
+
+// OpenDB opens a connection pool to the knowledge base configured in the server config.
+// For SQLite, DB_DSN is the path to the database file (or :memory:).
+func OpenDB(config *myconfig.ServerConfig) (*sqlx.DB, error) {
+	if GetDialect(config.Database.Driver) != DialectSQLite {
+		return gd.OpenDBConnection(config.Database.Dsn, config.Database.Driver, config.Database.User, config.Database.Passwd,
+			config.Database.Host, config.Database.Schema, config.Database.SslMode)
+	}
+	if len(config.Database.Dsn) == 0 {
+		return nil, errors.New("please specify the SQLite database file to use (DB_DSN)")
+	}
+	db, err := sqlx.Open(string(DialectSQLite), sqliteDSN(config.Database.Dsn))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	return db, nil
+}
+
+// SetDBOptions applies the connection pool settings suited to the configured database and checks it is reachable.
+func SetDBOptions(db *sqlx.DB, config *myconfig.ServerConfig) error {
+	if err := gd.SetDBOptionsAndPing(db); err != nil {
+		return err
+	}
+	if GetDBDialect(db) == DialectSQLite {
+		// SQLite only supports a single writer, so keep the pool small to avoid lock contention.
+		// In-memory databases are private to each connection, so they must not be pooled at all.
+		maxConns := 4
+		if isSQLiteMemoryDSN(config.Database.Dsn) {
+			maxConns = 1
+		}
+		db.SetMaxOpenConns(maxConns)
+		db.SetMaxIdleConns(maxConns)
+		db.SetConnMaxIdleTime(0)
+		db.SetConnMaxLifetime(0)
+	}
+	return nil
+}
+
+// sqliteDSN adds the pragmas required to share an SQLite database file between concurrent requests.
+func sqliteDSN(dsn string) string {
+	if strings.Contains(dsn, "_pragma=") {
+		return dsn // The user is already in control of the pragmas
+	}
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	pragmas := fmt.Sprintf("_pragma=busy_timeout(%d)", sqliteBusyTimeout)
+	if !isSQLiteMemoryDSN(dsn) {
+		pragmas += "&_pragma=journal_mode(WAL)"
+	}
+	return dsn + separator + pragmas
+}
+
+// isSQLiteMemoryDSN reports whether the given SQLite DSN refers to an in-memory database.
+func isSQLiteMemoryDSN(dsn string) bool {
+	return strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory")
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+func TestGetDialect(t *testing.T) {
+	tests := map[string]Dialect{"postgres": DialectPostgres, "sqlite": DialectSQLite, "sqlite3": DialectSQLite, "": DialectPostgres}
+	for driver, want := range tests {
+		if got := GetDialect(driver); got != want {
+			t.Errorf("GetDialect(%q) = %v, want %v", driver, got, want)
+		}
+	}
+}
+
+func TestSqliteDSN(t *testing.T) {
+	tests := map[string]string{
+		"kb.db":                         "kb.db?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+		"file:kb.db?mode=ro":            "file:kb.db?mode=ro&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+		":memory:":                      ":memory:?_pragma=busy_timeout(5000)",
+		"kb.db?_pragma=foreign_keys(1)": "kb.db?_pragma=foreign_keys(1)",
+	}
+	for dsn, want := range tests {
+		if got := sqliteDSN(dsn); got != want {
+			t.Errorf("sqliteDSN(%q) = %v, want %v", dsn, got, want)
+		}
+	}
+}
+
+func TestSQLiteKnowledgeBase(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Database.Driver = "sqlite"
+	_, err = OpenDB(myConfig)
+	if err == nil {
+		t.Errorf("OpenDB() expected an error when no SQLite file is specified")
+	}
+	myConfig.Database.Dsn = filepath.Join(t.TempDir(), "kb.db")
+	db, err := OpenDB(myConfig)
+	if err != nil {
+		t.Fatalf("OpenDB() unexpected error = %v", err)
+	}
+	defer CloseDB(db)
+	if err = SetDBOptions(db, myConfig); err != nil {
+		t.Fatalf("SetDBOptions() unexpected error = %v", err)
+	}
+	if _, err = MigrateSchema(ctx, db); err != nil {
+		t.Fatalf("MigrateSchema() unexpected error = %v", err)
+	}
+	// Every registered ecosystem must have a dependencies table
+	dependencyModel := NewDependencyModel(ctx, s, db, nil, nil)
+	for ecosystem := range shared.RegisteredEcosystems {
+		if _, err = dependencyModel.GetDependencies("does-not-exist", "1.0.0", ecosystem); err != nil {
+			t.Errorf("GetDependencies() unexpected error for %v = %v", ecosystem, err)
+		}
+	}
+	// Writes must work too (i.e. RETURNING clauses)
+	license, err := NewLicenseModel(ctx, s, db).GetLicenseByName("MIT", true)
+	if err != nil || license.ID <= 0 {
+		t.Errorf("GetLicenseByName() expected a new license, got %#v, err = %v", license, err)
+	}
+	version, err := NewVersionModel(ctx, s, db).GetVersionByName("1.0.0", true)
+	if err != nil || version.ID <= 0 {
+		t.Errorf("GetVersionByName() expected a new version, got %#v, err = %v", version, err)
+	}
+}
@@ -23,4 +23,10 @@
 // - Projects
 // - All URLs
 // - Golang Projects
+// - Dependencies
+// - Maven Metadata
+// - Live Components
+// - Curations
+// - Private Packages
+// - KB Metadata
 package models
@@ -21,6 +21,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/http"
 
 	pkggodevclient "github.com/guseggert/pkggodev-client"
 	"github.com/jmoiron/sqlx"
@@ -36,6 +37,8 @@ type GolangProjects struct {
 	s       *zap.SugaredLogger
 	db      *sqlx.DB
 	config  *myconfig.ServerConfig
+	client  *http.Client
+	live    LiveMetadataProvider
 	q       *database.DBQueryContext
 	ver     *VersionModel
 	lic     *LicenseModel
@@ -45,13 +48,19 @@ type GolangProjects struct {
 
 // NewGolangProjectModel creates a new instance of Golang Project Model.
 func NewGolangProjectModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, config *myconfig.ServerConfig) *GolangProjects {
-	return &GolangProjects{ctx: ctx, s: s, db: db, config: config,
+	client := newLiveHTTPClient(config)
+	return &GolangProjects{ctx: ctx, s: s, db: db, config: config, client: client, live: newPkgGoDevProvider(config, client),
 		q:   database.NewDBSelectContext(s, db, nil, config.Database.Trace),
 		ver: NewVersionModel(ctx, s, db), lic: NewLicenseModel(ctx, s, db), mine: NewMineModel(ctx, s, db),
 		project: NewProjectModel(ctx, s, db),
 	}
 }
 
+// SetTransport overrides the HTTP transport used to reach pkg.go.dev.
+func (m *GolangProjects) SetTransport(transport http.RoundTripper) {
+	m.client.Transport = transport
+}
+
 // GetGoLangURLByPurlString searches the Golang Projects for the specified Purl (and requirement).
 func (m *GolangProjects) GetGoLangURLByPurlString(purlString, purlReq string) (AllURL, error) {
 	if len(purlString) == 0 {
@@ -295,34 +304,23 @@ func (m *GolangProjects) queryPkgGoDev(purlName, purlVersion string) (AllURL, *p
 		m.s.Errorf("Please specify a valid Purl Name to query")
 		return AllURL{}, nil, false, errors.New("please specify a valid Purl Name to query")
 	}
-	client := pkggodevclient.New()
-	pkg := purlName
-	if len(purlVersion) > 0 {
-		pkg = fmt.Sprintf("%s@%s", purlName, purlVersion)
-	}
-	latest := false
-	m.s.Debugf("Checking pkg.go.dev for the latest info: %v", pkg)
-	comp, err := client.DescribePackage(pkggodevclient.DescribePackageRequest{Package: pkg})
-	if err != nil && len(purlVersion) > 0 {
-		// We have a version zero search, so look for the latest one
-		m.s.Debugf("Failed to query pkg.go.dev for %v: %v. Trying without version...", pkg, err)
-		comp, err = client.DescribePackage(pkggodevclient.DescribePackageRequest{Package: purlName})
-		latest = true // Mark that this information is from the latest package and not a specific version
+	if m.config.LiveMetadata.Offline {
+		m.s.Debugf("Offline mode. Skipping %v lookup for %v@%v", m.live.Name(), purlName, purlVersion)
+		return AllURL{}, nil, false, ErrLiveLookupOffline
 	}
+	m.s.Debugf("Checking %v for the latest info: %v@%v", m.live.Name(), purlName, purlVersion)
+	metadata, err := m.live.GetMetadata(m.ctx, purlName, purlVersion)
 	if err != nil {
-		m.s.Warnf("Failed to query pkg.go.dev for %v: %v", pkg, err)
-		return AllURL{}, nil, latest, fmt.Errorf("failed to query pkg.go.dev: %v", err)
-	}
-	var version = comp.Version
-	if len(purlVersion) > 0 {
-		version = purlVersion // Force the requested version if specified (the returned value can be concatenated)
+		m.s.Warnf("Failed to query %v for %v@%v: %v", m.live.Name(), purlName, purlVersion, err)
+		return AllURL{}, nil, false, err
 	}
+	pkg, _ := metadata.Details.(*pkggodevclient.Package)
 	allURL := AllURL{
 		Component: purlName,
-		Version:   version,
-		License:   comp.License,
+		Version:   metadata.Version,
+		License:   metadata.License,
 		PurlName:  purlName,
-		URL:       fmt.Sprintf("https://%v", comp.Repository),
+		URL:       metadata.URL,
 	}
-	return allURL, comp, latest, nil
+	return allURL, pkg, metadata.Latest, nil
 }
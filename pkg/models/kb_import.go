@@ -0,0 +1,396 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle the bulk import of knowledge base rows (dependencies, all_urls and golang_projects tables)
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// maxImportParams caps the number of bind parameters of a single upsert statement (SQLite allows 32766).
+const maxImportParams = 30000
+
+// ImportRecord is a single row to import, keyed by column name. Values are the textual form of each column.
+type ImportRecord map[string]string
+
+// importColumnKind describes how an imported value is converted before being written.
+type importColumnKind int
+
+const (
+	importText      importColumnKind = iota
+	importInt                        // Integer value
+	importBool                       // Boolean value (true/false, 1/0)
+	importDepData                    // JSON array of {"dep_purl_name": "...", "dep_ver": "..."} objects
+	importVersionID                  // Integer value, looked up from the "version" column if missing
+	importLicenseID                  // Integer value, looked up from the "license" column if missing
+	importMineID                     // Integer value, looked up from the target purl type if missing
+)
+
+// importColumn describes a column that can be imported.
+type importColumn struct {
+	name     string
+	kind     importColumnKind
+	required bool
+	fallback any // Value written when the column is missing or empty (nil = NULL)
+}
+
+// importTarget describes a table that can be bulk imported.
+type importTarget struct {
+	table    string
+	keys     []string
+	purlType string // Purl type used to look up a missing mine id
+	columns  []importColumn
+}
+
+// importTargets lists the tables supported by the bulk import, keyed by table name.
+var importTargets = buildImportTargets()
+
+// buildImportTargets creates the import definition of every supported table.
+func buildImportTargets() map[string]importTarget {
+	targets := map[string]importTarget{
+		"all_urls": {
+			table: "all_urls",
+			keys:  []string{"package_hash", "url", "url_hash"},
+			columns: []importColumn{
+				{name: "package_hash", required: true},
+				{name: "url", required: true},
+				{name: "url_hash", required: true},
+				{name: "purl_name", required: true},
+				{name: "vendor"},
+				{name: "component"},
+				{name: "version"},
+				{name: "date"},
+				{name: "mine_id", kind: importInt},
+				{name: "license"},
+				{name: "version_id", kind: importVersionID},
+				{name: "license_id", kind: importLicenseID},
+			},
+		},
+		"golang_projects": {
+			table:    "golang_projects",
+			keys:     []string{"purl_name", "version"},
+			purlType: "golang",
+			columns: []importColumn{
+				{name: "purl_name", required: true},
+				{name: "version", required: true},
+				{name: "version_date", required: true},
+				{name: "component", fallback: ""},
+				{name: "version_id", kind: importVersionID, fallback: 0},
+				{name: "is_module", kind: importBool},
+				{name: "is_package", kind: importBool},
+				{name: "license", fallback: ""},
+				{name: "license_id", kind: importLicenseID, fallback: 0},
+				{name: "has_valid_go_mod_file", kind: importBool},
+				{name: "has_redistributable_license", kind: importBool},
+				{name: "has_tagged_version", kind: importBool},
+				{name: "has_stable_version", kind: importBool},
+				{name: "repository", fallback: ""},
+				{name: "is_indexed", kind: importBool},
+				{name: "mine_id", kind: importMineID},
+				{name: "index_timestamp", fallback: ""},
+			},
+		},
+	}
+	for _, location := range shared.RegisteredEcosystems {
+		table := location.Table + "_dependencies"
+		targets[table] = importTarget{
+			table: table,
+			keys:  []string{"purl_name", "version"},
+			columns: []importColumn{
+				{name: "purl_name", required: true},
+				{name: "version", required: true},
+				{name: "dep_data", kind: importDepData, required: true},
+			},
+		}
+	}
+	return targets
+}
+
+// GetImportTargets returns the names of the tables supported by the bulk import.
+func GetImportTargets() []string {
+	names := make([]string, 0, len(importTargets))
+	for name := range importTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// KBImportModel writes bulk imported records to a knowledge base table.
+type KBImportModel struct {
+	ctx      context.Context
+	s        *zap.SugaredLogger
+	db       *sqlx.DB
+	target   importTarget
+	version  *VersionModel
+	license  *LicenseModel
+	mine     *MineModel
+	versions map[string]int32 // Cache of the version ids already looked up
+	licenses map[string]int32 // Cache of the license ids already looked up
+	mineID   int32
+}
+
+// NewKBImportModel creates a new instance of the KB Import Model for the given table.
+func NewKBImportModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, table string) (*KBImportModel, error) {
+	target, ok := importTargets[table]
+	if !ok {
+		return nil, fmt.Errorf("unsupported import target %q (expected one of: %v)", table, strings.Join(GetImportTargets(), ", "))
+	}
+	return &KBImportModel{ctx: ctx, s: s, db: db, target: target,
+		version: NewVersionModel(ctx, s, db), license: NewLicenseModel(ctx, s, db), mine: NewMineModel(ctx, s, db),
+		versions: make(map[string]int32), licenses: make(map[string]int32),
+	}, nil
+}
+
+// MaxBatchSize returns the largest number of records that can be written in a single batch.
+func (m *KBImportModel) MaxBatchSize() int {
+	return maxImportParams / len(m.target.columns)
+}
+
+// ValidateRecord checks the given record can be imported into the target table.
+func (m *KBImportModel) ValidateRecord(record ImportRecord) error {
+	_, err := m.convertRecord(record, false)
+	return err
+}
+
+// UpsertRecords validates and writes the given records in a single transaction, replacing existing rows with the same key.
+func (m *KBImportModel) UpsertRecords(records []ImportRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if len(records) > m.MaxBatchSize() {
+		return fmt.Errorf("too many records in a single batch: %v (max %v)", len(records), m.MaxBatchSize())
+	}
+	// Convert every record (last one wins for duplicate keys, as a statement cannot update the same row twice)
+	rows := make(map[string][]any, len(records))
+	var order []string
+	for i, record := range records {
+		values, err := m.convertRecord(record, true)
+		if err != nil {
+			return fmt.Errorf("invalid record %v: %w", i+1, err)
+		}
+		key := m.recordKey(record)
+		if _, exists := rows[key]; !exists {
+			order = append(order, key)
+		}
+		rows[key] = values
+	}
+	columns := make([]string, len(m.target.columns))
+	for i, column := range m.target.columns {
+		columns[i] = column.name
+	}
+	var query strings.Builder
+	query.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES ", m.target.table, strings.Join(columns, ", ")))
+	args := make([]any, 0, len(order)*len(columns))
+	for i, key := range order {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		placeholders := make([]string, len(columns))
+		for j := range columns {
+			placeholders[j] = fmt.Sprintf("$%d", len(args)+j+1)
+		}
+		query.WriteString("(" + strings.Join(placeholders, ", ") + ")")
+		args = append(args, rows[key]...)
+	}
+	var updates []string
+	for _, column := range columns {
+		if !slices.Contains(m.target.keys, column) {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", column, column))
+		}
+	}
+	query.WriteString(fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(m.target.keys, ", "), strings.Join(updates, ", ")))
+
+	tx, err := m.db.BeginTxx(m.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start import transaction: %v", err)
+	}
+	if _, err = tx.ExecContext(m.ctx, query.String(), args...); err != nil {
+		_ = tx.Rollback()
+		m.s.Errorf("Error: Failed to import %v records into %v: %v", len(order), m.target.table, err)
+		return fmt.Errorf("failed to import records into %v: %v", m.target.table, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import into %v: %v", m.target.table, err)
+	}
+	return nil
+}
+
+// recordKey returns the primary key of the given record.
+func (m *KBImportModel) recordKey(record ImportRecord) string {
+	parts := make([]string, len(m.target.keys))
+	for i, key := range m.target.keys {
+		parts[i] = strings.TrimSpace(record[key])
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// convertRecord validates the given record and returns the values to write for each target column.
+// Reference ids (versions, licenses and mines) are only looked up (and created) when requested.
+func (m *KBImportModel) convertRecord(record ImportRecord, lookup bool) ([]any, error) {
+	for name := range record {
+		if !m.hasColumn(name) {
+			return nil, fmt.Errorf("unknown column %q for %v", name, m.target.table)
+		}
+	}
+	values := make([]any, len(m.target.columns))
+	for i, column := range m.target.columns {
+		raw := strings.TrimSpace(record[column.name])
+		if len(raw) == 0 {
+			if column.required {
+				return nil, fmt.Errorf("missing required column %q", column.name)
+			}
+			value, err := m.lookupValue(column, record, lookup)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+			continue
+		}
+		value, err := convertImportValue(column, raw)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// hasColumn reports whether the target table has an importable column with the given name.
+func (m *KBImportModel) hasColumn(name string) bool {
+	for _, column := range m.target.columns {
+		if column.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupValue returns the value to write for a column missing from the given record.
+func (m *KBImportModel) lookupValue(column importColumn, record ImportRecord, lookup bool) (any, error) {
+	if !lookup {
+		return column.fallback, nil
+	}
+	switch column.kind {
+	case importVersionID:
+		if name := strings.TrimSpace(record["version"]); len(name) > 0 {
+			return m.lookupID(m.versions, name, func() (int32, error) {
+				version, err := m.version.GetVersionByName(name, true)
+				return version.ID, err
+			})
+		}
+	case importLicenseID:
+		if name := strings.TrimSpace(record["license"]); len(name) > 0 {
+			return m.lookupID(m.licenses, name, func() (int32, error) {
+				license, err := m.license.GetLicenseByName(name, true)
+				return license.ID, err
+			})
+		}
+	case importMineID:
+		if m.mineID == 0 {
+			mineIDs, err := m.mine.GetMineIdsByPurlType(m.target.purlType)
+			if err != nil || len(mineIDs) == 0 {
+				return nil, fmt.Errorf("missing mine_id and no mine registered for %v: %v", m.target.purlType, err)
+			}
+			m.mineID = mineIDs[0]
+		}
+		return m.mineID, nil
+	}
+	return column.fallback, nil
+}
+
+// lookupID returns the cached id of the given name, or looks it up (creating it if necessary).
+func (m *KBImportModel) lookupID(cache map[string]int32, name string, lookup func() (int32, error)) (any, error) {
+	if id, ok := cache[name]; ok {
+		return id, nil
+	}
+	id, err := lookup()
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the id of %q: %v", name, err)
+	}
+	if id > 0 {
+		cache[name] = id
+	}
+	return id, nil
+}
+
+// convertImportValue converts the textual value of a column to the type written to the database.
+func convertImportValue(column importColumn, raw string) (any, error) {
+	switch column.kind {
+	case importInt, importVersionID, importLicenseID, importMineID:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("column %q must be an integer: %q", column.name, raw)
+		}
+		return value, nil
+	case importBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("column %q must be a boolean: %q", column.name, raw)
+		}
+		return value, nil
+	case importDepData:
+		return ValidateDepData(raw)
+	}
+	return raw, nil
+}
+
+// ValidateDepData checks the given dep_data is a JSON array of {"dep_purl_name": "...", "dep_ver": "..."} objects
+// and returns its compacted form.
+func ValidateDepData(depData string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(depData))
+	decoder.DisallowUnknownFields()
+	var dependencies []struct {
+		Purl        *string `json:"dep_purl_name"`
+		Requirement *string `json:"dep_ver"`
+	}
+	if err := decoder.Decode(&dependencies); err != nil {
+		return "", fmt.Errorf("invalid dep_data (expected a JSON array of dep_purl_name/dep_ver objects): %v", err)
+	}
+	if decoder.More() {
+		return "", errors.New("invalid dep_data: unexpected data after the JSON array")
+	}
+	if dependencies == nil {
+		return "", errors.New("invalid dep_data: expected a JSON array, got null")
+	}
+	for i, dependency := range dependencies {
+		if dependency.Purl == nil || len(strings.TrimSpace(*dependency.Purl)) == 0 {
+			return "", fmt.Errorf("invalid dep_data: dependency %v has no dep_purl_name", i+1)
+		}
+		if dependency.Requirement == nil {
+			return "", fmt.Errorf("invalid dep_data: dependency %v (%v) has no dep_ver", i+1, *dependency.Purl)
+		}
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(depData)); err != nil {
+		return "", fmt.Errorf("invalid dep_data: %v", err)
+	}
+	return compacted.String(), nil
+}
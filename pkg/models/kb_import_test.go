@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestValidateDepData(t *testing.T) {
+	tests := []struct {
+		name    string
+		depData string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty array", depData: "[]", want: "[]"},
+		{name: "valid", depData: `[ {"dep_purl_name": "a", "dep_ver": "^1.0"} ]`, want: `[{"dep_purl_name":"a","dep_ver":"^1.0"}]`},
+		{name: "empty requirement", depData: `[{"dep_purl_name": "a", "dep_ver": ""}]`, want: `[{"dep_purl_name":"a","dep_ver":""}]`},
+		{name: "null", depData: "null", wantErr: true},
+		{name: "object", depData: `{"dep_purl_name": "a", "dep_ver": "1"}`, wantErr: true},
+		{name: "missing purl", depData: `[{"dep_ver": "1"}]`, wantErr: true},
+		{name: "missing version", depData: `[{"dep_purl_name": "a"}]`, wantErr: true},
+		{name: "unknown field", depData: `[{"dep_purl_name": "a", "dep_ver": "1", "scope": "test"}]`, wantErr: true},
+		{name: "wrong type", depData: `[{"dep_purl_name": "a", "dep_ver": 1}]`, wantErr: true},
+		{name: "trailing data", depData: `[] []`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateDepData(tt.depData)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateDepData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateDepData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKBImportModel(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	_, err = NewKBImportModel(ctx, s, db, "licenses")
+	if err == nil {
+		t.Errorf("NewKBImportModel() expected an error for an unsupported table")
+	}
+	importModel, err := NewKBImportModel(ctx, s, db, "npmjs_dependencies")
+	if err != nil {
+		t.Fatalf("NewKBImportModel() unexpected error = %v", err)
+	}
+	if err = importModel.ValidateRecord(ImportRecord{"purl_name": "rails", "dep_data": "[]"}); err == nil {
+		t.Errorf("ValidateRecord() expected an error for a missing version")
+	}
+	// Duplicate keys within a batch must not fail (the last one wins)
+	err = importModel.UpsertRecords([]ImportRecord{
+		{"purl_name": "import-test", "version": "1.0", "dep_data": `[{"dep_purl_name": "a", "dep_ver": "1"}]`},
+		{"purl_name": "import-test", "version": "1.0", "dep_data": `[{"dep_purl_name": "b", "dep_ver": "2"}]`},
+	})
+	if err != nil {
+		t.Fatalf("UpsertRecords() unexpected error = %v", err)
+	}
+	deps, err := NewDependencyModel(ctx, s, db, nil, nil).GetDependencies("import-test", "1.0", "npm")
+	if err != nil || len(deps) != 1 || deps[0].Purl != "b" {
+		t.Errorf("GetDependencies() expected the last duplicate to win, got %#v, err = %v", deps, err)
+	}
+}
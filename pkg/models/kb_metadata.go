@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the kb_metadata table
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// Keys of the kb_metadata table.
+const (
+	KBSnapshotKey  = "snapshot"  // Knowledge base snapshot marker
+	KBCurationsKey = "curations" // Generation of the curations table (bumped by a trigger on every change)
+)
+
+type KBMetadataModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+// NewKBMetadataModel creates a new instance of the KB Metadata Model.
+func NewKBMetadataModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *KBMetadataModel {
+	return &KBMetadataModel{ctx: ctx, s: s, db: db}
+}
+
+// GetValue returns the value of the given metadata key (empty if it is not set).
+func (m *KBMetadataModel) GetValue(key string) (string, error) {
+	var value string
+	err := m.db.QueryRowxContext(m.ctx, "SELECT value FROM kb_metadata WHERE key = $1", key).Scan(&value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		m.s.Errorf("Failed to query kb_metadata for %v: %v", key, err)
+		return "", fmt.Errorf("failed to query the kb_metadata table: %v", err)
+	}
+	return value, nil
+}
+
+// SetValue creates or updates the given metadata key.
+func (m *KBMetadataModel) SetValue(key, value string) error {
+	_, err := m.db.ExecContext(m.ctx,
+		"INSERT INTO kb_metadata (key, value, updated_at) VALUES ($1, $2, $3)"+
+			" ON CONFLICT (key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at",
+		key, value, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to update the kb_metadata table: %v", err)
+	}
+	return nil
+}
+
+// GetSnapshot returns the snapshot marker of the loaded knowledge base (empty if it is unknown).
+func (m *KBMetadataModel) GetSnapshot() (string, error) {
+	return m.GetValue(KBSnapshotKey)
+}
+
+// SetSnapshot records the snapshot marker of the loaded knowledge base.
+func (m *KBMetadataModel) SetSnapshot(snapshot string) error {
+	return m.SetValue(KBSnapshotKey, snapshot)
+}
+
+// CompareSnapshots compares two snapshot markers (i.e. 2026.10.01 or 42), segment by segment.
+// Returns -1 if a < b, 0 if a == b and 1 if a > b.
+func CompareSnapshots(a, b string) int {
+	return shared.CompareVersions("", a, b)
+}
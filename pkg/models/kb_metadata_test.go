@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestKBMetadata(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, nil, []string{"./tests/kb_metadata.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	model := NewKBMetadataModel(ctx, s, db)
+	snapshot, err := model.GetSnapshot()
+	if err != nil || snapshot != "2026.10.01" {
+		t.Errorf("GetSnapshot() = %v, %v, want 2026.10.01", snapshot, err)
+	}
+	if err = model.SetSnapshot("2026.11.15"); err != nil {
+		t.Fatalf("SetSnapshot() unexpected error = %v", err)
+	}
+	if snapshot, _ = model.GetSnapshot(); snapshot != "2026.11.15" {
+		t.Errorf("GetSnapshot() = %v after an update, want 2026.11.15", snapshot)
+	}
+	if value, err := model.GetValue("missing"); err != nil || len(value) > 0 {
+		t.Errorf("GetValue() = %v, %v for a missing key, want an empty value", value, err)
+	}
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "2026.10.01", b: "2026.10.01", want: 0},
+		{a: "2026.10.01", b: "2026.9.30", want: 1},
+		{a: "41", b: "42", want: -1},
+		{a: "v2", b: "2.0.0", want: 0},
+	}
+	for _, tt := range tests {
+		if got := CompareSnapshots(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareSnapshots(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
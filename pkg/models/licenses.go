@@ -47,8 +47,6 @@ var bannedLicPrefixes = []string{"see ", "\"", "'", "-", "*", ".", "/", "?", "@"
 var bannedLicSuffixes = []string{".md", ".txt", ".html"}                                                    // unwanted license suffixes
 var whiteSpaceRegex = regexp.MustCompile(`\s+`)                                                             // generic whitespace regex
 
-// TODO add cache for licenses already searched for?
-
 // NewLicenseModel create a new instance of the License Model.
 func NewLicenseModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *LicenseModel {
 	return &LicenseModel{ctx: ctx, s: s, db: db}
@@ -60,6 +58,12 @@ func (m *LicenseModel) GetLicenseByID(id int32) (License, error) {
 		m.s.Error("Please specify a valid License ID to query")
 		return License{}, errors.New("please specify a valid License Name to query")
 	}
+	cache := GetReferenceCache()
+	if cache != nil {
+		if license, found := cache.getLicenseByID(id); found {
+			return license, nil
+		}
+	}
 	var license License
 	err := m.db.QueryRowxContext(m.ctx,
 		"SELECT id, license_name, spdx_id, is_spdx FROM licenses"+
@@ -69,6 +73,9 @@ func (m *LicenseModel) GetLicenseByID(id int32) (License, error) {
 		m.s.Errorf("Error: Failed to query license table for %v: %#v", id, err)
 		return License{}, fmt.Errorf("failed to query the license table: %v", err)
 	}
+	if cache != nil && len(license.LicenseName) > 0 {
+		cache.putLicense(license.LicenseName, license)
+	}
 	return license, nil
 }
 
@@ -78,6 +85,16 @@ func (m *LicenseModel) GetLicenseByName(name string, create bool) (License, erro
 		m.s.Warn("No License Name specified to query")
 		return License{}, nil
 	}
+	cache := GetReferenceCache()
+	if cache != nil {
+		license, found := cache.getLicense(name)
+		if found && (len(license.LicenseName) > 0 || !create) {
+			return license, nil
+		}
+		if found { // Cached as missing, but requested to create it (so look it up again)
+			cache.forgetLicense(name)
+		}
+	}
 	var license License
 	err := m.db.QueryRowxContext(m.ctx,
 		"SELECT id, license_name, spdx_id, is_spdx FROM licenses"+
@@ -89,7 +106,13 @@ func (m *LicenseModel) GetLicenseByName(name string, create bool) (License, erro
 		return License{}, fmt.Errorf("failed to query the license table: %v", err)
 	}
 	if create && len(license.LicenseName) == 0 { // No license found and requested to create an entry
-		return m.saveLicense(name)
+		license, err = m.saveLicense(name)
+		if err != nil {
+			return license, err
+		}
+	}
+	if cache != nil {
+		cache.putLicense(name, license)
 	}
 	return license, nil
 }
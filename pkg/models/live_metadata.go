@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the live_components table and the upstream registries
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/dependencies/pkg/config"
+)
+
+var (
+	// ErrLiveMetadataUnavailable is returned when there is no live lookup available for a purl type.
+	ErrLiveMetadataUnavailable = errors.New("live metadata lookup not available")
+	// ErrLiveLookupOffline is returned when a live lookup was skipped because the server runs in offline mode.
+	ErrLiveLookupOffline = errors.New("live lookup skipped (offline mode)")
+)
+
+type LiveMetadataModel struct {
+	ctx       context.Context
+	s         *zap.SugaredLogger
+	db        *sqlx.DB
+	config    *myconfig.ServerConfig
+	lic       *LicenseModel
+	mine      *MineModel
+	client    *http.Client
+	providers map[string]LiveMetadataProvider
+}
+
+// NewLiveMetadataModel creates a new instance of the Live Metadata Model.
+func NewLiveMetadataModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, config *myconfig.ServerConfig) *LiveMetadataModel {
+	client := newLiveHTTPClient(config)
+	return &LiveMetadataModel{ctx: ctx, s: s, db: db, config: config,
+		lic: NewLicenseModel(ctx, s, db), mine: NewMineModel(ctx, s, db),
+		client: client, providers: newLiveMetadataProviders(config, client),
+	}
+}
+
+// SetTransport overrides the HTTP transport used to reach the upstream registries.
+func (m *LiveMetadataModel) SetTransport(transport http.RoundTripper) {
+	m.client.Transport = transport
+}
+
+// newLiveHTTPClient creates the HTTP client used for live lookups.
+func newLiveHTTPClient(config *myconfig.ServerConfig) *http.Client {
+	return &http.Client{Timeout: time.Duration(config.LiveMetadata.Timeout) * time.Second}
+}
+
+// GetLiveURL retrieves the component details of the given purl from previously stored lookups or its upstream registry.
+// If requested (via config), versioned lookups will be committed to the live_components table.
+func (m *LiveMetadataModel) GetLiveURL(purlName, purlType, purlVersion string) (AllURL, error) {
+	if len(purlName) == 0 || len(purlType) == 0 {
+		m.s.Error("Please specify a valid Purl Name and Type to query")
+		return AllURL{}, errors.New("please specify a valid Purl Name and Type to query")
+	}
+	provider, ok := m.providers[purlType]
+	if !m.config.LiveMetadata.Enabled || !ok {
+		return AllURL{}, ErrLiveMetadataUnavailable
+	}
+	if len(purlVersion) > 0 {
+		stored, err := m.getStoredURL(purlName, purlType, purlVersion)
+		if err == nil && len(stored.License) > 0 {
+			return stored, nil
+		}
+	}
+	if m.config.LiveMetadata.Offline {
+		m.s.Debugf("Offline mode. Skipping %v lookup for %v@%v", provider.Name(), purlName, purlVersion)
+		return AllURL{}, ErrLiveLookupOffline
+	}
+	m.s.Debugf("Checking %v for live info: %v@%v", provider.Name(), purlName, purlVersion)
+	metadata, err := provider.GetMetadata(m.ctx, purlName, purlVersion)
+	if err != nil {
+		m.s.Infof("Failed to query %v for %v@%v: %v", provider.Name(), purlName, purlVersion, err)
+		return AllURL{}, fmt.Errorf("failed to query %v: %w", provider.Name(), err)
+	}
+	allURL := AllURL{
+		Component: purlName,
+		Version:   metadata.Version,
+		License:   metadata.License,
+		PurlName:  purlName,
+		URL:       metadata.URL,
+	}
+	cleansedLicense, err := CleanseLicenseName(metadata.License)
+	if err != nil {
+		return allURL, err
+	}
+	license, _ := m.lic.GetLicenseByName(cleansedLicense, m.config.Components.CommitMissing)
+	if len(license.LicenseName) > 0 {
+		allURL.License = license.LicenseName
+		allURL.LicenseID = license.LicenseID
+		allURL.IsSpdx = license.IsSpdx
+	}
+	mineIDs, _ := m.mine.GetMineIdsByPurlType(purlType)
+	if len(mineIDs) > 0 {
+		allURL.MineID = mineIDs[0] // Assign the first mine id
+	}
+	// Only versioned lookups are stored, as the "latest" version will change over time
+	if len(purlVersion) > 0 && m.config.Components.CommitMissing && license.ID > 0 {
+		_ = m.saveURL(allURL, purlType, license, provider.Name())
+	}
+	return allURL, nil
+}
+
+// getStoredURL retrieves the details of a previous live lookup from the live_components table.
+func (m *LiveMetadataModel) getStoredURL(purlName, purlType, purlVersion string) (AllURL, error) {
+	var row struct {
+		AllURL
+		RepoURL sql.NullString `db:"url"`
+	}
+	err := m.db.QueryRowxContext(m.ctx,
+		"SELECT purl_name AS component, version, l.license_name AS license, l.spdx_id AS license_id,"+
+			" l.is_spdx AS is_spdx, purl_name, url FROM live_components lc"+
+			" LEFT JOIN licenses l ON lc.license_id = l.id"+
+			" WHERE purl_type = $1 AND purl_name = $2 AND version = $3",
+		purlType, purlName, purlVersion,
+	).StructScan(&row)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			m.s.Warnf("Problem encountered searching live_components table for %v - %v@%v: %v", purlType, purlName, purlVersion, err)
+		}
+		return AllURL{}, err
+	}
+	allURL := row.AllURL
+	allURL.URL = row.RepoURL.String
+	mineIDs, _ := m.mine.GetMineIdsByPurlType(purlType)
+	if len(mineIDs) > 0 {
+		allURL.MineID = mineIDs[0]
+	}
+	return allURL, nil
+}
+
+// saveURL writes the given live lookup to the live_components table.
+func (m *LiveMetadataModel) saveURL(allURL AllURL, purlType string, license License, provider string) error {
+	m.s.Debugf("Attempting to save '%#v' to the live_components table...", allURL)
+	_, err := m.db.ExecContext(m.ctx,
+		"INSERT INTO live_components (purl_type, purl_name, version, license_id, url, provider, retrieved)"+
+			" VALUES($1, $2, $3, $4, $5, $6, $7)"+
+			" ON CONFLICT (purl_type, purl_name, version) DO UPDATE SET"+
+			" license_id = excluded.license_id, url = excluded.url, provider = excluded.provider, retrieved = excluded.retrieved",
+		purlType, allURL.PurlName, allURL.Version, license.ID, allURL.URL, provider, time.Now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		m.s.Errorf("Error: Failed to save live component %v - %v@%v: %v", purlType, allURL.PurlName, allURL.Version, err)
+		return fmt.Errorf("failed to save live component: %v", err)
+	}
+	return nil
+}
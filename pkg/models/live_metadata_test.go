@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/dependencies/pkg/config"
+)
+
+// liveRegistryResponses are the canned upstream registry responses served by the stub registry.
+var liveRegistryResponses = map[string]string{
+	"/npm/left-pad/1.3.0":                        `{"version": "1.3.0", "license": "WTFPL", "repository": {"type": "git", "url": "git+https://github.com/stevemao/left-pad.git"}}`,
+	"/npm/@scope%2Fpkg/latest":                   `{"version": "2.0.0", "license": {"type": "MIT"}}`,
+	"/maven/org/example/demo/maven-metadata.xml": `<metadata><versioning><latest>1.1-SNAPSHOT</latest><release>1.0</release></versioning></metadata>`,
+	"/maven/org/example/demo/1.0/demo-1.0.pom": `<project><url>https://example.org/demo</url>` +
+		`<licenses><license><name>Apache-2.0</name></license></licenses></project>`,
+	"/crates/api/v1/crates/serde": `{"crate": {"max_stable_version": "1.0.200", "repository": "https://github.com/serde-rs/serde"},` +
+		` "versions": [{"num": "1.0.200", "license": "MIT OR Apache-2.0"}, {"num": "1.0.100", "license": "MIT/Apache-2.0"}]}`,
+	"/packagist/p2/monolog/monolog.json": `{"packages": {"monolog/monolog": [` +
+		`{"version": "3.5.0", "license": ["MIT"], "source": {"url": "https://github.com/Seldaek/monolog.git"}},` +
+		` {"version": "3.4.0"}, {"version": "1.0.0", "license": "__unset"}]}}`,
+	"/rubygems/api/v2/rubygems/rails/versions/7.1.0.json": `{"number": "7.1.0", "licenses": ["MIT"], "source_code_uri": "https://github.com/rails/rails"}`,
+}
+
+// newLiveRegistryServer starts a stub HTTP server impersonating all the upstream registries.
+func newLiveRegistryServer(t *testing.T) (*httptest.Server, *myconfig.ServerConfig) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := liveRegistryResponses[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.LiveMetadata.Enabled = true
+	myConfig.LiveMetadata.NpmURL = server.URL + "/npm"
+	myConfig.LiveMetadata.MavenURL = server.URL + "/maven/"
+	myConfig.LiveMetadata.CratesURL = server.URL + "/crates"
+	myConfig.LiveMetadata.PackagistURL = server.URL + "/packagist"
+	myConfig.LiveMetadata.RubyGemsURL = server.URL + "/rubygems"
+	return server, myConfig
+}
+
+func TestLiveMetadataProviders(t *testing.T) {
+	server, myConfig := newLiveRegistryServer(t)
+	defer server.Close()
+	providers := newLiveMetadataProviders(myConfig, server.Client())
+	tests := []struct {
+		name     string
+		purlType string
+		purlName string
+		version  string
+		want     LiveMetadata
+		wantErr  bool
+	}{
+		{name: "npm version", purlType: "npm", purlName: "left-pad", version: "1.3.0",
+			want: LiveMetadata{Version: "1.3.0", License: "WTFPL", URL: "https://github.com/stevemao/left-pad"}},
+		{name: "npm scoped latest", purlType: "npm", purlName: "@scope/pkg", want: LiveMetadata{Version: "2.0.0", License: "MIT"}},
+		{name: "npm scoped escaped", purlType: "npm", purlName: "%40scope/pkg", want: LiveMetadata{Version: "2.0.0", License: "MIT"}},
+		{name: "npm missing", purlType: "npm", purlName: "does-not-exist", version: "1.0.0", wantErr: true},
+		{name: "maven latest", purlType: "maven", purlName: "org.example/demo",
+			want: LiveMetadata{Version: "1.0", License: "Apache-2.0", URL: "https://example.org/demo"}},
+		{name: "maven invalid name", purlType: "maven", purlName: "demo", wantErr: true},
+		{name: "crates latest", purlType: "cargo", purlName: "serde",
+			want: LiveMetadata{Version: "1.0.200", License: "MIT OR Apache-2.0", URL: "https://github.com/serde-rs/serde"}},
+		{name: "crates version", purlType: "cargo", purlName: "serde", version: "1.0.100",
+			want: LiveMetadata{Version: "1.0.100", License: "MIT/Apache-2.0", URL: "https://github.com/serde-rs/serde"}},
+		{name: "crates unknown version", purlType: "cargo", purlName: "serde", version: "0.1.0", wantErr: true},
+		{name: "packagist minified version", purlType: "composer", purlName: "monolog/monolog", version: "3.4.0",
+			want: LiveMetadata{Version: "3.4.0", License: "MIT", URL: "https://github.com/Seldaek/monolog.git"}},
+		{name: "packagist unset license", purlType: "composer", purlName: "monolog/monolog", version: "1.0.0",
+			want: LiveMetadata{Version: "1.0.0", URL: "https://github.com/Seldaek/monolog.git"}},
+		{name: "rubygems version", purlType: "gem", purlName: "rails", version: "7.1.0",
+			want: LiveMetadata{Version: "7.1.0", License: "MIT", URL: "https://github.com/rails/rails"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := providers[tt.purlType].GetMetadata(context.Background(), tt.purlName, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetMetadata() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiveMetadataModel(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	server, myConfig := newLiveRegistryServer(t)
+	myConfig.Components.CommitMissing = true
+	liveModel := NewLiveMetadataModel(ctx, s, db, myConfig)
+
+	_, err = liveModel.GetLiveURL("left-pad", "pypi", "1.3.0")
+	if !errors.Is(err, ErrLiveMetadataUnavailable) {
+		t.Errorf("GetLiveURL() expected unavailable error for an unsupported purl type, got %v", err)
+	}
+	allURL, err := liveModel.GetLiveURL("left-pad", "npm", "1.3.0")
+	if err != nil {
+		t.Fatalf("GetLiveURL() unexpected error = %v", err)
+	}
+	if allURL.License != "WTFPL" || allURL.Version != "1.3.0" || allURL.MineID != 2 {
+		t.Errorf("GetLiveURL() unexpected result: %#v", allURL)
+	}
+	// The versioned lookup should have been stored, so it must still be available without the registry
+	server.Close()
+	allURL, err = liveModel.GetLiveURL("left-pad", "npm", "1.3.0")
+	if err != nil {
+		t.Fatalf("GetLiveURL() expected stored data, got error = %v", err)
+	}
+	if allURL.License != "WTFPL" || allURL.URL != "https://github.com/stevemao/left-pad" {
+		t.Errorf("GetLiveURL() unexpected stored result: %#v", allURL)
+	}
+	myConfig.LiveMetadata.Enabled = false
+	_, err = liveModel.GetLiveURL("left-pad", "npm", "1.3.0")
+	if !errors.Is(err, ErrLiveMetadataUnavailable) {
+		t.Errorf("GetLiveURL() expected unavailable error when disabled, got %v", err)
+	}
+}
+
+// dialCountingTransport returns an HTTP transport that counts (and refuses) every network dial attempted.
+func dialCountingTransport(dials *atomic.Int32) *http.Transport {
+	return &http.Transport{
+		DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+			dials.Add(1)
+			return nil, fmt.Errorf("unexpected dial to %v %v", network, addr)
+		},
+	}
+}
+
+func TestLiveLookupOfflineMode(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.LiveMetadata.Enabled = true
+	var dials atomic.Int32
+	liveModel := NewLiveMetadataModel(ctx, s, db, myConfig)
+	liveModel.SetTransport(dialCountingTransport(&dials))
+	golangProjModel := NewGolangProjectModel(ctx, s, db, myConfig)
+	golangProjModel.SetTransport(dialCountingTransport(&dials))
+
+	// Sanity check: when online, the injected transport is the one used to reach the registries
+	_, err = liveModel.GetLiveURL("left-pad", "npm", "9.9.9")
+	if err == nil || dials.Load() == 0 {
+		t.Fatalf("expected the online lookup to dial through the injected transport (dials = %v, err = %v)", dials.Load(), err)
+	}
+	dials.Store(0)
+
+	myConfig.LiveMetadata.Offline = true
+	_, err = liveModel.GetLiveURL("left-pad", "npm", "9.9.9")
+	if !errors.Is(err, ErrLiveLookupOffline) {
+		t.Errorf("GetLiveURL() expected offline error, got %v", err)
+	}
+	_, err = golangProjModel.getLatestPkgGoDev("example.com/does/not/exist", "golang", "v1.0.0")
+	if !errors.Is(err, ErrLiveLookupOffline) {
+		t.Errorf("getLatestPkgGoDev() expected offline error, got %v", err)
+	}
+	allURL, err := golangProjModel.GetGolangUrlsByPurlNameTypeVersion("example.com/does/not/exist", "golang", "v1.0.0")
+	if err != nil {
+		t.Errorf("GetGolangUrlsByPurlNameTypeVersion() unexpected error = %v", err)
+	}
+	if len(allURL.License) > 0 {
+		t.Errorf("GetGolangUrlsByPurlNameTypeVersion() expected no license in offline mode, got %#v", allURL)
+	}
+	if dials.Load() != 0 {
+		t.Errorf("expected no network dials in offline mode, got %v", dials.Load())
+	}
+}
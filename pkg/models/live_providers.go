@@ -0,0 +1,392 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Live metadata providers for the upstream package registries
+
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	pkggodevclient "github.com/guseggert/pkggodev-client"
+	myconfig "scanoss.com/dependencies/pkg/config"
+)
+
+const liveUserAgent = "scanoss-dependencies" // Some registries (i.e. crates.io) reject requests without a user agent
+
+// ErrLiveMetadataNotFound is returned when a registry has no record of the requested package/version.
+var ErrLiveMetadataNotFound = errors.New("package not found in upstream registry")
+
+// LiveMetadata holds the component details retrieved from an upstream registry.
+type LiveMetadata struct {
+	Version string
+	License string
+	URL     string
+	Latest  bool // Set when the requested version was not found and the latest one was returned instead
+	Details any  // Registry specific details (i.e. *pkggodevclient.Package for pkg.go.dev)
+}
+
+// LiveMetadataProvider retrieves component details from an upstream package registry.
+type LiveMetadataProvider interface {
+	// Name returns a short identifier for the registry.
+	Name() string
+	// GetMetadata returns the details of the given package version (or the latest one if no version is specified).
+	GetMetadata(ctx context.Context, purlName, purlVersion string) (LiveMetadata, error)
+}
+
+// newLiveMetadataProviders creates the registry providers for each supported purl type.
+func newLiveMetadataProviders(config *myconfig.ServerConfig, client *http.Client) map[string]LiveMetadataProvider {
+	return map[string]LiveMetadataProvider{
+		"cargo":    &cratesProvider{baseURL: strings.TrimSuffix(config.LiveMetadata.CratesURL, "/"), client: client},
+		"composer": &packagistProvider{baseURL: strings.TrimSuffix(config.LiveMetadata.PackagistURL, "/"), client: client},
+		"gem":      &rubyGemsProvider{baseURL: strings.TrimSuffix(config.LiveMetadata.RubyGemsURL, "/"), client: client},
+		"maven":    &mavenCentralProvider{baseURL: strings.TrimSuffix(config.LiveMetadata.MavenURL, "/"), client: client},
+		"npm":      &npmProvider{baseURL: strings.TrimSuffix(config.LiveMetadata.NpmURL, "/"), client: client},
+	}
+}
+
+// npmProvider queries the npm registry (https://registry.npmjs.org).
+type npmProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (p *npmProvider) Name() string { return "npm" }
+
+func (p *npmProvider) GetMetadata(ctx context.Context, purlName, purlVersion string) (LiveMetadata, error) {
+	version := purlVersion
+	if len(version) == 0 {
+		version = "latest"
+	}
+	var pkg struct {
+		Version    string          `json:"version"`
+		License    json.RawMessage `json:"license"`
+		Repository json.RawMessage `json:"repository"`
+		Homepage   string          `json:"homepage"`
+	}
+	// Purl names can arrive already escaped (i.e. %40scope/name), so decode them before escaping the scope separator
+	name, err := url.PathUnescape(purlName)
+	if err != nil {
+		name = purlName
+	}
+	// Scoped packages need their separator escaped (i.e. @scope%2Fname)
+	if err = getLiveJSON(ctx, p.client, fmt.Sprintf("%s/%s/%s", p.baseURL, url.PathEscape(name), url.PathEscape(version)), &pkg); err != nil {
+		return LiveMetadata{}, err
+	}
+	repoURL := pkg.Homepage
+	if repo := stringOrField(pkg.Repository, "url"); len(repo) > 0 {
+		repoURL = strings.TrimSuffix(strings.TrimPrefix(repo, "git+"), ".git")
+	}
+	return LiveMetadata{Version: pkg.Version, License: stringOrField(pkg.License, "type"), URL: repoURL}, nil
+}
+
+// pkgGoDevProvider queries the Go package discovery site (https://pkg.go.dev).
+type pkgGoDevProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// newPkgGoDevProvider creates the provider used to look up Go modules missing from the golang_projects table.
+func newPkgGoDevProvider(config *myconfig.ServerConfig, client *http.Client) *pkgGoDevProvider {
+	return &pkgGoDevProvider{baseURL: strings.TrimSuffix(config.LiveMetadata.PkgGoDevURL, "/"), client: client}
+}
+
+func (p *pkgGoDevProvider) Name() string { return "pkg.go.dev" }
+
+func (p *pkgGoDevProvider) GetMetadata(_ context.Context, purlName, purlVersion string) (LiveMetadata, error) {
+	if len(purlName) == 0 {
+		return LiveMetadata{}, errors.New("please specify a valid Purl Name to query")
+	}
+	client := pkggodevclient.New(pkggodevclient.WithBaseURL(p.baseURL), pkggodevclient.WithHTTPClient(p.client))
+	pkg := purlName
+	if len(purlVersion) > 0 {
+		pkg = fmt.Sprintf("%s@%s", purlName, purlVersion)
+	}
+	latest := false
+	comp, err := client.DescribePackage(pkggodevclient.DescribePackageRequest{Package: pkg})
+	if err != nil && len(purlVersion) > 0 {
+		// The requested version is unknown, so look for the latest one
+		comp, err = client.DescribePackage(pkggodevclient.DescribePackageRequest{Package: purlName})
+		latest = true // Mark that this information is from the latest package and not a specific version
+	}
+	if err != nil {
+		return LiveMetadata{}, fmt.Errorf("failed to query pkg.go.dev for %v: %v", pkg, err)
+	}
+	version := comp.Version
+	if len(purlVersion) > 0 {
+		version = purlVersion // Force the requested version if specified (the returned value can be concatenated)
+	}
+	return LiveMetadata{
+		Version: version,
+		License: comp.License,
+		URL:     fmt.Sprintf("https://%v", comp.Repository),
+		Latest:  latest,
+		Details: comp,
+	}, nil
+}
+
+// mavenCentralProvider queries a Maven repository layout (https://repo1.maven.org/maven2).
+type mavenCentralProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (p *mavenCentralProvider) Name() string { return "maven-central" }
+
+func (p *mavenCentralProvider) GetMetadata(ctx context.Context, purlName, purlVersion string) (LiveMetadata, error) {
+	groupID, artifactID, found := strings.Cut(purlName, "/")
+	if !found || len(groupID) == 0 || len(artifactID) == 0 {
+		return LiveMetadata{}, fmt.Errorf("invalid maven purl name: %v", purlName)
+	}
+	artifactURL := fmt.Sprintf("%s/%s/%s", p.baseURL, strings.ReplaceAll(groupID, ".", "/"), url.PathEscape(artifactID))
+	version := purlVersion
+	if len(version) == 0 {
+		var metadata struct {
+			Versioning struct {
+				Latest  string `xml:"latest"`
+				Release string `xml:"release"`
+			} `xml:"versioning"`
+		}
+		if err := getLiveXML(ctx, p.client, artifactURL+"/maven-metadata.xml", &metadata); err != nil {
+			return LiveMetadata{}, err
+		}
+		version = metadata.Versioning.Release
+		if len(version) == 0 {
+			version = metadata.Versioning.Latest
+		}
+		if len(version) == 0 {
+			return LiveMetadata{}, ErrLiveMetadataNotFound
+		}
+	}
+	var pom struct {
+		URL      string `xml:"url"`
+		Licenses []struct {
+			Name string `xml:"name"`
+		} `xml:"licenses>license"`
+		Scm struct {
+			URL string `xml:"url"`
+		} `xml:"scm"`
+	}
+	if err := getLiveXML(ctx, p.client, fmt.Sprintf("%s/%s/%s-%s.pom", artifactURL, url.PathEscape(version), url.PathEscape(artifactID), url.PathEscape(version)), &pom); err != nil {
+		return LiveMetadata{}, err
+	}
+	licenses := make([]string, 0, len(pom.Licenses))
+	for _, l := range pom.Licenses {
+		if name := strings.TrimSpace(l.Name); len(name) > 0 {
+			licenses = append(licenses, name)
+		}
+	}
+	repoURL := pom.Scm.URL
+	if len(repoURL) == 0 {
+		repoURL = pom.URL
+	}
+	return LiveMetadata{Version: version, License: strings.Join(licenses, "/"), URL: repoURL}, nil
+}
+
+// cratesProvider queries the crates.io API (https://crates.io).
+type cratesProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (p *cratesProvider) Name() string { return "crates.io" }
+
+func (p *cratesProvider) GetMetadata(ctx context.Context, purlName, purlVersion string) (LiveMetadata, error) {
+	var crate struct {
+		Crate struct {
+			MaxStableVersion string `json:"max_stable_version"`
+			MaxVersion       string `json:"max_version"`
+			Repository       string `json:"repository"`
+			Homepage         string `json:"homepage"`
+		} `json:"crate"`
+		Versions []struct {
+			Num     string `json:"num"`
+			License string `json:"license"`
+		} `json:"versions"`
+	}
+	if err := getLiveJSON(ctx, p.client, fmt.Sprintf("%s/api/v1/crates/%s", p.baseURL, url.PathEscape(purlName)), &crate); err != nil {
+		return LiveMetadata{}, err
+	}
+	version := purlVersion
+	if len(version) == 0 {
+		version = crate.Crate.MaxStableVersion
+		if len(version) == 0 {
+			version = crate.Crate.MaxVersion
+		}
+	}
+	repoURL := crate.Crate.Repository
+	if len(repoURL) == 0 {
+		repoURL = crate.Crate.Homepage
+	}
+	for _, v := range crate.Versions {
+		if v.Num == version {
+			return LiveMetadata{Version: v.Num, License: v.License, URL: repoURL}, nil
+		}
+	}
+	return LiveMetadata{}, ErrLiveMetadataNotFound
+}
+
+// packagistProvider queries the Packagist metadata API (https://repo.packagist.org).
+type packagistProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (p *packagistProvider) Name() string { return "packagist" }
+
+func (p *packagistProvider) GetMetadata(ctx context.Context, purlName, purlVersion string) (LiveMetadata, error) {
+	var metadata struct {
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+	}
+	if err := getLiveJSON(ctx, p.client, fmt.Sprintf("%s/p2/%s.json", p.baseURL, purlName), &metadata); err != nil {
+		return LiveMetadata{}, err
+	}
+	// Versions are listed newest first and minified: each entry only contains the fields that changed
+	// from the previous one, with "__unset" marking removed fields.
+	expanded := make(map[string]json.RawMessage)
+	for _, entry := range metadata.Packages[purlName] {
+		for k, v := range entry {
+			if string(v) == `"__unset"` {
+				delete(expanded, k)
+				continue
+			}
+			expanded[k] = v
+		}
+		var version string
+		_ = json.Unmarshal(expanded["version"], &version)
+		if len(purlVersion) > 0 && strings.TrimPrefix(version, "v") != strings.TrimPrefix(purlVersion, "v") {
+			continue
+		}
+		var licenses []string
+		_ = json.Unmarshal(expanded["license"], &licenses)
+		return LiveMetadata{
+			Version: version,
+			License: strings.Join(licenses, "/"),
+			URL:     stringOrField(expanded["source"], "url"),
+		}, nil
+	}
+	return LiveMetadata{}, ErrLiveMetadataNotFound
+}
+
+// rubyGemsProvider queries the RubyGems API (https://rubygems.org).
+type rubyGemsProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (p *rubyGemsProvider) Name() string { return "rubygems" }
+
+func (p *rubyGemsProvider) GetMetadata(ctx context.Context, purlName, purlVersion string) (LiveMetadata, error) {
+	var gem struct {
+		Version       string   `json:"version"`
+		Number        string   `json:"number"`
+		Licenses      []string `json:"licenses"`
+		SourceCodeURI string   `json:"source_code_uri"`
+		HomepageURI   string   `json:"homepage_uri"`
+	}
+	gemURL := fmt.Sprintf("%s/api/v1/gems/%s.json", p.baseURL, url.PathEscape(purlName))
+	if len(purlVersion) > 0 {
+		gemURL = fmt.Sprintf("%s/api/v2/rubygems/%s/versions/%s.json", p.baseURL, url.PathEscape(purlName), url.PathEscape(purlVersion))
+	}
+	if err := getLiveJSON(ctx, p.client, gemURL, &gem); err != nil {
+		return LiveMetadata{}, err
+	}
+	version := gem.Version
+	if len(version) == 0 {
+		version = gem.Number
+	}
+	repoURL := gem.SourceCodeURI
+	if len(repoURL) == 0 {
+		repoURL = gem.HomepageURI
+	}
+	return LiveMetadata{Version: version, License: strings.Join(gem.Licenses, "/"), URL: repoURL}, nil
+}
+
+// stringOrField decodes a JSON value that can either be a plain string or an object holding the value in the given field.
+func stringOrField(raw json.RawMessage, field string) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err == nil {
+		_ = json.Unmarshal(object[field], &value)
+	}
+	return value
+}
+
+// getLiveJSON retrieves the given URL and decodes its JSON body into v.
+func getLiveJSON(ctx context.Context, client *http.Client, target string, v any) error {
+	resp, err := getLive(ctx, client, target)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response from %v: %v", target, err)
+	}
+	return nil
+}
+
+// getLiveXML retrieves the given URL and decodes its XML body into v.
+func getLiveXML(ctx context.Context, client *http.Client, target string, v any) error {
+	resp, err := getLive(ctx, client, target)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+	if err = xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response from %v: %v", target, err)
+	}
+	return nil
+}
+
+// getLive issues a GET request against an upstream registry, mapping a 404 to ErrLiveMetadataNotFound.
+func getLive(ctx context.Context, client *http.Client, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %v: %v", target, err)
+	}
+	req.Header.Set("User-Agent", liveUserAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %v: %v", target, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		closeBody(resp)
+		return nil, ErrLiveMetadataNotFound
+	case resp.StatusCode != http.StatusOK:
+		closeBody(resp)
+		return nil, fmt.Errorf("unexpected status from %v: %v", target, resp.Status)
+	}
+	return resp, nil
+}
+
+// closeBody closes the given response body, ignoring any errors.
+func closeBody(resp *http.Response) {
+	_ = resp.Body.Close()
+}
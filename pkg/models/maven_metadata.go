@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the maven metadata table
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type MavenMetadataModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+// MavenMetadata holds the POM details required to resolve a Maven artifact's dependency requirements.
+type MavenMetadata struct {
+	PurlName             string
+	Version              string
+	ParentPurlName       string
+	ParentVersion        string
+	Properties           map[string]string
+	DependencyManagement []ManagedDependency
+}
+
+// ManagedDependency is an entry of a POM's dependencyManagement section.
+// Entries with an "import" scope refer to a BOM whose managed dependencies should be imported.
+type ManagedDependency struct {
+	Purl        string `json:"dep_purl_name"`
+	Requirement string `json:"dep_ver"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// mavenMetadataRow is the raw database representation of the maven metadata table.
+type mavenMetadataRow struct {
+	PurlName       string `db:"purl_name"`
+	Version        string `db:"version"`
+	ParentPurlName string `db:"parent_purl_name"`
+	ParentVersion  string `db:"parent_version"`
+	Properties     []byte `db:"properties"`
+	DepManagement  []byte `db:"dep_management"`
+}
+
+// NewMavenMetadataModel creates a new instance of the Maven Metadata Model.
+func NewMavenMetadataModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *MavenMetadataModel {
+	return &MavenMetadataModel{ctx: ctx, s: s, db: db}
+}
+
+// GetMetadata retrieves the parent, properties and dependency management details of the given Maven artifact.
+// An empty MavenMetadata is returned if no metadata is stored for it.
+func (m *MavenMetadataModel) GetMetadata(purlName, version string) (MavenMetadata, error) {
+	if len(purlName) == 0 || len(version) == 0 {
+		m.s.Error("Please specify a valid Purl Name and Version to query")
+		return MavenMetadata{}, errors.New("please specify a valid Purl Name and Version to query")
+	}
+	var row mavenMetadataRow
+	err := m.db.QueryRowxContext(m.ctx,
+		"SELECT purl_name, version, COALESCE(parent_purl_name, '') AS parent_purl_name, COALESCE(parent_version, '') AS parent_version,"+
+			" properties, dep_management FROM maven_metadata"+
+			" WHERE purl_name = $1 AND version = $2",
+		purlName, version).StructScan(&row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return MavenMetadata{}, nil
+		}
+		m.s.Errorf("Failed to query maven metadata table for %v@%v: %v", purlName, version, err)
+		return MavenMetadata{}, fmt.Errorf("failed to query the maven metadata table: %v", err)
+	}
+	metadata := MavenMetadata{
+		PurlName:       row.PurlName,
+		Version:        row.Version,
+		ParentPurlName: row.ParentPurlName,
+		ParentVersion:  row.ParentVersion,
+	}
+	if len(row.Properties) > 0 {
+		if err = json.Unmarshal(row.Properties, &metadata.Properties); err != nil {
+			return MavenMetadata{}, fmt.Errorf("failed to unmarshal maven properties for %v@%v: %v", purlName, version, err)
+		}
+	}
+	if len(row.DepManagement) > 0 {
+		if err = json.Unmarshal(row.DepManagement, &metadata.DependencyManagement); err != nil {
+			return MavenMetadata{}, fmt.Errorf("failed to unmarshal maven dependency management for %v@%v: %v", purlName, version, err)
+		}
+	}
+	return metadata, nil
+}
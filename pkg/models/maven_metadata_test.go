@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestMavenMetadata(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	err = LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to load test SQL data: %v", err)
+	}
+	defer func(db *sqlx.DB) {
+		if errDB := db.Close(); errDB != nil {
+			t.Fatalf("failed to close db: %v", errDB)
+		}
+	}(db)
+	metadataModel := NewMavenMetadataModel(ctx, s, db)
+
+	_, err = metadataModel.GetMetadata("", "1.0.0")
+	if err == nil {
+		t.Errorf("FAILED: Expected an error when passing an empty purl name, got err = nil")
+	}
+
+	metadata, err := metadataModel.GetMetadata("com.example/app", "1.0.0")
+	if err != nil {
+		t.Errorf("FAILED: Expected no errors, got err = %v", err)
+	}
+	if metadata.ParentPurlName != "com.example/parent" || metadata.ParentVersion != "3" {
+		t.Errorf("FAILED: Expected parent com.example/parent@3, got %v@%v", metadata.ParentPurlName, metadata.ParentVersion)
+	}
+	if metadata.Properties["guava.version"] != "31.1-jre" {
+		t.Errorf("FAILED: Expected guava.version property, got %v", metadata.Properties)
+	}
+
+	metadata, err = metadataModel.GetMetadata("com.example/parent", "3")
+	if err != nil {
+		t.Errorf("FAILED: Expected no errors, got err = %v", err)
+	}
+	if len(metadata.DependencyManagement) != 1 || metadata.DependencyManagement[0].Scope != "import" {
+		t.Errorf("FAILED: Expected a single BOM import, got %v", metadata.DependencyManagement)
+	}
+
+	metadata, err = metadataModel.GetMetadata("com.example/unknown", "1.0.0")
+	if err != nil {
+		t.Errorf("FAILED: Expected no errors, got err = %v", err)
+	}
+	if len(metadata.PurlName) > 0 {
+		t.Errorf("FAILED: Expected empty metadata, got %v", metadata)
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle the versioning (migrations) of the knowledge base schema
+
+package models
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// migrationFiles holds the ordered schema migrations of each dialect (i.e. migrations/<dialect>/NNNN_name.sql).
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+var (
+	// ErrSchemaVersionMismatch is returned when the database schema is not the version expected by this binary.
+	ErrSchemaVersionMismatch = errors.New("knowledge base schema version mismatch")
+	// ErrSchemaUntracked is returned when the database has no record of any applied migration (i.e. it predates them).
+	ErrSchemaUntracked = errors.New("knowledge base schema is not tracked by migrations")
+)
+
+// Migration is a single versioned change to the knowledge base schema.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// AppliedMigration records a migration that has already been applied to the database.
+type AppliedMigration struct {
+	Version   int    `db:"version"`
+	Name      string `db:"name"`
+	AppliedAt string `db:"applied_at"`
+}
+
+// createMigrationsTable holds the statement creating the table that tracks the applied migrations.
+const createMigrationsTable = "CREATE TABLE IF NOT EXISTS schema_migrations" +
+	" (version INTEGER PRIMARY KEY, name TEXT NOT NULL, applied_at TEXT NOT NULL)"
+
+// GetMigrations returns the embedded migrations of the given dialect, ordered by version.
+func GetMigrations(dialect Dialect) ([]Migration, error) {
+	dir := path.Join("migrations", string(dialect))
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations available for %v: %v", dialect, err)
+	}
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration file name (expected NNNN_name.sql): %v", entry.Name())
+		}
+		if other, exists := seen[version]; exists {
+			return nil, fmt.Errorf("duplicate migration version %v: %v and %v", version, other, name)
+		}
+		seen[version] = name
+		contents, err := migrationFiles.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %v: %v", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(contents)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ExpectedSchemaVersion returns the schema version this binary expects for the given dialect.
+func ExpectedSchemaVersion(dialect Dialect) (int, error) {
+	migrations, err := GetMigrations(dialect)
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// hasMigrationsTable reports whether the schema_migrations table exists, without modifying the database.
+func hasMigrationsTable(ctx context.Context, db *sqlx.DB) (bool, error) {
+	query := "SELECT count(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+	if GetDBDialect(db) == DialectSQLite {
+		query = "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = $1"
+	}
+	var count int
+	if err := db.GetContext(ctx, &count, query, "schema_migrations"); err != nil {
+		return false, fmt.Errorf("failed to look up the schema_migrations table: %v", err)
+	}
+	return count > 0, nil
+}
+
+// GetAppliedMigrations returns the migrations already applied to the given database, ordered by version.
+// It only reads from the database, returning no migrations if the schema_migrations table does not exist.
+func GetAppliedMigrations(ctx context.Context, db *sqlx.DB) ([]AppliedMigration, error) {
+	exists, err := hasMigrationsTable(ctx, db)
+	if err != nil || !exists {
+		return nil, err
+	}
+	var applied []AppliedMigration
+	err = db.SelectContext(ctx, &applied, "SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the schema_migrations table: %v", err)
+	}
+	return applied, nil
+}
+
+// GetSchemaVersion returns the highest migration version applied to the given database (0 if none).
+func GetSchemaVersion(ctx context.Context, db *sqlx.DB) (int, error) {
+	applied, err := GetAppliedMigrations(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if len(applied) == 0 {
+		return 0, nil
+	}
+	return applied[len(applied)-1].Version, nil
+}
+
+// MigrateSchema applies any pending migration to the given database, in order, and returns the ones applied.
+// Each migration runs in its own transaction together with its schema_migrations record.
+func MigrateSchema(ctx context.Context, db *sqlx.DB) ([]Migration, error) {
+	migrations, err := GetMigrations(GetDBDialect(db))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, fmt.Errorf("failed to create the schema_migrations table: %v", err)
+	}
+	current, err := GetSchemaVersion(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var applied []Migration
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err = applyMigration(ctx, db, migration); err != nil {
+			return applied, err
+		}
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
+
+// applyMigration runs the given migration and records it in the schema_migrations table.
+func applyMigration(ctx context.Context, db *sqlx.DB, migration Migration) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start migration %v: %v", migration.Name, err)
+	}
+	if _, err = tx.ExecContext(ctx, migration.SQL); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to apply migration %v: %v", migration.Name, err)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+		migration.Version, migration.Name, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to record migration %v: %v", migration.Name, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %v: %v", migration.Name, err)
+	}
+	return nil
+}
+
+// CheckSchemaVersion verifies that the given database schema is the version this binary expects.
+// It only reads from the database and returns ErrSchemaUntracked if no migration has ever been recorded.
+func CheckSchemaVersion(ctx context.Context, db *sqlx.DB) error {
+	expected, err := ExpectedSchemaVersion(GetDBDialect(db))
+	if err != nil {
+		return err
+	}
+	current, err := GetSchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if current == 0 && expected > 0 {
+		return fmt.Errorf("%w: expected version %v (run the migrate command to start tracking it)", ErrSchemaUntracked, expected)
+	}
+	if current < expected {
+		return fmt.Errorf("%w: database is at version %v, expected %v (please run the migrate command)",
+			ErrSchemaVersionMismatch, current, expected)
+	}
+	if current > expected {
+		return fmt.Errorf("%w: database is at version %v, newer than the expected %v (please upgrade this service)",
+			ErrSchemaVersionMismatch, current, expected)
+	}
+	return nil
+}
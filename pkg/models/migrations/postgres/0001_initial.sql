@@ -0,0 +1,162 @@
+-- SCANOSS Dependencies knowledge base schema (PostgreSQL) - initial version
+--
+-- Applied by the migrate (or init-sqlite) CLI command, which records it in the schema_migrations table.
+
+-- Sources (mines) the components were collected from, keyed by purl type
+CREATE TABLE IF NOT EXISTS mines
+(
+    id        INTEGER PRIMARY KEY,
+    mine_name TEXT DEFAULT '',
+    purl_type TEXT DEFAULT ''
+);
+
+-- License names and their SPDX identifiers
+CREATE TABLE IF NOT EXISTS licenses
+(
+    id           SERIAL PRIMARY KEY,
+    license_name TEXT    NOT NULL UNIQUE,
+    spdx_id      TEXT    NOT NULL DEFAULT '',
+    is_spdx      BOOLEAN NOT NULL DEFAULT FALSE,
+    is_sanitized BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Version names (with their semantic version if any)
+CREATE TABLE IF NOT EXISTS versions
+(
+    id           SERIAL PRIMARY KEY,
+    version_name TEXT NOT NULL UNIQUE,
+    semver       TEXT DEFAULT ''
+);
+
+-- Project level details (one row per purl name and mine)
+CREATE TABLE IF NOT EXISTS projects
+(
+    mine_id             INTEGER NOT NULL,
+    vendor              TEXT    NOT NULL,
+    component           TEXT    NOT NULL,
+    first_version_date  DATE,
+    latest_version_date DATE,
+    license             TEXT,
+    versions            INTEGER,
+    source_vendor       TEXT,
+    source_component    TEXT,
+    git_created_at      TIMESTAMP,
+    git_updated_at      TIMESTAMP,
+    git_pushed_at       TIMESTAMP,
+    git_watchers        INTEGER,
+    git_issues          INTEGER,
+    git_forks           INTEGER,
+    git_license         TEXT,
+    source_mine_id      INTEGER,
+    purl_name           TEXT    NOT NULL,
+    source_purl_name    TEXT,
+    verified            TEXT,
+    license_id          INTEGER,
+    git_license_id      INTEGER,
+    PRIMARY KEY (mine_id, purl_name)
+);
+
+-- Component version details (one row per downloadable package)
+CREATE TABLE IF NOT EXISTS all_urls
+(
+    package_hash TEXT NOT NULL,
+    vendor       TEXT,
+    component    TEXT,
+    version      TEXT,
+    date         DATE,
+    url          TEXT NOT NULL,
+    url_hash     TEXT NOT NULL,
+    mine_id      INTEGER,
+    license      TEXT,
+    version_id   INTEGER,
+    license_id   INTEGER,
+    purl_name    TEXT,
+    PRIMARY KEY (package_hash, url, url_hash)
+);
+CREATE INDEX IF NOT EXISTS all_urls_purl_name_idx ON all_urls (purl_name);
+
+-- Go module details (also populated from pkg.go.dev when COMP_COMMIT_MISSING is set)
+CREATE TABLE IF NOT EXISTS golang_projects
+(
+    component                   TEXT    NOT NULL,
+    version                     TEXT    NOT NULL,
+    version_id                  INTEGER NOT NULL,
+    version_date                TIMESTAMP NOT NULL,
+    is_module                   BOOLEAN,
+    is_package                  BOOLEAN,
+    license                     TEXT    NOT NULL,
+    license_id                  INTEGER NOT NULL,
+    has_valid_go_mod_file       BOOLEAN,
+    has_redistributable_license BOOLEAN,
+    has_tagged_version          BOOLEAN,
+    has_stable_version          BOOLEAN,
+    repository                  TEXT    NOT NULL,
+    is_indexed                  BOOLEAN,
+    purl_name                   TEXT    NOT NULL,
+    mine_id                     INTEGER NOT NULL,
+    index_timestamp             TEXT    NOT NULL,
+    PRIMARY KEY (purl_name, version)
+);
+
+-- Declared dependencies of each package version (one table per registered ecosystem).
+-- dep_data holds a JSON array of {"dep_purl_name": "...", "dep_ver": "..."} objects.
+CREATE TABLE IF NOT EXISTS composer_dependencies
+(
+    purl_name TEXT NOT NULL,
+    version   TEXT NOT NULL,
+    dep_data  JSONB,
+    PRIMARY KEY (purl_name, version)
+);
+CREATE TABLE IF NOT EXISTS crates_dependencies
+(
+    purl_name TEXT NOT NULL,
+    version   TEXT NOT NULL,
+    dep_data  JSONB,
+    PRIMARY KEY (purl_name, version)
+);
+CREATE TABLE IF NOT EXISTS maven_dependencies
+(
+    purl_name TEXT NOT NULL,
+    version   TEXT NOT NULL,
+    dep_data  JSONB,
+    PRIMARY KEY (purl_name, version)
+);
+CREATE TABLE IF NOT EXISTS npmjs_dependencies
+(
+    purl_name TEXT NOT NULL,
+    version   TEXT NOT NULL,
+    dep_data  JSONB,
+    PRIMARY KEY (purl_name, version)
+);
+CREATE TABLE IF NOT EXISTS ruby_dependencies
+(
+    purl_name TEXT NOT NULL,
+    version   TEXT NOT NULL,
+    dep_data  JSONB,
+    PRIMARY KEY (purl_name, version)
+);
+
+-- Maven parent POM, properties (JSON object) and dependencyManagement (JSON array) details
+CREATE TABLE IF NOT EXISTS maven_metadata
+(
+    purl_name        TEXT NOT NULL,
+    version          TEXT NOT NULL,
+    parent_purl_name TEXT DEFAULT '',
+    parent_version   TEXT DEFAULT '',
+    properties       JSONB DEFAULT '{}',
+    dep_management   JSONB DEFAULT '[]',
+    PRIMARY KEY (purl_name, version)
+);
+
+-- Component details retrieved from upstream registries (populated when COMP_COMMIT_MISSING is set)
+CREATE TABLE IF NOT EXISTS live_components
+(
+    purl_type  TEXT NOT NULL,
+    purl_name  TEXT NOT NULL,
+    version    TEXT NOT NULL,
+    license_id INTEGER,
+    url        TEXT DEFAULT '',
+    provider   TEXT DEFAULT '',
+    retrieved  TEXT DEFAULT '',
+    PRIMARY KEY (purl_type, purl_name, version)
+);
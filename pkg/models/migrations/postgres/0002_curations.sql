@@ -0,0 +1,18 @@
+-- Curations: tenant supplied overrides of the knowledge base (PostgreSQL)
+
+-- License/URL overrides and dependency edits for the versions of a component matching version_range ('' = all).
+-- add_dependencies holds a JSON array of {"dep_purl_name": "...", "dep_ver": "..."} objects
+-- and remove_dependencies a JSON array of dependency purl names.
+CREATE TABLE IF NOT EXISTS curations
+(
+    id                  SERIAL PRIMARY KEY,
+    purl_type           TEXT NOT NULL,
+    purl_name           TEXT NOT NULL,
+    version_range       TEXT NOT NULL DEFAULT '',
+    license             TEXT NOT NULL DEFAULT '',
+    url                 TEXT NOT NULL DEFAULT '',
+    add_dependencies    JSONB NOT NULL DEFAULT '[]',
+    remove_dependencies JSONB NOT NULL DEFAULT '[]',
+    reason              TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS curations_purl_idx ON curations (purl_type, purl_name);
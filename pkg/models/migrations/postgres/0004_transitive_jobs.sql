@@ -0,0 +1,16 @@
+-- Transitive jobs: state of the asynchronous transitive dependency jobs (PostgreSQL)
+
+-- Used when TRANSITIVE_JOBS_STORE=sql. request/result hold the JSON payloads and timestamps are fixed width UTC text.
+CREATE TABLE IF NOT EXISTS transitive_jobs
+(
+    id             TEXT PRIMARY KEY,
+    state          TEXT    NOT NULL,
+    request        TEXT    NOT NULL DEFAULT '',
+    result         TEXT    NOT NULL DEFAULT '',
+    error          TEXT    NOT NULL DEFAULT '',
+    nodes_explored INTEGER NOT NULL DEFAULT 0,
+    queue_depth    INTEGER NOT NULL DEFAULT 0,
+    created_at     TEXT    NOT NULL,
+    updated_at     TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS transitive_jobs_state_idx ON transitive_jobs (state, updated_at);
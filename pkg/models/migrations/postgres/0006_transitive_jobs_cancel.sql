@@ -0,0 +1,4 @@
+-- Transitive job cancellations: requested by any replica, and carried out by the replica running the job (PostgreSQL)
+
+-- Lets a replica cancel a job running on another one, which checks for the flag while the job runs.
+ALTER TABLE transitive_jobs ADD COLUMN cancel_requested BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,18 @@
+-- Curations generation: bumped on every change of the curations table (PostgreSQL)
+
+-- The 'curations' kb_metadata key identifies the current contents of the curations table, so that the response cache
+-- can tell when they change without reading the whole table.
+CREATE OR REPLACE FUNCTION bump_curations_generation() RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO kb_metadata (key, value, updated_at)
+    VALUES ('curations', '1', to_char(now() AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'))
+    ON CONFLICT (key) DO UPDATE SET value = (kb_metadata.value::BIGINT + 1)::TEXT, updated_at = excluded.updated_at;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS curations_generation ON curations;
+CREATE TRIGGER curations_generation
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON curations
+    FOR EACH STATEMENT
+EXECUTE FUNCTION bump_curations_generation();
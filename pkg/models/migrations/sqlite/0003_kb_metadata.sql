@@ -0,0 +1,9 @@
+-- Knowledge base metadata (SQLite)
+
+-- Key/value details of the loaded knowledge base (i.e. the 'snapshot' marker written when the KB is built or imported).
+CREATE TABLE IF NOT EXISTS kb_metadata
+(
+    key        TEXT PRIMARY KEY,
+    value      TEXT NOT NULL,
+    updated_at TEXT NOT NULL DEFAULT ''
+);
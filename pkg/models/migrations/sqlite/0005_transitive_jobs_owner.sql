@@ -0,0 +1,4 @@
+-- Transitive job owners: instance running each asynchronous transitive dependency job (SQLite)
+
+-- Lets replicas sharing the table only fail their own interrupted jobs, or the ones whose lease (updated_at) has expired.
+ALTER TABLE transitive_jobs ADD COLUMN owner TEXT NOT NULL DEFAULT '';
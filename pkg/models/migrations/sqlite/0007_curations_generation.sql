@@ -0,0 +1,19 @@
+-- Curations generation: bumped on every change of the curations table (SQLite)
+
+-- The 'curations' kb_metadata key identifies the current contents of the curations table, so that the response cache
+-- can tell when they change without reading the whole table.
+CREATE TRIGGER IF NOT EXISTS curations_generation_insert AFTER INSERT ON curations
+BEGIN
+    INSERT INTO kb_metadata (key, value, updated_at) VALUES ('curations', '1', strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    ON CONFLICT (key) DO UPDATE SET value = CAST(CAST(kb_metadata.value AS INTEGER) + 1 AS TEXT), updated_at = excluded.updated_at;
+END;
+CREATE TRIGGER IF NOT EXISTS curations_generation_update AFTER UPDATE ON curations
+BEGIN
+    INSERT INTO kb_metadata (key, value, updated_at) VALUES ('curations', '1', strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    ON CONFLICT (key) DO UPDATE SET value = CAST(CAST(kb_metadata.value AS INTEGER) + 1 AS TEXT), updated_at = excluded.updated_at;
+END;
+CREATE TRIGGER IF NOT EXISTS curations_generation_delete AFTER DELETE ON curations
+BEGIN
+    INSERT INTO kb_metadata (key, value, updated_at) VALUES ('curations', '1', strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    ON CONFLICT (key) DO UPDATE SET value = CAST(CAST(kb_metadata.value AS INTEGER) + 1 AS TEXT), updated_at = excluded.updated_at;
+END;
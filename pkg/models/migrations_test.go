@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	myconfig "scanoss.com/dependencies/pkg/config"
+)
+
+func TestGetMigrations(t *testing.T) {
+	sqliteMigrations, err := GetMigrations(DialectSQLite)
+	if err != nil {
+		t.Fatalf("GetMigrations() unexpected error = %v", err)
+	}
+	postgresMigrations, err := GetMigrations(DialectPostgres)
+	if err != nil {
+		t.Fatalf("GetMigrations() unexpected error = %v", err)
+	}
+	if len(sqliteMigrations) == 0 || len(sqliteMigrations) != len(postgresMigrations) {
+		t.Fatalf("expected the same migrations for each dialect, got %v and %v", len(sqliteMigrations), len(postgresMigrations))
+	}
+	for i := range sqliteMigrations {
+		if sqliteMigrations[i].Version != postgresMigrations[i].Version || sqliteMigrations[i].Name != postgresMigrations[i].Name {
+			t.Errorf("migration %v differs between dialects: %v vs %v", i, sqliteMigrations[i].Name, postgresMigrations[i].Name)
+		}
+		if i > 0 && sqliteMigrations[i].Version <= sqliteMigrations[i-1].Version {
+			t.Errorf("migrations are not ordered: %v after %v", sqliteMigrations[i].Name, sqliteMigrations[i-1].Name)
+		}
+	}
+	_, err = GetMigrations("oracle")
+	if err == nil {
+		t.Errorf("GetMigrations() expected an error for an unsupported dialect")
+	}
+}
+
+func TestMigrateSchema(t *testing.T) {
+	ctx := context.Background()
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Database.Driver = "sqlite"
+	myConfig.Database.Dsn = filepath.Join(t.TempDir(), "kb.db")
+	db, err := OpenDB(myConfig)
+	if err != nil {
+		t.Fatalf("OpenDB() unexpected error = %v", err)
+	}
+	defer CloseDB(db)
+	expected, err := ExpectedSchemaVersion(DialectSQLite)
+	if err != nil || expected <= 0 {
+		t.Fatalf("ExpectedSchemaVersion() = %v, err = %v", expected, err)
+	}
+	err = CheckSchemaVersion(ctx, db)
+	if !errors.Is(err, ErrSchemaUntracked) {
+		t.Errorf("CheckSchemaVersion() expected an untracked schema on an empty database, got %v", err)
+	}
+	// Checking the version must not write to the database
+	if exists, _ := hasMigrationsTable(ctx, db); exists {
+		t.Errorf("CheckSchemaVersion() unexpectedly created the schema_migrations table")
+	}
+	applied, err := MigrateSchema(ctx, db)
+	if err != nil {
+		t.Fatalf("MigrateSchema() unexpected error = %v", err)
+	}
+	if len(applied) == 0 || applied[len(applied)-1].Version != expected {
+		t.Errorf("MigrateSchema() expected to apply up to version %v, got %#v", expected, applied)
+	}
+	if err = CheckSchemaVersion(ctx, db); err != nil {
+		t.Errorf("CheckSchemaVersion() unexpected error after migrating = %v", err)
+	}
+	// Migrating again should be a no-op
+	applied, err = MigrateSchema(ctx, db)
+	if err != nil || len(applied) > 0 {
+		t.Errorf("MigrateSchema() expected nothing to apply, got %#v, err = %v", applied, err)
+	}
+	history, err := GetAppliedMigrations(ctx, db)
+	if err != nil || len(history) == 0 || len(history[0].AppliedAt) == 0 {
+		t.Errorf("GetAppliedMigrations() unexpected result %#v, err = %v", history, err)
+	}
+	// A database ahead of this binary must be rejected too
+	_, err = db.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+		expected+1, "future", "")
+	if err != nil {
+		t.Fatalf("failed to insert a future migration: %v", err)
+	}
+	err = CheckSchemaVersion(ctx, db)
+	if !errors.Is(err, ErrSchemaVersionMismatch) {
+		t.Errorf("CheckSchemaVersion() expected a mismatch on a newer database, got %v", err)
+	}
+}
@@ -50,6 +50,12 @@ func (m *MineModel) GetMineIdsByPurlType(purlType string) ([]int32, error) {
 		m.s.Error("Please specify a Purl Type to query")
 		return nil, errors.New("please specify a Purl Type to query")
 	}
+	cache := GetReferenceCache()
+	if cache != nil {
+		if mineIds, found := cache.getMineIds(purlType); found {
+			return mineIds, nil
+		}
+	}
 	var mines []Mine
 	err := m.db.SelectContext(m.ctx, &mines,
 		"SELECT id,mine_name,purl_type FROM mines WHERE purl_type = $1", purlType,
@@ -63,6 +69,9 @@ func (m *MineModel) GetMineIdsByPurlType(purlType string) ([]int32, error) {
 		for _, mine := range mines {
 			mineIds = append(mineIds, mine.ID)
 		}
+		if cache != nil {
+			cache.putMineIds(purlType, mineIds)
+		}
 		return mineIds, nil
 	}
 	m.s.Error("No entries found in the mines table.")
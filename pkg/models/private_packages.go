@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle all interaction with the private (internal) package registry overlay
+
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	componentHelper "github.com/scanoss/go-component-helper/componenthelper"
+	purlutils "github.com/scanoss/go-purl-helper/pkg"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// PrivatePackage holds the details of one version of an internal package.
+type PrivatePackage struct {
+	Purl         string                 `json:"purl"` // Purl including the version (i.e. pkg:npm/%40ourcorp/ui@1.2.0)
+	License      string                 `json:"license"`
+	URL          string                 `json:"url"`
+	Dependencies []UnresolvedDependency `json:"dependencies"`
+	PurlType     string                 `json:"-"`
+	PurlName     string                 `json:"-"`
+	Version      string                 `json:"-"`
+}
+
+// PrivateRegistry holds the internal packages served for a set of purl prefixes (i.e. pkg:npm/%40ourcorp/).
+// Components under these prefixes are never looked up in the public KB.
+type PrivateRegistry struct {
+	Prefixes []string                    `json:"prefixes"`
+	Packages []PrivatePackage            `json:"packages"`
+	prefixes []string                    // Normalised type/name prefixes
+	versions map[string][]PrivatePackage // Package versions keyed by normalised type/name, newest first
+}
+
+type PrivatePackageModel struct {
+	ctx    context.Context
+	s      *zap.SugaredLogger
+	config *myconfig.ServerConfig
+	lic    *LicenseModel
+}
+
+// privatePackagesFile caches the contents of the private packages file until it is modified.
+var privatePackagesFile struct {
+	sync.Mutex
+	path     string
+	modTime  time.Time
+	registry *PrivateRegistry
+}
+
+// NewPrivatePackageModel creates a new instance of the Private Package Model.
+func NewPrivatePackageModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, config *myconfig.ServerConfig) *PrivatePackageModel {
+	return &PrivatePackageModel{ctx: ctx, s: s, config: config, lic: NewLicenseModel(ctx, s, db)}
+}
+
+// privatePackageKey normalises a purl type and name, so that encoded and decoded names (i.e. %40ourcorp/ui) match.
+func privatePackageKey(purlType, purlName string) string {
+	name, err := url.PathUnescape(purlName)
+	if err != nil {
+		name = purlName
+	}
+	return purlType + "/" + name
+}
+
+// LoadPrivatePackagesFile reads a private registry (its purl prefixes and packages) from the given JSON file.
+// Every package must have a version and be covered by one of the prefixes.
+func LoadPrivatePackagesFile(path string) (*PrivateRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var registry PrivateRegistry
+	if err = json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("invalid private packages file: %v", err)
+	}
+	for _, prefix := range registry.Prefixes {
+		purlType, namePrefix, found := strings.Cut(strings.TrimPrefix(prefix, "pkg:"), "/")
+		if !strings.HasPrefix(prefix, "pkg:") || !found || len(purlType) == 0 || len(namePrefix) == 0 {
+			return nil, fmt.Errorf("invalid private purl prefix %q (expected pkg:<type>/<namespace>)", prefix)
+		}
+		registry.prefixes = append(registry.prefixes, privatePackageKey(purlType, namePrefix))
+	}
+	registry.versions = make(map[string][]PrivatePackage)
+	for i, pkg := range registry.Packages {
+		purl, err := purlutils.PurlFromString(pkg.Purl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid purl in private package %v (%q): %v", i+1, pkg.Purl, err)
+		}
+		purlName, err := purlutils.PurlNameFromString(pkg.Purl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid purl in private package %v (%q): %v", i+1, pkg.Purl, err)
+		}
+		if len(purl.Version) == 0 {
+			return nil, fmt.Errorf("missing version in private package %v (%q)", i+1, pkg.Purl)
+		}
+		pkg.PurlType, pkg.PurlName, pkg.Version = purl.Type, purlName, purl.Version
+		key := privatePackageKey(pkg.PurlType, pkg.PurlName)
+		if !registry.covers(key) {
+			return nil, fmt.Errorf("private package %v (%q) is not covered by any prefix", i+1, pkg.Purl)
+		}
+		registry.versions[key] = append(registry.versions[key], pkg)
+	}
+	for _, versions := range registry.versions {
+		sort.SliceStable(versions, func(i, j int) bool {
+			return shared.CompareVersions(versions[i].PurlType, versions[i].Version, versions[j].Version) > 0
+		})
+	}
+	return &registry, nil
+}
+
+// covers reports whether the given normalised type/name falls under one of the registry prefixes.
+func (r *PrivateRegistry) covers(key string) bool {
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the requested version of a package (or its latest version if none is requested).
+func (r *PrivateRegistry) lookup(key, version string) (PrivatePackage, bool) {
+	versions := r.versions[key]
+	if len(versions) == 0 {
+		return PrivatePackage{}, false
+	}
+	if len(version) == 0 {
+		return versions[0], true
+	}
+	for _, pkg := range versions {
+		if pkg.Version == version || strings.TrimPrefix(pkg.Version, "v") == strings.TrimPrefix(version, "v") {
+			return pkg, true
+		}
+	}
+	return PrivatePackage{}, false
+}
+
+// getRegistry returns the configured private registry, reloading it when the file changes (nil if there is none).
+func (m *PrivatePackageModel) getRegistry() *PrivateRegistry {
+	path := m.config.PrivatePackages.File
+	if len(path) == 0 {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		m.s.Warnf("Ignoring private packages file %v: %v", path, err)
+		return nil
+	}
+	privatePackagesFile.Lock()
+	defer privatePackagesFile.Unlock()
+	if privatePackagesFile.path == path && privatePackagesFile.modTime.Equal(info.ModTime()) {
+		return privatePackagesFile.registry
+	}
+	registry, err := LoadPrivatePackagesFile(path)
+	if err != nil {
+		m.s.Warnf("Ignoring private packages file %v: %v", path, err)
+		return nil
+	}
+	m.s.Infof("Loaded %v private packages for %v prefixes from %v", len(registry.Packages), len(registry.Prefixes), path)
+	privatePackagesFile.path, privatePackagesFile.modTime, privatePackagesFile.registry = path, info.ModTime(), registry
+	return registry
+}
+
+// Generation identifies the current version of the private packages file, which changes whenever it does.
+func (m *PrivatePackageModel) Generation() string {
+	return fileGeneration(m.config.PrivatePackages.File)
+}
+
+// Covers reports whether the given package falls under one of the private registry prefixes.
+func (m *PrivatePackageModel) Covers(purlType, purlName string) bool {
+	registry := m.getRegistry()
+	return registry != nil && registry.covers(privatePackageKey(purlType, purlName))
+}
+
+// GetURL searches the private registry for the details of the given component.
+// The second value is false if the component is not private (and so should be looked up in the KB).
+// A private component with no matching version is returned without a version.
+func (m *PrivatePackageModel) GetURL(component componentHelper.Component) (AllURL, bool) {
+	registry := m.getRegistry()
+	key := privatePackageKey(component.PurlType, component.Name)
+	if registry == nil || !registry.covers(key) {
+		return AllURL{}, false
+	}
+	version := component.Version
+	if len(version) == 0 && len(component.Requirement) > 0 {
+		version = purlutils.GetVersionFromReqOperator(component.Requirement)
+	}
+	pkg, found := registry.lookup(key, version)
+	if !found && len(component.Version) == 0 {
+		pkg, found = registry.lookup(key, "") // Fall back to the latest version (the requirement is checked by the caller)
+	}
+	if !found {
+		m.s.Debugf("No private package found for %v - %v@%v", component.PurlType, component.Name, version)
+		return AllURL{Private: true}, true
+	}
+	result := AllURL{Component: component.Name, PurlName: component.Name, Version: pkg.Version, SemVer: pkg.Version,
+		URL: pkg.URL, Private: true}
+	if len(pkg.License) > 0 {
+		result.License, result.LicenseID = pkg.License, pkg.License
+		license, _ := m.lic.GetLicenseByName(pkg.License, false)
+		if len(license.LicenseName) > 0 {
+			result.License, result.LicenseID, result.IsSpdx = license.LicenseName, license.LicenseID, license.IsSpdx
+		}
+	}
+	return result, true
+}
+
+// GetVersions returns the versions of the given private package (newest first).
+// The second value is false if the package is not private (and so should be looked up in the KB).
+func (m *PrivatePackageModel) GetVersions(purlType, purlName string) ([]string, bool) {
+	registry := m.getRegistry()
+	key := privatePackageKey(purlType, purlName)
+	if registry == nil || !registry.covers(key) {
+		return nil, false
+	}
+	versions := make([]string, 0, len(registry.versions[key]))
+	for _, pkg := range registry.versions[key] {
+		versions = append(versions, pkg.Version)
+	}
+	return versions, true
+}
+
+// GetDependencies returns the declared dependencies of the given private package version.
+// The second value is false if the package is not private (and so should be looked up in the KB).
+func (m *PrivatePackageModel) GetDependencies(purlType, purlName, version string) ([]UnresolvedDependency, bool) {
+	registry := m.getRegistry()
+	key := privatePackageKey(purlType, purlName)
+	if registry == nil || !registry.covers(key) {
+		return nil, false
+	}
+	pkg, found := registry.lookup(key, version)
+	if !found || len(version) == 0 {
+		m.s.Debugf("No private package found for %v - %v@%v", purlType, purlName, version)
+		return []UnresolvedDependency{}, true
+	}
+	return slices.Clone(pkg.Dependencies), true
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	componentHelper "github.com/scanoss/go-component-helper/componenthelper"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/dependencies/pkg/config"
+)
+
+const testPrivatePackagesFile = `{
+  "prefixes": ["pkg:npm/%40ourcorp/", "pkg:maven/com.ourcorp/"],
+  "packages": [
+    {"purl": "pkg:npm/%40ourcorp/ui@1.2.0", "license": "MIT", "url": "https://git.ourcorp.com/ui",
+     "dependencies": [{"dep_purl_name": "react", "dep_ver": "^17.0.0"}, {"dep_purl_name": "%40ourcorp/theme", "dep_ver": "^2.0.0"}]},
+    {"purl": "pkg:npm/%40ourcorp/ui@1.10.0", "license": "LicenseRef-OurCorp", "url": "https://git.ourcorp.com/ui"},
+    {"purl": "pkg:maven/com.ourcorp/core@3.0.0", "license": "Apache-2.0"}
+  ]
+}`
+
+func TestPrivatePackages(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.PrivatePackages.File = filepath.Join(t.TempDir(), "private.json")
+	if err = os.WriteFile(myConfig.PrivatePackages.File, []byte(testPrivatePackagesFile), 0o600); err != nil {
+		t.Fatalf("failed to write private packages file: %v", err)
+	}
+	privateModel := NewPrivatePackageModel(ctx, s, db, myConfig)
+	allUrlsModel := NewAllURLModel(ctx, s, db, NewProjectModel(ctx, s, db), nil, NewMineModel(ctx, s, db),
+		database.NewDBSelectContext(s, db, nil, false), nil, nil, privateModel)
+
+	tests := []struct {
+		name        string
+		component   componentHelper.Component
+		wantPrivate bool
+		wantVersion string
+		wantLicense string
+	}{
+		{name: "exact version", component: componentHelper.Component{Name: "@ourcorp/ui", PurlType: "npm", Version: "1.2.0"},
+			wantPrivate: true, wantVersion: "1.2.0", wantLicense: "MIT"},
+		{name: "latest version", component: componentHelper.Component{Name: "%40ourcorp/ui", PurlType: "npm"},
+			wantPrivate: true, wantVersion: "1.10.0", wantLicense: "LicenseRef-OurCorp"},
+		{name: "maven namespace", component: componentHelper.Component{Name: "com.ourcorp/core", PurlType: "maven", Requirement: "3.0.0"},
+			wantPrivate: true, wantVersion: "3.0.0", wantLicense: "Apache-2.0"},
+		{name: "unknown version", component: componentHelper.Component{Name: "@ourcorp/ui", PurlType: "npm", Version: "9.9.9"},
+			wantPrivate: true},
+		{name: "unknown package under a prefix", component: componentHelper.Component{Name: "@ourcorp/missing", PurlType: "npm"},
+			wantPrivate: true},
+		{name: "public package", component: componentHelper.Component{Name: "react", PurlType: "npm", Version: "17.0.2"},
+			wantVersion: "17.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, err := allUrlsModel.GetURLsByPurlString(tt.component)
+			if err != nil {
+				t.Fatalf("GetURLsByPurlString() unexpected error = %v", err)
+			}
+			if url.Private != tt.wantPrivate || url.Version != tt.wantVersion {
+				t.Errorf("GetURLsByPurlString() private = %v, version = %v, want %v, %v", url.Private, url.Version, tt.wantPrivate, tt.wantVersion)
+			}
+			if tt.wantPrivate && url.License != tt.wantLicense {
+				t.Errorf("GetURLsByPurlString() license = %v, want %v", url.License, tt.wantLicense)
+			}
+		})
+	}
+
+	dependencyModel := NewDependencyModel(ctx, s, db, nil, privateModel)
+	dependencies, err := dependencyModel.GetDependencies("%40ourcorp/ui", "1.2.0", "npm")
+	if err != nil {
+		t.Fatalf("GetDependencies() unexpected error = %v", err)
+	}
+	if len(dependencies) != 2 || dependencies[0].Purl != "react" || dependencies[1].Purl != "%40ourcorp/theme" {
+		t.Errorf("GetDependencies() expected the private package dependencies, got %#v", dependencies)
+	}
+	dependencies, err = dependencyModel.GetDependencies("%40ourcorp/theme", "2.0.0", "npm")
+	if err != nil || len(dependencies) != 0 {
+		t.Errorf("GetDependencies() expected no dependencies for an unknown private package, got %#v, %v", dependencies, err)
+	}
+	dependencies, err = dependencyModel.GetDependencies("%40leaflink/stash", "31.1.2", "npm")
+	if err != nil || len(dependencies) == 0 {
+		t.Errorf("GetDependencies() expected public dependencies from the KB, got %#v, %v", dependencies, err)
+	}
+
+	invalidFiles := map[string]string{
+		"bad prefix":  `{"prefixes": ["npm/ourcorp"]}`,
+		"no version":  `{"prefixes": ["pkg:npm/%40ourcorp/"], "packages": [{"purl": "pkg:npm/%40ourcorp/ui"}]}`,
+		"not covered": `{"prefixes": ["pkg:npm/%40ourcorp/"], "packages": [{"purl": "pkg:npm/left-pad@1.0.0"}]}`,
+	}
+	for name, contents := range invalidFiles {
+		path := filepath.Join(t.TempDir(), "invalid.json")
+		if err = os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatalf("failed to write private packages file: %v", err)
+		}
+		if _, err = LoadPrivatePackagesFile(path); err == nil {
+			t.Errorf("LoadPrivatePackagesFile() expected an error for %v", name)
+		}
+	}
+}
@@ -35,6 +35,7 @@ type ProjectModel struct {
 
 type Project struct {
 	PurlName     string `db:"purl_name"`
+	MineID       int32  `db:"mine_id"`
 	Component    string `db:"component"`
 	License      string `db:"license"`
 	LicenseID    string `db:"license_id"`
@@ -112,3 +113,46 @@ func (m *ProjectModel) GetProjectByPurlName(purlName string, mineID int32) (Proj
 	}
 	return project, nil
 }
+
+// ProjectKey identifies a project by its Purl Name and Mine ID.
+type ProjectKey struct {
+	PurlName string
+	MineID   int32
+}
+
+// GetProjectsByPurlNames searches the projects' table for the details of many Purl Names in the given mines at once.
+func (m *ProjectModel) GetProjectsByPurlNames(purlNames []string, mineIDs []int32) (map[ProjectKey]Project, error) {
+	projects := make(map[ProjectKey]Project)
+	if len(purlNames) == 0 || len(mineIDs) == 0 {
+		return projects, nil
+	}
+	args := make([]any, 0, len(mineIDs)+len(purlNames))
+	for _, mineID := range mineIDs {
+		args = append(args, mineID)
+	}
+	for _, purlName := range purlNames {
+		args = append(args, purlName)
+	}
+	var allProjects []Project
+	err := m.db.SelectContext(m.ctx, &allProjects,
+		"SELECT purl_name, mine_id, component,"+
+			" l.license_name AS   license, l.spdx_id AS   license_id, l.is_spdx AS is_spdx,"+
+			" g.license_name AS g_license, g.spdx_id AS g_license_id, g.is_spdx AS g_is_spdx"+
+			" FROM projects p"+
+			" LEFT JOIN licenses l ON p.license_id = l.id"+
+			" LEFT JOIN licenses g ON p.git_license_id = g.id"+
+			" WHERE mine_id IN ("+sqlPlaceholders(1, len(mineIDs))+")"+
+			" AND purl_name IN ("+sqlPlaceholders(len(mineIDs)+1, len(purlNames))+")",
+		args...)
+	if err != nil {
+		m.s.Errorf("Error: Failed to query projects table for %v purl names: %v", len(purlNames), err)
+		return nil, fmt.Errorf("failed to query the projects table: %v", err)
+	}
+	for _, project := range allProjects {
+		key := ProjectKey{PurlName: project.PurlName, MineID: project.MineID}
+		if _, exists := projects[key]; !exists { // Keep the first match, as GetProjectByPurlName does
+			projects[key] = project
+		}
+	}
+	return projects, nil
+}
@@ -102,6 +102,16 @@ func TestProjectsSearch(t *testing.T) {
 	} else {
 		fmt.Printf("Got expected error = %v\n", err)
 	}
+	projectsByKey, err := projectsModel.GetProjectsByPurlNames([]string{"tablestyle", "NONEXISTENT"}, []int32{1, 2})
+	if err != nil {
+		t.Errorf("projects.GetProjectsByPurlNames() error = %+v", err)
+	}
+	if batched := projectsByKey[ProjectKey{PurlName: "tablestyle", MineID: 1}]; batched.PurlName != project.PurlName || batched.License != project.License {
+		t.Errorf("projects.GetProjectsByPurlNames() = %#v, want %#v", batched, project)
+	}
+	if _, found := projectsByKey[ProjectKey{PurlName: "NONEXISTENT", MineID: 1}]; found {
+		t.Errorf("projects.GetProjectsByPurlNames() returned a project that does not exist")
+	}
 }
 
 func TestProjectsSearchBadSql(t *testing.T) {
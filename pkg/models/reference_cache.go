@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Handle the process-wide cache of the licenses, versions and mines reference tables
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// ReferenceCacheOptions controls the reference table cache.
+type ReferenceCacheOptions struct {
+	Preload         bool          // Load the licenses and mines tables up front (and after each invalidation)
+	RefreshInterval time.Duration // Time between cache invalidations (0 to never invalidate automatically)
+	MaxVersions     int           // Versions cached before the version cache is emptied (0 for no limit)
+}
+
+// ReferenceCache holds the licenses, versions and mines already looked up, so that they are only read once.
+// Misses are cached too (empty entries), except when the caller asks for a missing entry to be created.
+type ReferenceCache struct {
+	sync.RWMutex
+	ctx        context.Context
+	s          *zap.SugaredLogger
+	db         *sqlx.DB
+	options    ReferenceCacheOptions
+	licenses   map[string]License // Keyed by license name
+	licenseIDs map[int32]License
+	versions   map[string]Version // Keyed by version name
+	mines      map[string][]int32 // Mine IDs keyed by purl type
+	loaded     time.Time
+}
+
+// referenceCache is the reference table cache used by all models (nil if caching is disabled).
+var referenceCache atomic.Pointer[ReferenceCache]
+
+// StartReferenceCache enables the process-wide reference table cache, preloading it if requested.
+// The cache is invalidated every refresh interval until the given context is done.
+func StartReferenceCache(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, options ReferenceCacheOptions) (*ReferenceCache, error) {
+	cache := &ReferenceCache{ctx: ctx, s: s, db: db, options: options}
+	if err := cache.Invalidate(); err != nil {
+		return nil, err
+	}
+	referenceCache.Store(cache)
+	if options.RefreshInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(options.RefreshInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := cache.Invalidate(); err != nil {
+						s.Warnf("Problem refreshing the reference cache: %v", err)
+					}
+				}
+			}
+		}()
+	}
+	return cache, nil
+}
+
+// StopReferenceCache disables the process-wide reference table cache.
+func StopReferenceCache() {
+	referenceCache.Store(nil)
+}
+
+// GetReferenceCache returns the process-wide reference table cache (nil if caching is disabled).
+func GetReferenceCache() *ReferenceCache {
+	return referenceCache.Load()
+}
+
+// Invalidate empties the cache, preloading it again if requested.
+func (c *ReferenceCache) Invalidate() error {
+	licenses := make(map[string]License)
+	licenseIDs := make(map[int32]License)
+	mines := make(map[string][]int32)
+	if c.options.Preload {
+		var allLicenses []License
+		err := c.db.SelectContext(c.ctx, &allLicenses, "SELECT id, license_name, spdx_id, is_spdx FROM licenses")
+		if err != nil {
+			return fmt.Errorf("failed to preload the licenses table: %v", err)
+		}
+		for _, license := range allLicenses {
+			licenses[license.LicenseName] = license
+			licenseIDs[license.ID] = license
+		}
+		var allMines []Mine
+		err = c.db.SelectContext(c.ctx, &allMines, "SELECT id, mine_name, purl_type FROM mines ORDER BY id")
+		if err != nil {
+			return fmt.Errorf("failed to preload the mines table: %v", err)
+		}
+		for _, mine := range allMines {
+			mines[mine.PurlType] = append(mines[mine.PurlType], mine.ID)
+		}
+		c.s.Infof("Preloaded %v licenses and %v mines into the reference cache", len(allLicenses), len(allMines))
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.licenses, c.licenseIDs, c.versions, c.mines = licenses, licenseIDs, make(map[string]Version), mines
+	c.loaded = time.Now()
+	return nil
+}
+
+// Stats returns the number of licenses, versions and purl types currently cached, and when the cache was last (re)loaded.
+func (c *ReferenceCache) Stats() (licenses, versions, purlTypes int, loaded time.Time) {
+	c.RLock()
+	defer c.RUnlock()
+	return len(c.licenses), len(c.versions), len(c.mines), c.loaded
+}
+
+// getLicense returns the cached license of the given name (if any).
+func (c *ReferenceCache) getLicense(name string) (License, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	license, found := c.licenses[name]
+	return license, found
+}
+
+// forgetLicense removes the license of the given name from the cache (i.e. before it is created).
+func (c *ReferenceCache) forgetLicense(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.licenses, name)
+}
+
+// getLicenseByID returns the cached license of the given row ID (if any).
+func (c *ReferenceCache) getLicenseByID(id int32) (License, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	license, found := c.licenseIDs[id]
+	return license, found
+}
+
+// putLicense caches the license looked up by the given name.
+func (c *ReferenceCache) putLicense(name string, license License) {
+	c.Lock()
+	defer c.Unlock()
+	c.licenses[name] = license
+	if len(license.LicenseName) > 0 {
+		c.licenseIDs[license.ID] = license
+	}
+}
+
+// getVersion returns the cached version of the given name (if any).
+func (c *ReferenceCache) getVersion(name string) (Version, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	version, found := c.versions[name]
+	return version, found
+}
+
+// putVersion caches the version looked up by the given name, emptying the version cache first if it is full.
+func (c *ReferenceCache) putVersion(name string, version Version) {
+	c.Lock()
+	defer c.Unlock()
+	if c.options.MaxVersions > 0 && len(c.versions) >= c.options.MaxVersions {
+		c.versions = make(map[string]Version)
+	}
+	c.versions[name] = version
+}
+
+// forgetVersion removes the version of the given name from the cache (i.e. before it is created).
+func (c *ReferenceCache) forgetVersion(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.versions, name)
+}
+
+// getMineIds returns the cached mine IDs of the given purl type (if any).
+func (c *ReferenceCache) getMineIds(purlType string) ([]int32, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	mineIds, found := c.mines[purlType]
+	return mineIds, found
+}
+
+// putMineIds caches the mine IDs of the given purl type.
+func (c *ReferenceCache) putMineIds(purlType string, mineIds []int32) {
+	c.Lock()
+	defer c.Unlock()
+	c.mines[purlType] = mineIds
+}
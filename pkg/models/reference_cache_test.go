@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestReferenceCache(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	err = loadTestSQLDataFiles(db, ctx, nil, []string{"../models/tests/licenses.sql", "../models/tests/mines.sql", "../models/tests/versions.sql"})
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	cache, err := StartReferenceCache(ctx, s, db, ReferenceCacheOptions{Preload: true, MaxVersions: 2})
+	if err != nil {
+		t.Fatalf("StartReferenceCache() unexpected error = %v", err)
+	}
+	defer StopReferenceCache()
+	licenses, _, purlTypes, _ := cache.Stats()
+	if licenses == 0 || purlTypes == 0 {
+		t.Errorf("StartReferenceCache() expected the licenses and mines to be preloaded, got %v, %v", licenses, purlTypes)
+	}
+
+	licenseModel := NewLicenseModel(ctx, s, db)
+	mineModel := NewMineModel(ctx, s, db)
+	versionModel := NewVersionModel(ctx, s, db)
+	mit, err := licenseModel.GetLicenseByName("MIT", false)
+	if err != nil || len(mit.LicenseName) == 0 {
+		t.Fatalf("GetLicenseByName() expected a license, got %#v, err = %v", mit, err)
+	}
+	version, err := versionModel.GetVersionByName("0.0.12", false)
+	if err != nil || len(version.VersionName) == 0 {
+		t.Fatalf("GetVersionByName() expected a version, got %#v, err = %v", version, err)
+	}
+	missing, err := licenseModel.GetLicenseByName("NONEXISTENT", false)
+	if err != nil || len(missing.LicenseName) > 0 {
+		t.Fatalf("GetLicenseByName() expected no license, got %#v, err = %v", missing, err)
+	}
+	// Changes to the tables are not seen until the cache is invalidated
+	if _, err = db.Exec("DELETE FROM licenses WHERE license_name = 'MIT'"); err != nil {
+		t.Fatalf("failed to delete license: %v", err)
+	}
+	if _, err = db.Exec("DELETE FROM mines WHERE purl_type = 'npm'"); err != nil {
+		t.Fatalf("failed to delete mines: %v", err)
+	}
+	if _, err = db.Exec("DELETE FROM versions WHERE version_name = '0.0.12'"); err != nil {
+		t.Fatalf("failed to delete version: %v", err)
+	}
+	if license, _ := licenseModel.GetLicenseByName("MIT", false); license != mit {
+		t.Errorf("GetLicenseByName() expected the cached license, got %#v", license)
+	}
+	if license, _ := licenseModel.GetLicenseByID(mit.ID); license != mit {
+		t.Errorf("GetLicenseByID() expected the cached license, got %#v", license)
+	}
+	if cached, _ := versionModel.GetVersionByName("0.0.12", false); cached != version {
+		t.Errorf("GetVersionByName() expected the cached version, got %#v", cached)
+	}
+	if mineIds, err := mineModel.GetMineIdsByPurlType("npm"); err != nil || !slices.Contains(mineIds, 2) {
+		t.Errorf("GetMineIdsByPurlType() expected the cached mines, got %v, err = %v", mineIds, err)
+	}
+	// Creating a license cached as missing looks it up again
+	created, err := licenseModel.GetLicenseByName("NONEXISTENT", true)
+	if err != nil || created.LicenseName != "NONEXISTENT" {
+		t.Errorf("GetLicenseByName() expected a new license, got %#v, err = %v", created, err)
+	}
+
+	if err = cache.Invalidate(); err != nil {
+		t.Fatalf("Invalidate() unexpected error = %v", err)
+	}
+	if license, _ := licenseModel.GetLicenseByName("MIT", false); len(license.LicenseName) > 0 {
+		t.Errorf("GetLicenseByName() expected the deleted license to be gone, got %#v", license)
+	}
+	if cached, _ := versionModel.GetVersionByName("0.0.12", false); len(cached.VersionName) > 0 {
+		t.Errorf("GetVersionByName() expected the deleted version to be gone, got %#v", cached)
+	}
+	if _, err = mineModel.GetMineIdsByPurlType("npm"); err == nil {
+		t.Errorf("GetMineIdsByPurlType() expected an error for the deleted mines")
+	}
+	for _, name := range []string{"0.0.7", "0.0.8", "0.0.9"} {
+		_, _ = versionModel.GetVersionByName(name, false)
+	}
+	if _, versions, _, _ := cache.Stats(); versions > 2 {
+		t.Errorf("GetVersionByName() expected at most 2 cached versions, got %v", versions)
+	}
+}
@@ -0,0 +1,31 @@
+DROP TABLE IF EXISTS curations;
+CREATE TABLE curations (
+                       id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+                       purl_type           TEXT NOT NULL,
+                       purl_name           TEXT NOT NULL,
+                       version_range       TEXT NOT NULL DEFAULT '',
+                       license             TEXT NOT NULL DEFAULT '',
+                       url                 TEXT NOT NULL DEFAULT '',
+                       add_dependencies    TEXT NOT NULL DEFAULT '[]',
+                       remove_dependencies TEXT NOT NULL DEFAULT '[]',
+                       reason              TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO curations (purl_type, purl_name, version_range, license, url, add_dependencies, remove_dependencies, reason) VALUES ('npm', 'react', '>=17.0.0 <18.0.0', 'Apache-2.0', '', '[]', '[]', 'Relicensed by legal review');
+INSERT INTO curations (purl_type, purl_name, version_range, license, url, add_dependencies, remove_dependencies, reason) VALUES ('npm', '%40leaflink/stash', '31.1.2', '', '', '[{"dep_purl_name": "curated-dep", "dep_ver": "^1.0.0"}]', '["sass", "vite"]', 'Bogus dependencies');
+
+CREATE TRIGGER curations_generation_insert AFTER INSERT ON curations
+BEGIN
+    INSERT INTO kb_metadata (key, value, updated_at) VALUES ('curations', '1', strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    ON CONFLICT (key) DO UPDATE SET value = CAST(CAST(kb_metadata.value AS INTEGER) + 1 AS TEXT), updated_at = excluded.updated_at;
+END;
+CREATE TRIGGER curations_generation_update AFTER UPDATE ON curations
+BEGIN
+    INSERT INTO kb_metadata (key, value, updated_at) VALUES ('curations', '1', strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    ON CONFLICT (key) DO UPDATE SET value = CAST(CAST(kb_metadata.value AS INTEGER) + 1 AS TEXT), updated_at = excluded.updated_at;
+END;
+CREATE TRIGGER curations_generation_delete AFTER DELETE ON curations
+BEGIN
+    INSERT INTO kb_metadata (key, value, updated_at) VALUES ('curations', '1', strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    ON CONFLICT (key) DO UPDATE SET value = CAST(CAST(kb_metadata.value AS INTEGER) + 1 AS TEXT), updated_at = excluded.updated_at;
+END;
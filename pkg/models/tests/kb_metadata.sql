@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS kb_metadata;
+CREATE TABLE kb_metadata (
+                         key        TEXT PRIMARY KEY,
+                         value      TEXT NOT NULL,
+                         updated_at TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO kb_metadata (key, value, updated_at) VALUES ('snapshot', '2026.10.01', '2026-10-01T00:00:00Z');
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS live_components;
+CREATE TABLE live_components (
+                       purl_type  TEXT NOT NULL,
+                       purl_name  TEXT NOT NULL,
+                       version    TEXT NOT NULL,
+                       license_id INTEGER,
+                       url        TEXT DEFAULT '',
+                       provider   TEXT DEFAULT '',
+                       retrieved  TEXT DEFAULT '',
+                       PRIMARY KEY (purl_type, purl_name, version)
+);
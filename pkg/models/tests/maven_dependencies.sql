@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS maven_dependencies;
+CREATE TABLE maven_dependencies (
+                       purl_name TEXT,
+                       version TEXT,
+                       dep_data  TEXT,
+                       PRIMARY KEY (purl_name, version)
+);
+
+INSERT INTO maven_dependencies (purl_name, version, dep_data) VALUES ('com.example/app', '1.0.0', '[{"dep_ver": "${project.version}", "dep_purl_name": "com.example/lib"}, {"dep_ver": "${guava.version}", "dep_purl_name": "com.google.guava/guava"}, {"dep_ver": "", "dep_purl_name": "org.slf4j/slf4j-api"}, {"dep_ver": "[4.12,5.0)", "dep_purl_name": "junit/junit"}, {"dep_ver": "${missing.version}", "dep_purl_name": "com.example/missing"}, {"dep_ver": "", "dep_purl_name": "com.example/unmanaged"}]');
+INSERT INTO maven_dependencies (purl_name, version, dep_data) VALUES ('com.example/lib', '1.0.0', '[{"dep_ver": "${commons.version}", "dep_purl_name": "org.apache.commons/commons-lang3"}]');
+INSERT INTO maven_dependencies (purl_name, version, dep_data) VALUES ('com.google.guava/guava', '31.1-jre', '[{"dep_ver": "1.0.1", "dep_purl_name": "com.google.guava/failureaccess"}]');
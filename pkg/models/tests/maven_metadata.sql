@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS maven_metadata;
+CREATE TABLE maven_metadata (
+                       purl_name        TEXT NOT NULL,
+                       version          TEXT NOT NULL,
+                       parent_purl_name TEXT DEFAULT '',
+                       parent_version   TEXT DEFAULT '',
+                       properties       TEXT DEFAULT '{}',
+                       dep_management   TEXT DEFAULT '[]',
+                       PRIMARY KEY (purl_name, version)
+);
+
+INSERT INTO maven_metadata (purl_name, version, parent_purl_name, parent_version, properties, dep_management) VALUES ('com.example/app', '1.0.0', 'com.example/parent', '3', '{"guava.version": "31.1-jre"}', '[]');
+INSERT INTO maven_metadata (purl_name, version, parent_purl_name, parent_version, properties, dep_management) VALUES ('com.example/lib', '1.0.0', 'com.example/parent', '3', '{}', '[]');
+INSERT INTO maven_metadata (purl_name, version, parent_purl_name, parent_version, properties, dep_management) VALUES ('com.example/parent', '3', '', '', '{"commons.version": "3.12.0", "slf4j.version": "1.7.36"}', '[{"dep_ver": "${project.version}", "dep_purl_name": "org.example/bom", "scope": "import"}]');
+INSERT INTO maven_metadata (purl_name, version, parent_purl_name, parent_version, properties, dep_management) VALUES ('org.example/bom', '3', '', '', '{"slf4j.version": "2.0.7"}', '[{"dep_ver": "${slf4j.version}", "dep_purl_name": "org.slf4j/slf4j-api"}]');
@@ -40,8 +40,6 @@ type Version struct {
 	SemVer      string `db:"semver"`
 }
 
-// TODO add cache for versions already searched for?
-
 // NewVersionModel creates a new instance of the Version Model.
 func NewVersionModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *VersionModel {
 	return &VersionModel{ctx: ctx, s: s, db: db}
@@ -53,6 +51,16 @@ func (m *VersionModel) GetVersionByName(name string, create bool) (Version, erro
 		m.s.Error("Please specify a valid Version Name to query")
 		return Version{}, errors.New("please specify a valid Version Name to query")
 	}
+	cache := GetReferenceCache()
+	if cache != nil {
+		version, found := cache.getVersion(name)
+		if found && (len(version.VersionName) > 0 || !create) {
+			return version, nil
+		}
+		if found { // Cached as missing, but requested to create it (so look it up again)
+			cache.forgetVersion(name)
+		}
+	}
 	var version Version
 	err := m.db.QueryRowxContext(m.ctx,
 		"SELECT id, version_name, semver FROM versions"+
@@ -63,9 +71,14 @@ func (m *VersionModel) GetVersionByName(name string, create bool) (Version, erro
 		return Version{}, fmt.Errorf("failed to query the versions table: %v", err)
 	}
 	if create && len(version.VersionName) == 0 { // No version found and requested to create an entry
-		return m.saveVersion(name)
+		version, err = m.saveVersion(name)
+		if err != nil {
+			return version, err
+		}
+	}
+	if cache != nil {
+		cache.putVersion(name, version)
 	}
-
 	return version, nil
 }
 
@@ -22,15 +22,21 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	gw "github.com/scanoss/go-grpc-helper/pkg/grpc/gateway"
 	pb "github.com/scanoss/papi/api/dependenciesv2"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	myconfig "scanoss.com/dependencies/pkg/config"
 )
 
+// RouteRegistrar registers REST-only endpoints that are served directly by the gateway.
+type RouteRegistrar interface {
+	RegisterRoutes(mux *runtime.ServeMux) error
+}
+
 // RunServer runs REST grpc gateway to forward requests onto the gRPC server.
 func RunServer(config *myconfig.ServerConfig, ctx context.Context, grpcPort, httpPort string,
-	allowedIPs, deniedIPs []string, startTLS bool) (*http.Server, error) {
+	allowedIPs, deniedIPs []string, startTLS bool, routes RouteRegistrar) (*http.Server, error) {
 	// configure the gateway for forwarding to gRPC
 	srv, mux, grpcGateway, opts, err := gw.SetupGateway(grpcPort, httpPort, config.TLS.CertFile, config.TLS.CN,
 		allowedIPs, deniedIPs, config.Filtering.BlockByDefault, config.Filtering.TrustProxy,
@@ -38,6 +44,12 @@ func RunServer(config *myconfig.ServerConfig, ctx context.Context, grpcPort, htt
 	if err != nil {
 		return nil, err
 	}
+	// Add any REST-only endpoints (these still go through the same IP filtering as the gateway)
+	if routes != nil {
+		if err = routes.RegisterRoutes(mux); err != nil {
+			return nil, err
+		}
+	}
 	// Open TCP port (in the background) and listen for requests
 	go func() {
 		ctx2, cancel := context.WithCancel(ctx)
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// GetComponentVersions returns a page of the versions of the requested component (newest first), with their release
+// date, licenses and whether dependency data exists for them. Supports the purl, constraint, page and page_size
+// query parameters.
+func (d DependencyHTTPServer) GetComponentVersions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing component versions request...")
+	query := r.URL.Query()
+	request := dtos.ComponentVersionsInput{Purl: strings.TrimSpace(query.Get("purl")), Constraint: strings.TrimSpace(query.Get("constraint"))}
+	if len(request.Purl) == 0 {
+		writeHTTPError(w, s, errors.NewBadRequestError("no purl supplied", nil))
+		return
+	}
+	var err error
+	for name, value := range map[string]*int{"page": &request.Page, "page_size": &request.PageSize} {
+		if param := query.Get(name); len(param) > 0 {
+			if *value, err = strconv.Atoi(param); err != nil {
+				writeHTTPError(w, s, errors.NewBadRequestError("invalid "+name, err))
+				return
+			}
+		}
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	depUc := usecase.NewDependencies(ctx, s, d.db, d.config, d.pool)
+	output, err := depUc.GetComponentVersions(request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	output.KBSnapshot = snapshot
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	output.Status = dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	writeHTTPResponse(w, s, http.StatusOK, output)
+}
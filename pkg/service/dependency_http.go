@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/jobs"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// REST-only endpoint paths (not yet available in the gRPC API definitions).
+const (
+	dependencyDecoratePath       = "/v2/dependencies/decorate"
+	dependencyStreamPath         = "/v2/dependencies/stream"
+	componentVersionsPath        = "/v2/dependencies/versions"
+	licenseChangesPath           = "/v2/dependencies/license-changes"
+	transitiveGraphPath          = "/v2/dependencies/transitive/graph"
+	transitiveStreamPath         = "/v2/dependencies/transitive/stream"
+	transitiveDiffPath           = "/v2/dependencies/transitive/diff"
+	transitiveSimulatePath       = "/v2/dependencies/transitive/simulate"
+	transitiveExportPath         = "/v2/dependencies/transitive/export"
+	referenceCacheInvalidatePath = "/v2/admin/reference-cache/invalidate"
+)
+
+// DependencyHTTPServer serves the REST-only dependency endpoints directly from the gateway.
+type DependencyHTTPServer struct {
+	db     *sqlx.DB
+	config *myconfig.ServerConfig
+	jobs   *jobs.Manager
+	pool   *usecase.DecorationPool
+}
+
+// NewDependencyHTTPServer creates a new instance of the Dependency HTTP Server.
+// The asynchronous transitive job endpoints are only served if a job manager is supplied.
+// Components are decorated on the given pool, which should be shared with the gRPC server.
+func NewDependencyHTTPServer(db *sqlx.DB, config *myconfig.ServerConfig, jobManager *jobs.Manager,
+	pool *usecase.DecorationPool) *DependencyHTTPServer {
+	return &DependencyHTTPServer{db: db, config: config, jobs: jobManager, pool: pool}
+}
+
+// RegisterRoutes adds the REST-only endpoints to the supplied gateway mux.
+func (d DependencyHTTPServer) RegisterRoutes(mux *runtime.ServeMux) error {
+	if err := mux.HandlePath(http.MethodPost, transitiveGraphPath, d.GetTransitiveDependencyGraph); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, transitiveStreamPath, d.StreamTransitiveDependencies); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, transitiveDiffPath, d.DiffTransitiveDependencies); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, transitiveSimulatePath, d.SimulateUpgrade); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, transitiveExportPath, d.ExportTransitiveDependencies); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, dependencyDecoratePath, d.DecorateDependencies); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodPost, dependencyStreamPath, d.StreamDependencies); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, componentVersionsPath, d.GetComponentVersions); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, licenseChangesPath, d.GetLicenseChanges); err != nil {
+		return err
+	}
+	if d.config.ReferenceCache.Admin { // Only served on request, as it is not authenticated
+		if err := mux.HandlePath(http.MethodPost, referenceCacheInvalidatePath, d.InvalidateReferenceCache); err != nil {
+			return err
+		}
+	}
+	if d.jobs == nil {
+		return nil
+	}
+	return d.registerJobRoutes(mux)
+}
+
+// GetTransitiveDependencyGraph returns the raw transitive dependencies along with the effective set
+// the ecosystem's package manager would resolve.
+func (d DependencyHTTPServer) GetTransitiveDependencyGraph(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing transitive dependency graph request...")
+	var request transitiveDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem parsing transitive dependency request", err))
+		return
+	}
+	transitiveDependencyDTO, err := convertRESTToTransitiveDependencyDTO(s, d.config, request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	transitiveDependenciesUc := usecase.NewTransitiveDependencies(ctx, s, d.db, d.config)
+	result, err := transitiveDependenciesUc.GetTransitiveDependencies(s, transitiveDependencyDTO)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	output := convertToTransitiveDependencyGraphOutput(result)
+	output.KBSnapshot = snapshot
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	output.Status = dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	writeHTTPResponse(w, s, http.StatusOK, output)
+}
+
+// DecorateDependencies searches the KB for the details of the given purls, like the GetDependencies RPC, returning
+// the optional details it has no fields for (i.e. the outdated version report requested with "outdated": true
+// and the license warnings requested with "license_warnings": true).
+func (d DependencyHTTPServer) DecorateDependencies(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing dependency decoration request...")
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem reading dependency request", err))
+		return
+	}
+	request, err := dtos.ParseDependencyInput(s, body)
+	if err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem parsing dependency request", err))
+		return
+	}
+	if len(request.Files) == 0 {
+		writeHTTPError(w, s, errors.NewBadRequestError("no request data supplied", nil))
+		return
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	depUc := usecase.NewDependencies(ctx, s, d.db, d.config, d.pool)
+	dependencies, warn, err := depUc.GetDependencies(request)
+	status := dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	if err != nil {
+		if !warn {
+			writeHTTPError(w, s, errors.NewInternalError("problems encountered extracting dependency data", err))
+			return
+		}
+		status = dtos.StatusOutput{Status: common.StatusCode_SUCCEEDED_WITH_WARNINGS.String(), Message: "Problems decorating some purls"}
+	}
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	writeHTTPResponse(w, s, http.StatusOK, dtos.DependencyDecorationOutput{Files: dependencies.Files, KBSnapshot: snapshot, Status: status})
+}
+
+// requestLogger attaches the application logger to the request context and returns both.
+func requestLogger(r *http.Request) (context.Context, *zap.SugaredLogger) {
+	ctx := ctxzap.ToContext(r.Context(), zlog.L)
+	return ctx, ctxzap.Extract(ctx).Sugar()
+}
+
+// writeHTTPError converts the given error into a JSON status response with the matching HTTP code.
+func writeHTTPError(w http.ResponseWriter, s *zap.SugaredLogger, err error) {
+	serviceErr, ok := errors.GetServiceError(err)
+	if !ok {
+		serviceErr = errors.NewInternalError("internal server error", err)
+	}
+	s.Errorf("REST request failed: %v", err)
+	writeHTTPResponse(w, s, serviceErr.GetHTTPCode(), struct {
+		Status dtos.StatusOutput `json:"status"`
+	}{Status: dtos.StatusOutput{Status: common.StatusCode_FAILED.String(), Message: serviceErr.Message}})
+}
+
+// writeHTTPResponse writes the given value as a JSON response.
+func writeHTTPResponse(w http.ResponseWriter, s *zap.SugaredLogger, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.Warnf("Problem writing REST response: %v", err)
+	}
+}
@@ -0,0 +1,666 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/dependenciesv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/protobuf/encoding/protojson"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/models"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// setupHTTPServer creates a gateway mux with the REST-only routes registered against the test data.
+func setupHTTPServer(t *testing.T) (*runtime.ServeMux, func()) {
+	t.Helper()
+	return setupHTTPServerConfig(t, nil)
+}
+
+// setupHTTPServerConfig creates a test HTTP server, letting the caller adjust the default config first.
+func setupHTTPServerConfig(t *testing.T, configure func(config *myconfig.ServerConfig)) (*runtime.ServeMux, func()) {
+	t.Helper()
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // Each connection to an in-memory database opens a new, empty one
+	err = models.LoadTestSQLData(db, nil, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	if configure != nil {
+		configure(myConfig)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	jobManager, err := NewTransitiveJobManager(ctx, db, myConfig)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the job manager", err)
+	}
+	mux := runtime.NewServeMux()
+	if err = NewDependencyHTTPServer(db, myConfig, jobManager, usecase.NewDecorationPool(myConfig.Components.MaxWorkers)).RegisterRoutes(mux); err != nil {
+		t.Fatalf("an error '%s' was not expected when registering routes", err)
+	}
+	return mux, func() {
+		cancel()
+		models.CloseDB(db)
+		zlog.SyncZap()
+	}
+}
+
+func TestDependencyHTTPServer_GetTransitiveDependencyGraph(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	tests := []struct {
+		name         string
+		body         string
+		minSnapshot  string
+		wantCode     int
+		wantStrategy string
+		wantVersions map[string]string // Resolved version by purl
+		wantRequires map[string]string // Declared requirement by purl
+		wantExcluded string            // Purl prefix of excluded dependencies
+	}{
+		{
+			name:         "npm transitive graph",
+			body:         `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1}`,
+			wantCode:     http.StatusOK,
+			wantStrategy: "nested",
+			wantRequires: map[string]string{"pkg:npm/isbinaryfile": "^4.0.8"},
+		},
+		{
+			name:         "minimum KB snapshot met",
+			body:         `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1}`,
+			minSnapshot:  "2026.9",
+			wantCode:     http.StatusOK,
+			wantStrategy: "nested",
+		},
+		{
+			name:        "minimum KB snapshot not met",
+			body:        `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1}`,
+			minSnapshot: "2027.01.01",
+			wantCode:    http.StatusPreconditionFailed,
+		},
+		{
+			name: "pinned and excluded dependencies",
+			body: `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1,
+				"pins": [{"purl": "pkg:npm/@types/*", "exclude": true}, {"purl": "pkg:npm/isbinaryfile", "version": "4.0.0"}]}`,
+			wantCode:     http.StatusOK,
+			wantStrategy: "nested",
+			wantVersions: map[string]string{"pkg:npm/isbinaryfile": "4.0.0"},
+			wantRequires: map[string]string{"pkg:npm/isbinaryfile": "4.0.0"},
+			wantExcluded: "pkg:npm/%2540types",
+		},
+		{
+			name: "pin without version",
+			body: `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1,
+				"pins": [{"purl": "pkg:npm/isbinaryfile"}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid request body",
+			body:     `{"components": `,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unsupported ecosystem",
+			body:     `{"components": [{"purl": "pkg:pypi/requests", "requirement": "1.0.0"}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, transitiveGraphPath, strings.NewReader(tt.body))
+			if len(tt.minSnapshot) > 0 {
+				req.Header.Set(kbMinSnapshotKey, tt.minSnapshot)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected HTTP code %v, got %v: %v", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			var output dtos.TransitiveDependencyOutput
+			if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+				t.Fatalf("an error '%s' was not expected when parsing the response", err)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if output.ResolutionStrategy != tt.wantStrategy {
+				t.Errorf("expected strategy %v, got %v", tt.wantStrategy, output.ResolutionStrategy)
+			}
+			if output.KBSnapshot != "2026.10.01" || rec.Header().Get(kbSnapshotKey) != output.KBSnapshot {
+				t.Errorf("expected KB snapshot 2026.10.01, got %v (header %v)", output.KBSnapshot, rec.Header().Get(kbSnapshotKey))
+			}
+			if len(output.Dependencies) == 0 || len(output.Resolved) == 0 {
+				t.Errorf("expected raw and resolved dependencies, got %+v", output)
+			}
+			for _, resolved := range output.Resolved {
+				if version, found := tt.wantVersions[resolved.Purl]; found && version != resolved.Version {
+					t.Errorf("expected %v to resolve to %v, got %v", resolved.Purl, version, resolved.Version)
+				}
+				if requirement, found := tt.wantRequires[resolved.Purl]; found && requirement != resolved.Requirement {
+					t.Errorf("expected %v to be required as %v, got %v", resolved.Purl, requirement, resolved.Requirement)
+				}
+				if len(tt.wantExcluded) > 0 && strings.HasPrefix(resolved.Purl, tt.wantExcluded) {
+					t.Errorf("expected %v to be excluded", resolved.Purl)
+				}
+			}
+		})
+	}
+}
+
+func TestDependencyHTTPServer_TransitiveJobs(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+	rec := serve(http.MethodPost, transitiveJobsPath, `{"components": [{"purl": "pkg:pypi/requests", "requirement": "1.0.0"}]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected HTTP code %v for an invalid job, got %v: %v", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	rec = serve(http.MethodPost, transitiveJobsPath, `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1}`)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected HTTP code %v, got %v: %v", http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+	var job dtos.TransitiveJobOutput
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil || len(job.JobID) == 0 {
+		t.Fatalf("expected a job ID in the response, got %v (%v)", rec.Body.String(), err)
+	}
+	jobPath := transitiveJobsPath + "/" + job.JobID
+	for deadline := time.Now().Add(10 * time.Second); job.State != "completed" && time.Now().Before(deadline); {
+		time.Sleep(20 * time.Millisecond)
+		if rec = serve(http.MethodGet, jobPath, ""); rec.Code != http.StatusOK {
+			t.Fatalf("expected HTTP code %v, got %v: %v", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+			t.Fatalf("an error '%s' was not expected when parsing the response", err)
+		}
+	}
+	if job.State != "completed" || job.Progress.NodesExplored == 0 {
+		t.Fatalf("expected the job to complete with progress, got %+v", job)
+	}
+	rec = serve(http.MethodGet, jobPath+"/result", "")
+	var output dtos.TransitiveDependencyOutput
+	if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("expected the job result, got %v: %v", rec.Code, rec.Body.String())
+	}
+	if len(output.Dependencies) == 0 || output.KBSnapshot != "2026.10.01" {
+		t.Errorf("expected the transitive dependencies and KB snapshot, got %+v", output)
+	}
+	if rec = serve(http.MethodDelete, jobPath, ""); rec.Code != http.StatusConflict {
+		t.Errorf("expected HTTP code %v when cancelling a completed job, got %v", http.StatusConflict, rec.Code)
+	}
+	if rec = serve(http.MethodGet, transitiveJobsPath+"/unknown", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected HTTP code %v for an unknown job, got %v", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestDependencyHTTPServer_StreamTransitiveDependencies(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	body := `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 2}`
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, transitiveStreamPath, strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected HTTP code %v, got %v: %v", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var messages []dtos.TransitiveStreamOutput
+	decoder := json.NewDecoder(rec.Body)
+	for decoder.More() {
+		var message dtos.TransitiveStreamOutput
+		if err := decoder.Decode(&message); err != nil {
+			t.Fatalf("an error '%s' was not expected when parsing the stream", err)
+		}
+		messages = append(messages, message)
+	}
+	counts := map[string]int{}
+	for _, message := range messages {
+		counts[message.Type]++
+	}
+	if counts["node"] == 0 || counts["edge"] < counts["node"] || counts["summary"] != 1 {
+		t.Fatalf("expected nodes, edges and a single summary, got %v", counts)
+	}
+	summary := messages[len(messages)-1].Summary
+	if summary == nil || summary.Status.Status != common.StatusCode_SUCCESS.String() || summary.Dependencies != counts["node"] || summary.KBSnapshot != "2026.10.01" {
+		t.Errorf("expected a successful summary covering the streamed nodes, got %+v", summary)
+	}
+	if edge := messages[1].Edge; messages[0].Type != "node" || edge == nil || edge.From.Purl != "pkg:npm/scanoss" {
+		t.Errorf("expected the first node and its edge from the entry component, got %+v, %+v", messages[0], messages[1])
+	}
+
+	// A disconnected client cancels the collection, with nothing streamed
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, transitiveStreamPath, strings.NewReader(body)).WithContext(ctx))
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected no messages for a cancelled request, got %v", rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, transitiveStreamPath, strings.NewReader(`{"components": `)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected HTTP code %v, got %v", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestDependencyHTTPServer_StreamDependencies(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	body := `{"files": [{"file": "package.json", "purls": [{"purl": "pkg:npm/isbinaryfile", "requirement": "^4.0.8"}, {"purl": "pkg:npm/sort-paths"}]}]}
+{"files": [{"file": "package.json", "purls": [{"purl": "pkg:npm/electron-debug", "requirement": "^3.1.0"}]}]}
+{"files": []}
+{"files": [
+{"files": [{"file": "package.json", "purls": [{"purl": "pkg:npm/sort-paths"}]}], "unknown": true}`
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, dependencyStreamPath, strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected HTTP code %v, got %v: %v", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var outputs []dependencyStreamOutput
+	decoder := json.NewDecoder(rec.Body)
+	for decoder.More() {
+		var output dependencyStreamOutput
+		if err := decoder.Decode(&output); err != nil {
+			t.Fatalf("an error '%s' was not expected when parsing the stream", err)
+		}
+		outputs = append(outputs, output)
+	}
+	if len(outputs) != 5 {
+		t.Fatalf("expected one output per chunk, got %+v", outputs)
+	}
+	success := common.StatusCode_SUCCESS.String()
+	var file pb.DependencyResponse_Files
+	if outputs[0].Status.Status != success || len(outputs[0].Files) != 1 || outputs[0].Purls != 2 {
+		t.Fatalf("expected the first chunk to be decorated, got %+v", outputs[0])
+	}
+	if err := protojson.Unmarshal(outputs[0].Files[0], &file); err != nil || len(file.GetDependencies()) != 2 {
+		t.Errorf("expected two protobuf JSON encoded dependencies, got %s (%v)", outputs[0].Files[0], err)
+	}
+	if outputs[1].Status.Status != success || outputs[1].Chunk != 2 || outputs[1].Purls != 3 {
+		t.Errorf("expected the second chunk to be decorated, got %+v", outputs[1])
+	}
+	failed := common.StatusCode_FAILED.String()
+	if outputs[2].Status.Status != failed || outputs[3].Status.Status != failed || outputs[3].Chunk != 4 {
+		t.Errorf("expected the empty and malformed chunks to fail, got %+v, %+v", outputs[2], outputs[3])
+	}
+	if outputs[4].Status.Status != success || outputs[4].Chunk != 5 || outputs[4].Purls != 4 {
+		t.Errorf("expected the chunk after the malformed one to be decorated, got %+v", outputs[4])
+	}
+}
+
+func TestDependencyHTTPServer_InvalidateReferenceCache(t *testing.T) {
+	disabledMux, disabledCleanup := setupHTTPServer(t)
+	defer disabledCleanup()
+	rec := httptest.NewRecorder()
+	disabledMux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, referenceCacheInvalidatePath, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected HTTP code %v with the admin endpoint disabled, got %v: %v", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+	mux, cleanup := setupHTTPServerConfig(t, func(config *myconfig.ServerConfig) { config.ReferenceCache.Admin = true })
+	defer cleanup()
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, referenceCacheInvalidatePath, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected HTTP code %v without a reference cache, got %v: %v", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, nil, nil); err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	_, err = models.StartReferenceCache(context.Background(), zlog.S, db, models.ReferenceCacheOptions{Preload: true})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when starting the reference cache", err)
+	}
+	defer models.StopReferenceCache()
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, referenceCacheInvalidatePath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected HTTP code %v, got %v: %v", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var output dtos.ReferenceCacheOutput
+	if err = json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the response", err)
+	}
+	if output.Licenses == 0 || output.PurlTypes == 0 || len(output.LoadedAt) == 0 {
+		t.Errorf("expected the reloaded reference cache details, got %+v", output)
+	}
+}
+
+func TestDependencyHTTPServer_DecorateDependencies(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	body := `{"files": [{"file": "package.json", "purls": [{"purl": "pkg:npm/isbinaryfile@4.0.0", "requirement": "^4.0.0"}, {"purl": "pkg:npm/sort-paths"}]}], "outdated": true}`
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, dependencyDecoratePath, strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected HTTP code %v, got %v: %v", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var output dtos.DependencyDecorationOutput
+	if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing the response", err)
+	}
+	if len(output.Files) != 1 || len(output.Files[0].Dependencies) != 2 || output.KBSnapshot != "2026.10.01" {
+		t.Fatalf("expected both dependencies to be decorated, got %+v", output)
+	}
+	isBinaryFile, sortPaths := output.Files[0].Dependencies[0].Outdated, output.Files[0].Dependencies[1].Outdated
+	if isBinaryFile == nil || !isBinaryFile.Outdated || isBinaryFile.Latest != "4.0.8" || isBinaryFile.PatchesBehind != 7 {
+		t.Errorf("expected isbinaryfile to be 7 patches behind 4.0.8, got %+v", isBinaryFile)
+	}
+	if sortPaths == nil || sortPaths.Outdated {
+		t.Errorf("expected sort-paths to be up to date, got %+v", sortPaths)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, dependencyDecoratePath, strings.NewReader(`{"files": []}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected HTTP code %v for an empty request, got %v", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestDependencyHTTPServer_GetComponentVersions(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	tests := []struct {
+		name         string
+		query        string
+		wantCode     int
+		wantTotal    int
+		wantVersions []string
+	}{
+		{name: "constraint and page", query: "purl=pkg:npm/isbinaryfile&constraint=^4.0.0&page=2&page_size=4",
+			wantCode: http.StatusOK, wantTotal: 8, wantVersions: []string{"4.0.3", "4.0.2", "4.0.1", "4.0.0"}},
+		{name: "version in purl ignored", query: "purl=pkg:npm/isbinaryfile@1.0.0&page_size=2",
+			wantCode: http.StatusOK, wantTotal: 30, wantVersions: []string{"4.0.8", "4.0.6"}},
+		{name: "page past the end", query: "purl=pkg:npm/isbinaryfile&constraint=^4.0.0&page=10",
+			wantCode: http.StatusOK, wantTotal: 8, wantVersions: []string{}},
+		{name: "huge page", query: "purl=pkg:npm/isbinaryfile&page=4611686018427387904&page_size=4",
+			wantCode: http.StatusOK, wantTotal: 30, wantVersions: []string{}},
+		{name: "missing purl", query: "constraint=^4.0.0", wantCode: http.StatusBadRequest},
+		{name: "invalid page size", query: "purl=pkg:npm/isbinaryfile&page_size=lots", wantCode: http.StatusBadRequest},
+		{name: "page size too large", query: "purl=pkg:npm/isbinaryfile&page_size=5000", wantCode: http.StatusBadRequest},
+		{name: "unknown component", query: "purl=pkg:npm/not-a-real-package-xyz", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, componentVersionsPath+"?"+tt.query, nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected HTTP code %v, got %v: %v", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var output dtos.ComponentVersionsOutput
+			if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+				t.Fatalf("an error '%s' was not expected when parsing the response", err)
+			}
+			versions := make([]string, 0, len(output.Versions))
+			for _, version := range output.Versions {
+				versions = append(versions, version.Version)
+			}
+			if output.Total != tt.wantTotal || !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("expected %v versions %v, got %v %v", tt.wantTotal, tt.wantVersions, output.Total, versions)
+			}
+			if output.KBSnapshot != "2026.10.01" || output.Status.Status != common.StatusCode_SUCCESS.String() {
+				t.Errorf("expected a successful response for KB snapshot 2026.10.01, got %+v", output)
+			}
+			for _, version := range output.Versions {
+				if len(version.Date) == 0 || len(version.Licenses) == 0 || version.Licenses[0].SpdxID != "MIT" {
+					t.Errorf("expected the date and MIT license for %v, got %+v", version.Version, version)
+				}
+			}
+		})
+	}
+}
+
+func TestDependencyHTTPServer_GetLicenseChanges(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+	}{
+		{name: "from and to", query: "purl=pkg:npm/isbinaryfile&from=3.0.0&to=4.0.8", wantCode: http.StatusOK},
+		{name: "constraint", query: "purl=pkg:npm/isbinaryfile&constraint=^4.0.0", wantCode: http.StatusOK},
+		{name: "missing purl", query: "from=3.0.0", wantCode: http.StatusBadRequest},
+		{name: "from after to", query: "purl=pkg:npm/isbinaryfile&from=4.0.0&to=3.0.0", wantCode: http.StatusBadRequest},
+		{name: "unknown component", query: "purl=pkg:npm/not-a-real-package-xyz", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, licenseChangesPath+"?"+tt.query, nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected HTTP code %v, got %v: %v", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var output dtos.LicenseChangesOutput
+			if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+				t.Fatalf("an error '%s' was not expected when parsing the response", err)
+			}
+			// Every version of isbinaryfile in the test KB is MIT licensed
+			if output.Versions == 0 || output.Changes == nil || len(output.Changes) != 0 || output.KBSnapshot != "2026.10.01" {
+				t.Errorf("expected no license changes, got %+v", output)
+			}
+		})
+	}
+}
+
+func TestDependencyHTTPServer_DiffTransitiveDependencies(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    int
+		wantAdded   bool
+		wantRemoved bool
+		wantChanged []string
+	}{
+		{name: "same dependencies", body: `{"purl": "pkg:npm/vue-phone", "from": "1.0.8", "to": "1.0.10", "depth": 1}`,
+			wantCode: http.StatusOK},
+		{name: "different components", body: `{"before": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}],
+			"after": [{"purl": "pkg:npm/vue-phone", "requirement": "1.0.10"}], "depth": 1}`,
+			wantCode: http.StatusOK, wantAdded: true, wantRemoved: true, wantChanged: []string{"pkg:npm/chai", "pkg:npm/mocha"}},
+		{name: "new component", body: `{"after": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1}`,
+			wantCode: http.StatusOK, wantAdded: true},
+		{name: "no components", body: `{"depth": 1}`, wantCode: http.StatusBadRequest},
+		{name: "purl without versions", body: `{"purl": "pkg:npm/vue-phone", "from": "1.0.8"}`, wantCode: http.StatusBadRequest},
+		{name: "invalid request body", body: `{"before": `, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, transitiveDiffPath, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected HTTP code %v, got %v: %v", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var output dtos.TransitiveDiffOutput
+			if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+				t.Fatalf("an error '%s' was not expected when parsing the response", err)
+			}
+			changed := make([]string, 0, len(output.Changed))
+			for _, change := range output.Changed {
+				changed = append(changed, change.Purl)
+				if len(change.Before) == 0 || len(change.After) == 0 {
+					t.Errorf("expected before and after versions for changed package %v, got %+v", change.Purl, change)
+				}
+			}
+			if (len(output.Added) > 0) != tt.wantAdded || (len(output.Removed) > 0) != tt.wantRemoved || !reflect.DeepEqual(changed, append([]string{}, tt.wantChanged...)) {
+				t.Errorf("expected added %v, removed %v and changed %v, got %+v", tt.wantAdded, tt.wantRemoved, tt.wantChanged, output)
+			}
+			if !output.Complete || output.KBSnapshot != "2026.10.01" || output.Status.Status != common.StatusCode_SUCCESS.String() {
+				t.Errorf("expected a complete diff for KB snapshot 2026.10.01, got %+v", output)
+			}
+			for _, added := range output.Added {
+				if len(added.Before) != 0 || len(added.After) == 0 {
+					t.Errorf("expected only after versions for added package %v, got %+v", added.Purl, added)
+				}
+			}
+		})
+	}
+}
+
+func TestDependencyHTTPServer_SimulateUpgrade(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    int
+		wantChanged []string
+		wantApplied map[string]bool
+	}{
+		{name: "pinned dependency", body: `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1,
+			"overrides": {"pkg:npm/isbinaryfile": "4.0.0", "pkg:npm/left-pad": "1.3.0"}, "policy": {"deny_licenses": ["GPL-3.0-only"]}}`,
+			wantCode: http.StatusOK, wantChanged: []string{"pkg:npm/isbinaryfile"},
+			wantApplied: map[string]bool{"pkg:npm/isbinaryfile": true, "pkg:npm/left-pad": false}},
+		{name: "no overrides", body: `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}]}`, wantCode: http.StatusBadRequest},
+		{name: "no components", body: `{"overrides": {"pkg:npm/isbinaryfile": "4.0.0"}}`, wantCode: http.StatusBadRequest},
+		{name: "other ecosystem", body: `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}],
+			"overrides": {"pkg:pypi/requests": "2.0.0"}}`, wantCode: http.StatusBadRequest},
+		{name: "invalid request body", body: `{"components": `, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, transitiveSimulatePath, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected HTTP code %v, got %v: %v", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var output dtos.UpgradeImpactOutput
+			if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+				t.Fatalf("an error '%s' was not expected when parsing the response", err)
+			}
+			changed := make([]string, 0, len(output.Changed))
+			for _, change := range output.Changed {
+				changed = append(changed, change.Purl)
+			}
+			if !reflect.DeepEqual(changed, tt.wantChanged) || len(output.Added) > 0 || len(output.Removed) > 0 {
+				t.Errorf("expected only %v to change, got %+v", tt.wantChanged, output.TransitiveDiffOutput)
+			}
+			applied := make(map[string]bool, len(output.Overrides))
+			for _, override := range output.Overrides {
+				applied[override.Purl] = override.Applied
+			}
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("expected applied overrides %v, got %+v", tt.wantApplied, output.Overrides)
+			}
+			if output.PolicyImpact.IntroducedViolations == nil || output.PolicyImpact.ResolvedViolations == nil {
+				t.Errorf("expected a policy impact, got %+v", output.PolicyImpact)
+			}
+			if output.KBSnapshot != "2026.10.01" || output.Status.Status != common.StatusCode_SUCCESS.String() {
+				t.Errorf("expected a simulation for KB snapshot 2026.10.01, got %+v", output)
+			}
+		})
+	}
+}
+
+func TestDependencyHTTPServer_ExportTransitiveDependencies(t *testing.T) {
+	mux, cleanup := setupHTTPServer(t)
+	defer cleanup()
+	body := `{"components": [{"purl": "pkg:npm/scanoss", "requirement": "0.15.7"}], "depth": 1}`
+	tests := []struct {
+		name            string
+		format          string
+		body            string
+		wantCode        int
+		wantContentType string
+		want            []string
+	}{
+		{name: "default json", body: body, wantCode: http.StatusOK, wantContentType: "application/json",
+			want: []string{`"kb_snapshot": "2026.10.01"`, `"purl": "pkg:npm/scanoss"`, `"depth": 0`}},
+		{name: "dot", format: "dot", body: body, wantCode: http.StatusOK, wantContentType: "text/vnd.graphviz; charset=utf-8",
+			want: []string{"digraph dependencies {", `kb_snapshot="2026.10.01"`, `version="4.0.8", depth=1, license="MIT"`}},
+		{name: "graphml", format: "GraphML", body: body, wantCode: http.StatusOK, wantContentType: "application/graphml+xml; charset=utf-8",
+			want: []string{`<data key="kb_snapshot">2026.10.01</data>`, `<data key="purl">pkg:npm/isbinaryfile</data>`}},
+		{name: "mermaid", format: "mermaid", body: body, wantCode: http.StatusOK, wantContentType: "text/plain; charset=utf-8",
+			want: []string{"%% KB snapshot: 2026.10.01", "flowchart LR", "pkg:npm/isbinaryfile@4.0.8<br/>depth 1<br/>MIT"}},
+		{name: "unsupported format", format: "svg", body: body, wantCode: http.StatusBadRequest},
+		{name: "invalid request body", body: `{"components": `, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := transitiveExportPath
+			if len(tt.format) > 0 {
+				path += "?format=" + tt.format
+			}
+			export := func() *httptest.ResponseRecorder {
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(tt.body)))
+				return rec
+			}
+			rec := export()
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected HTTP code %v, got %v: %v", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != tt.wantContentType {
+				t.Errorf("expected content type %v, got %v", tt.wantContentType, contentType)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(rec.Body.String(), want) {
+					t.Errorf("expected %q in the export:\n%v", want, rec.Body.String())
+				}
+			}
+			if again := export(); again.Body.String() != rec.Body.String() {
+				t.Errorf("expected identical exports of the same graph")
+			}
+		})
+	}
+}
@@ -39,12 +39,13 @@ type dependencyServer struct {
 	pb.DependenciesServer
 	db     *sqlx.DB
 	config *myconfig.ServerConfig
+	pool   *usecase.DecorationPool
 }
 
-// NewDependencyServer creates a new instance of Dependency Server.
-func NewDependencyServer(db *sqlx.DB, config *myconfig.ServerConfig) pb.DependenciesServer {
+// NewDependencyServer creates a new instance of Dependency Server, decorating components on the given pool.
+func NewDependencyServer(db *sqlx.DB, config *myconfig.ServerConfig, pool *usecase.DecorationPool) pb.DependenciesServer {
 	setupMetrics()
-	return &dependencyServer{db: db, config: config}
+	return &dependencyServer{db: db, config: config, pool: pool}
 }
 
 // Echo sends back the same message received.
@@ -72,8 +73,11 @@ func (d dependencyServer) GetDependencies(ctx context.Context, request *pb.Depen
 		return &pb.DependencyResponse{Status: &statusResp}, errors.NewBadRequestError("problem parsing dependency input data", err)
 	}
 	telemetryReqCounters(ctx, d.config, depRequest) // Update request counters
+	if _, err = grpcKBSnapshot(ctx, s, d.db); err != nil {
+		return &pb.DependencyResponse{Status: errors.HandleServiceError(ctx, s, err)}, nil
+	}
 	// Search the KB for information about each dependency
-	depUc := usecase.NewDependencies(ctx, s, d.db, d.config)
+	depUc := usecase.NewDependencies(ctx, s, d.db, d.config, d.pool)
 	dtoDependencies, warn, err := depUc.GetDependencies(dtoRequest)
 	statusResp := common.StatusResponse{Status: common.StatusCode_SUCCESS, Message: "Success"} // Assume success :-)
 	if err != nil {
@@ -102,6 +106,11 @@ func (d dependencyServer) GetTransitiveDependencies(ctx context.Context, request
 			Status: errors.HandleServiceError(ctx, s, err),
 		}, nil
 	}
+	if _, err = grpcKBSnapshot(ctx, s, d.db); err != nil {
+		return &pb.TransitiveDependencyResponse{
+			Status: errors.HandleServiceError(ctx, s, err),
+		}, nil
+	}
 	transitiveDependenciesUc := usecase.NewTransitiveDependencies(ctx, s, d.db, d.config)
 	s.Infof("Processing transitive dependency request...%v", transitiveDependencyDTO)
 	transitiveDependencies, err := transitiveDependenciesUc.GetTransitiveDependencies(s, transitiveDependencyDTO)
@@ -116,7 +125,13 @@ func (d dependencyServer) GetTransitiveDependencies(ctx context.Context, request
 		}, nil
 	}
 
-	output := convertToTransitiveDependencyOutput(transitiveDependencies)
+	// Unresolved requirements are only reported by the REST graph endpoint
+	if len(transitiveDependencies.Dependencies) == 0 {
+		return &pb.TransitiveDependencyResponse{
+			Status: errors.HandleServiceError(ctx, s, errors.NewNotFoundError("transitive dependencies for the given components")),
+		}, nil
+	}
+	output := convertToTransitiveDependencyOutput(transitiveDependencies.Dependencies)
 	trailerErr := grpc.SetTrailer(ctx, metadata.Pairs("x-http-code", fmt.Sprintf("%d", http.StatusOK)))
 	if trailerErr != nil {
 		s.Debugf("error setting x-http-code to trailer: %v", trailerErr)
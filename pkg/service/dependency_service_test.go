@@ -27,9 +27,11 @@ import (
 	common "github.com/scanoss/papi/api/commonv2"
 	pb "github.com/scanoss/papi/api/dependenciesv2"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/metadata"
 	_ "modernc.org/sqlite"
 	myconfig "scanoss.com/dependencies/pkg/config"
 	"scanoss.com/dependencies/pkg/models"
+	"scanoss.com/dependencies/pkg/usecase"
 )
 
 func TestDependencyServer_Echo(t *testing.T) {
@@ -49,7 +51,7 @@ func TestDependencyServer_Echo(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to load Config: %v", err)
 	}
-	s := NewDependencyServer(db, myConfig)
+	s := NewDependencyServer(db, myConfig, usecase.NewDecorationPool(myConfig.Components.MaxWorkers))
 
 	type args struct {
 		ctx context.Context
@@ -107,7 +109,7 @@ func TestDependencyServer_GetDependencies_Success(t *testing.T) {
 		t.Fatalf("failed to load Config: %v", err)
 	}
 	myConfig.Telemetry.Enabled = true
-	s := NewDependencyServer(db, myConfig)
+	s := NewDependencyServer(db, myConfig, usecase.NewDecorationPool(myConfig.Components.MaxWorkers))
 
 	var depRequestData = `{
   "depth": 1,
@@ -181,6 +183,17 @@ func TestDependencyServer_GetDependencies_Success(t *testing.T) {
 			want:    &pb.DependencyResponse{Status: &common.StatusResponse{Status: common.StatusCode_FAILED, Message: "Failed"}},
 			wantErr: true,
 		},
+		{
+			name: "Get Deps Minimum KB Snapshot Not Met",
+			s:    s,
+			args: args{
+				ctx: metadata.NewIncomingContext(ctx, metadata.Pairs(kbMinSnapshotKey, "2099.1")),
+				req: &depReq,
+			},
+
+			want: &pb.DependencyResponse{Status: &common.StatusResponse{Status: common.StatusCode_FAILED,
+				Message: "knowledge base snapshot '2026.10.01' is older than the required '2099.1'"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/dependenciesv2"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// The papi messages of a dependency stream are encoded with the same options the gateway uses for the other endpoints.
+var (
+	streamMarshalOptions   = protojson.MarshalOptions{EmitDefaultValues: true}
+	streamUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// dependencyStreamOutput is the decorated result of one chunk of a streamed dependency request.
+type dependencyStreamOutput struct {
+	Chunk  int               `json:"chunk"`           // Position of the request chunk (starting at 1)
+	Purls  int               `json:"purls"`           // Purls decorated so far (including this chunk)
+	Files  []json.RawMessage `json:"files,omitempty"` // Protobuf JSON encoded pb.DependencyResponse_Files
+	Status dtos.StatusOutput `json:"status"`
+}
+
+// StreamDependencies decorates a newline-delimited JSON stream of dependency requests (each a chunk of purls),
+// writing the decorated results of each chunk back as soon as it is processed. Only one chunk is held at a time.
+// A malformed chunk is reported as failed, and the rest of the stream is still processed.
+func (d DependencyHTTPServer) StreamDependencies(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing dependency stream request...")
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	// Results are written back while the remaining chunks are still being read
+	if err = http.NewResponseController(w).EnableFullDuplex(); err != nil {
+		s.Debugf("Full duplex streaming not available: %v", err)
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	send := func(output dependencyStreamOutput) bool {
+		if err := encoder.Encode(output); err != nil {
+			s.Warnf("Problem writing dependency stream chunk %v: %v", output.Chunk, err)
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return true
+	}
+	depUc := usecase.NewDependencies(ctx, s, d.db, d.config, d.pool)
+	reader := bufio.NewReader(r.Body)
+	chunks, purls := 0, 0
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !goerrors.Is(readErr, io.EOF) {
+			s.Errorf("Problem reading dependency stream after %v chunks: %v", chunks, readErr)
+			send(dependencyStreamOutput{Chunk: chunks + 1, Purls: purls, Status: dtos.StatusOutput{
+				Status: common.StatusCode_FAILED.String(), Message: "problem reading dependency request stream"}})
+			return
+		}
+		if ctx.Err() != nil {
+			s.Infof("Dependency stream cancelled after %v chunks: %v", chunks, ctx.Err())
+			return
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			chunks++
+			var request pb.DependencyRequest
+			output := dependencyStreamOutput{Status: dtos.StatusOutput{Status: common.StatusCode_FAILED.String(),
+				Message: fmt.Sprintf("problem parsing dependency request chunk %v", chunks)}}
+			if err = streamUnmarshalOptions.Unmarshal(line, &request); err != nil {
+				s.Errorf("Problem parsing dependency stream chunk %v: %v", chunks, err)
+			} else {
+				output = d.decorateDependencyChunk(ctx, s, depUc, &request)
+				purls += countRequestPurls(&request)
+			}
+			output.Chunk, output.Purls = chunks, purls
+			if !send(output) {
+				return
+			}
+		}
+		if readErr != nil { // End of the stream
+			break
+		}
+	}
+	s.Infof("Decorated %v purls from %v dependency stream chunks", purls, chunks)
+}
+
+// decorateDependencyChunk searches the KB for the details of the purls in a single chunk of a dependency stream.
+func (d DependencyHTTPServer) decorateDependencyChunk(ctx context.Context, s *zap.SugaredLogger, depUc *usecase.DependencyUseCase,
+	request *pb.DependencyRequest) dependencyStreamOutput {
+	failed := func(message string) dependencyStreamOutput {
+		return dependencyStreamOutput{Status: dtos.StatusOutput{Status: common.StatusCode_FAILED.String(), Message: message}}
+	}
+	count := countRequestPurls(request)
+	if count == 0 {
+		return failed("No dependency request data supplied")
+	}
+	if count > d.config.Components.StreamChunkMax {
+		return failed(fmt.Sprintf("Chunk of %v purls exceeds the maximum of %v", count, d.config.Components.StreamChunkMax))
+	}
+	dtoRequest, err := convertDependencyInput(s, request)
+	if err != nil {
+		return failed("Problem parsing dependency input data")
+	}
+	telemetryReqCounters(ctx, d.config, request.GetFiles())
+	dtoDependencies, warn, err := depUc.GetDependencies(dtoRequest)
+	status := dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	if err != nil {
+		if !warn {
+			s.Errorf("Failed to get dependencies: %v", err)
+			return failed("Problems encountered extracting dependency data")
+		}
+		status = dtos.StatusOutput{Status: common.StatusCode_SUCCEEDED_WITH_WARNINGS.String(), Message: "Problems decorating some purls"}
+	}
+	output := dependencyStreamOutput{Status: status}
+	for _, file := range convertDependencyOutput(dtoDependencies).Files {
+		data, err := streamMarshalOptions.Marshal(file)
+		if err != nil {
+			s.Errorf("Problem encoding dependency stream output: %v", err)
+			return failed("Problem encoding dependency output data")
+		}
+		output.Files = append(output.Files, data)
+	}
+	return output
+}
+
+// countRequestPurls returns the number of purls across all the files of a dependency request.
+func countRequestPurls(request *pb.DependencyRequest) int {
+	count := 0
+	for _, file := range request.GetFiles() {
+		count += len(file.GetPurls())
+	}
+	return count
+}
@@ -32,6 +32,7 @@ import (
 	"scanoss.com/dependencies/pkg/errors"
 	"scanoss.com/dependencies/pkg/shared"
 	trasitiveDependencies "scanoss.com/dependencies/pkg/transdep"
+	"scanoss.com/dependencies/pkg/usecase"
 )
 
 // Structure for storing OTEL metrics.
@@ -241,6 +242,32 @@ func convertToTransitiveDependencyDTO(
 	return transitiveDepDTO, nil
 }
 
+// transitiveDependencyRequest is the body of a REST transitive dependency request: a TransitiveDependencyRequest
+// with the pins (and exclusions) the gRPC request has no field for.
+type transitiveDependencyRequest struct {
+	Components []*pb.TransitiveDependencyRequest_Component `json:"components"`
+	Depth      int32                                       `json:"depth"`
+	Limit      int32                                       `json:"limit"`
+	Pins       []dtos.DependencyPinDTO                     `json:"pins"`
+}
+
+// convertRESTToTransitiveDependencyDTO validates and converts a REST transitive dependency request, including its pins.
+func convertRESTToTransitiveDependencyDTO(s *zap.SugaredLogger, config *config.ServerConfig,
+	request transitiveDependencyRequest) (dtos.TransitiveDependencyDTO, error) {
+	transitiveDepDTO, err := convertToTransitiveDependencyDTO(s, config,
+		&pb.TransitiveDependencyRequest{Components: request.Components, Depth: request.Depth, Limit: request.Limit})
+	if err != nil {
+		return dtos.TransitiveDependencyDTO{}, err
+	}
+	for _, pin := range request.Pins {
+		if _, err = trasitiveDependencies.NewPin(pin.Purl, pin.Version, pin.Exclude, transitiveDepDTO.Ecosystem); err != nil {
+			return dtos.TransitiveDependencyDTO{}, errors.NewBadRequestError(err.Error(), nil)
+		}
+	}
+	transitiveDepDTO.Pins = request.Pins
+	return transitiveDepDTO, nil
+}
+
 func convertToTransitiveDependencyOutput(dependencies []trasitiveDependencies.Dependency) *pb.TransitiveDependencyResponse {
 	var tdr pb.TransitiveDependencyResponse
 	for _, d := range dependencies {
@@ -252,3 +279,47 @@ func convertToTransitiveDependencyOutput(dependencies []trasitiveDependencies.De
 	}
 	return &tdr
 }
+
+// convertToTransitiveDependencyGraphOutput converts a transitive dependency result into the detailed REST output.
+func convertToTransitiveDependencyGraphOutput(result usecase.TransitiveDependencyResult) dtos.TransitiveDependencyOutput {
+	curated := make(map[trasitiveDependencies.Dependency]struct{}, len(result.Curated))
+	for _, d := range result.Curated {
+		curated[d] = struct{}{}
+	}
+	graph := result.Graph
+	if graph == nil {
+		graph = trasitiveDependencies.NewDepGraph()
+	}
+	return dtos.TransitiveDependencyOutput{
+		Dependencies:       convertToTransitiveComponentOutputs(result.Dependencies, curated, graph),
+		Resolved:           convertToTransitiveComponentOutputs(result.Resolved, curated, graph),
+		ResolutionStrategy: result.Strategy,
+		Unresolved:         convertToUnresolvedComponentOutputs(result.Unresolved),
+	}
+}
+
+// convertToUnresolvedComponentOutputs converts a list of unresolved graph dependencies into output components.
+func convertToUnresolvedComponentOutputs(unresolved []trasitiveDependencies.UnresolvedDependency) []dtos.UnresolvedComponentOutput {
+	components := make([]dtos.UnresolvedComponentOutput, 0, len(unresolved))
+	for _, u := range unresolved {
+		components = append(components, dtos.UnresolvedComponentOutput{
+			Purl:        u.Purl,
+			Requirement: u.Requirement,
+			Reason:      u.Reason,
+			Parent:      dtos.TransitiveComponentOutput{Purl: u.Parent.Purl, Version: u.Parent.Version},
+		})
+	}
+	return components
+}
+
+// convertToTransitiveComponentOutputs converts a list of graph dependencies into output components,
+// flagging the ones added by a curation and adding the requirement they were declared with.
+func convertToTransitiveComponentOutputs(dependencies []trasitiveDependencies.Dependency,
+	curated map[trasitiveDependencies.Dependency]struct{}, graph *trasitiveDependencies.DependencyGraph) []dtos.TransitiveComponentOutput {
+	components := make([]dtos.TransitiveComponentOutput, 0, len(dependencies))
+	for _, d := range dependencies {
+		_, isCurated := curated[d]
+		components = append(components, dtos.TransitiveComponentOutput{Purl: d.Purl, Version: d.Version, Requirement: graph.Requirement(d), Curated: isCurated})
+	}
+	return components
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+// Metadata keys (and REST-only endpoint headers) reporting the KB snapshot and requesting a minimum one.
+// Through the gateway, these are exchanged as Grpc-Metadata-X-Kb-Snapshot/Grpc-Metadata-X-Kb-Min-Snapshot headers.
+const (
+	kbSnapshotKey    = "x-kb-snapshot"
+	kbMinSnapshotKey = "x-kb-min-snapshot"
+)
+
+// checkKBSnapshot returns the snapshot marker of the knowledge base, verifying it is not older than the
+// minimum requested by the client (if any).
+func checkKBSnapshot(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, minimum string) (string, error) {
+	snapshot, err := models.NewKBMetadataModel(ctx, s, db).GetSnapshot()
+	if err != nil {
+		if len(minimum) > 0 {
+			return "", errors.NewServiceUnavailableError("knowledge base snapshot unavailable", err)
+		}
+		s.Warnf("Problem reading the knowledge base snapshot: %v", err)
+		return "", nil
+	}
+	if len(minimum) > 0 && (len(snapshot) == 0 || models.CompareSnapshots(snapshot, minimum) < 0) {
+		return snapshot, errors.NewPreconditionFailedError(
+			fmt.Sprintf("knowledge base snapshot '%s' is older than the required '%s'", snapshot, minimum), nil)
+	}
+	return snapshot, nil
+}
+
+// grpcKBSnapshot checks the KB snapshot against the minimum requested in the incoming metadata (if any),
+// and reports it in the response header metadata.
+func grpcKBSnapshot(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) (string, error) {
+	var minimum string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(kbMinSnapshotKey); len(values) > 0 {
+			minimum = strings.TrimSpace(values[0])
+		}
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, db, minimum)
+	if len(snapshot) > 0 {
+		if headerErr := grpc.SetHeader(ctx, metadata.Pairs(kbSnapshotKey, snapshot)); headerErr != nil {
+			s.Debugf("error setting %v to header: %v", kbSnapshotKey, headerErr)
+		}
+	}
+	return snapshot, err
+}
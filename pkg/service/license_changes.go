@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"net/http"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// GetLicenseChanges returns the versions of the requested component whose license changed from the previous version.
+// Supports the purl, constraint, from and to query parameters.
+func (d DependencyHTTPServer) GetLicenseChanges(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing license changes request...")
+	query := r.URL.Query()
+	request := dtos.LicenseChangesInput{
+		Purl:       strings.TrimSpace(query.Get("purl")),
+		Constraint: strings.TrimSpace(query.Get("constraint")),
+		From:       strings.TrimSpace(query.Get("from")),
+		To:         strings.TrimSpace(query.Get("to")),
+	}
+	if len(request.Purl) == 0 {
+		writeHTTPError(w, s, errors.NewBadRequestError("no purl supplied", nil))
+		return
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	depUc := usecase.NewDependencies(ctx, s, d.db, d.config, d.pool)
+	output, err := depUc.GetLicenseChanges(request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	output.KBSnapshot = snapshot
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	output.Status = dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	writeHTTPResponse(w, s, http.StatusOK, output)
+}
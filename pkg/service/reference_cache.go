@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"net/http"
+	"time"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+// InvalidateReferenceCache empties the reference table cache (reloading it if preloading is enabled),
+// so that changes to the licenses, versions and mines tables are picked up straight away.
+func (d DependencyHTTPServer) InvalidateReferenceCache(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	_, s := requestLogger(r)
+	s.Info("Processing reference cache invalidation request...")
+	cache := models.GetReferenceCache()
+	if cache == nil {
+		writeHTTPError(w, s, errors.NewNotFoundError("reference cache"))
+		return
+	}
+	if err := cache.Invalidate(); err != nil {
+		writeHTTPError(w, s, errors.NewInternalError("problem invalidating the reference cache", err))
+		return
+	}
+	licenses, versions, purlTypes, loaded := cache.Stats()
+	writeHTTPResponse(w, s, http.StatusOK, dtos.ReferenceCacheOutput{
+		Licenses:  licenses,
+		Versions:  versions,
+		PurlTypes: purlTypes,
+		LoadedAt:  loaded.UTC().Format(time.RFC3339),
+		Status:    dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Reference cache invalidated"},
+	})
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/dependenciesv2"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// transitiveDiffRequest is the body of a transitive dependency diff request. The component sets are given either as
+// "before" and "after" lists (one of them may be empty), or as two versions ("from" and "to") of a single package.
+type transitiveDiffRequest struct {
+	Before []*pb.TransitiveDependencyRequest_Component `json:"before"`
+	After  []*pb.TransitiveDependencyRequest_Component `json:"after"`
+	Purl   string                                      `json:"purl"`
+	From   string                                      `json:"from"`
+	To     string                                      `json:"to"`
+	Depth  int32                                       `json:"depth"`
+	Limit  int32                                       `json:"limit"`
+	Pins   []dtos.DependencyPinDTO                     `json:"pins"` // Applied to both sets
+}
+
+// DiffTransitiveDependencies collects the transitive dependencies of a "before" and an "after" set of components,
+// and returns the packages added, removed and resolved to other versions, with their licenses.
+func (d DependencyHTTPServer) DiffTransitiveDependencies(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing transitive dependency diff request...")
+	var request transitiveDiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem parsing transitive dependency diff request", err))
+		return
+	}
+	if len(request.Purl) > 0 {
+		if len(request.From) == 0 || len(request.To) == 0 || len(request.Before) > 0 || len(request.After) > 0 {
+			writeHTTPError(w, s, errors.NewBadRequestError("a purl diff requires 'from' and 'to' versions, and no component sets", nil))
+			return
+		}
+		request.Before = []*pb.TransitiveDependencyRequest_Component{{Purl: request.Purl, Requirement: request.From}}
+		request.After = []*pb.TransitiveDependencyRequest_Component{{Purl: request.Purl, Requirement: request.To}}
+	}
+	if len(request.Before) == 0 && len(request.After) == 0 {
+		writeHTTPError(w, s, errors.NewBadRequestError("'before' and/or 'after' components are required", nil))
+		return
+	}
+	var sets [2]dtos.TransitiveDependencyDTO
+	for i, components := range [][]*pb.TransitiveDependencyRequest_Component{request.Before, request.After} {
+		if len(components) == 0 {
+			continue
+		}
+		var err error
+		sets[i], err = convertRESTToTransitiveDependencyDTO(s, d.config, transitiveDependencyRequest{
+			Components: components, Depth: request.Depth, Limit: request.Limit, Pins: request.Pins})
+		if err != nil {
+			writeHTTPError(w, s, err)
+			return
+		}
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	transitiveDependenciesUc := usecase.NewTransitiveDependencies(ctx, s, d.db, d.config)
+	output, err := transitiveDependenciesUc.DiffTransitiveDependencies(s, sets[0], sets[1])
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	output.KBSnapshot = snapshot
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	output.Status = dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	writeHTTPResponse(w, s, http.StatusOK, output)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"scanoss.com/dependencies/pkg/errors"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// graphExportContentTypes maps each graph export format to its response content type.
+var graphExportContentTypes = map[string]string{
+	transitiveDep.ExportFormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	transitiveDep.ExportFormatGraphML: "application/graphml+xml; charset=utf-8",
+	transitiveDep.ExportFormatMermaid: "text/plain; charset=utf-8",
+	transitiveDep.ExportFormatJSON:    "application/json",
+}
+
+// ExportTransitiveDependencies collects the transitive dependency graph of a set of components, and returns it in
+// the export format selected with the "format" query parameter (json by default).
+func (d DependencyHTTPServer) ExportTransitiveDependencies(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing transitive dependency export request...")
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if len(format) == 0 {
+		format = transitiveDep.ExportFormatJSON
+	}
+	if !slices.Contains(transitiveDep.ExportFormats, format) {
+		writeHTTPError(w, s, errors.NewBadRequestError(fmt.Sprintf("unsupported export format %v, expected one of: %v",
+			format, strings.Join(transitiveDep.ExportFormats, ", ")), nil))
+		return
+	}
+	var request transitiveDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem parsing transitive dependency request", err))
+		return
+	}
+	transitiveDependencyDTO, err := convertRESTToTransitiveDependencyDTO(s, d.config, request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	transitiveDependenciesUc := usecase.NewTransitiveDependencies(ctx, s, d.db, d.config)
+	export, err := transitiveDependenciesUc.ExportTransitiveDependencies(s, transitiveDependencyDTO)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	export.KBSnapshot = snapshot
+	var output bytes.Buffer
+	if err = export.Write(&output, format); err != nil {
+		writeHTTPError(w, s, errors.NewInternalError("problem exporting the transitive dependency graph", err))
+		return
+	}
+	w.Header().Set("Content-Type", graphExportContentTypes[format])
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(output.Bytes()); err != nil {
+		s.Warnf("Problem writing transitive dependency export: %v", err)
+	}
+}
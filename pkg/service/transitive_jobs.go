@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/jobs"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// REST-only asynchronous transitive dependency job endpoints.
+const (
+	transitiveJobsPath      = "/v2/dependencies/transitive/jobs"
+	transitiveJobPath       = transitiveJobsPath + "/{job_id}"
+	transitiveJobResultPath = transitiveJobPath + "/result"
+)
+
+// NewTransitiveJobManager creates the manager running asynchronous transitive dependency jobs on a background worker pool.
+// Its workers stop once the given context is done.
+func NewTransitiveJobManager(ctx context.Context, db *sqlx.DB, config *myconfig.ServerConfig) (*jobs.Manager, error) {
+	var store jobs.Store
+	switch strings.ToLower(config.TransitiveJobs.Store) {
+	case "", "memory":
+		store = jobs.NewMemoryStore()
+	case "sql":
+		store = jobs.NewSQLStore(ctx, db)
+	default:
+		return nil, fmt.Errorf("unsupported transitive job store: %v (expected memory or sql)", config.TransitiveJobs.Store)
+	}
+	options := jobs.Options{
+		Workers:   config.TransitiveJobs.Workers,
+		QueueSize: config.TransitiveJobs.QueueSize,
+		Retention: time.Duration(config.TransitiveJobs.Retention) * time.Minute,
+		Instance:  config.TransitiveJobs.Instance,
+		Lease:     time.Duration(config.TransitiveJobs.Lease) * time.Second,
+	}
+	return jobs.NewManager(ctx, zlog.S, store, transitiveJobRunner(db, config), options)
+}
+
+// transitiveJobRunner resolves the transitive dependency request of a job, returning the graph output as its result.
+// Jobs use their own (longer) collection timeout.
+func transitiveJobRunner(db *sqlx.DB, config *myconfig.ServerConfig) jobs.Runner {
+	jobConfig := *config
+	jobConfig.TransitiveResources.TimeOut = config.TransitiveJobs.TimeOut
+	return func(ctx context.Context, job jobs.Job, progress func(jobs.Progress)) ([]byte, error) {
+		s := zlog.S.With("job_id", job.ID)
+		var request transitiveDependencyRequest
+		if err := json.Unmarshal(job.Request, &request); err != nil {
+			return nil, fmt.Errorf("invalid job request: %v", err)
+		}
+		transitiveDependencyDTO, err := convertRESTToTransitiveDependencyDTO(s, &jobConfig, request)
+		if err != nil {
+			return nil, err
+		}
+		snapshot, _ := checkKBSnapshot(ctx, s, db, "")
+		transitiveDependenciesUc := usecase.NewTransitiveDependencies(ctx, s, db, &jobConfig)
+		transitiveDependenciesUc.ProgressHandler = func(p transitiveDep.CollectorProgress) {
+			progress(jobs.Progress{NodesExplored: p.NodesExplored, QueueDepth: p.QueueDepth})
+		}
+		result, err := transitiveDependenciesUc.GetTransitiveDependencies(s, transitiveDependencyDTO)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			if serviceErr, ok := errors.GetServiceError(err); ok {
+				return nil, goerrors.New(serviceErr.Message)
+			}
+			return nil, err
+		}
+		output := convertToTransitiveDependencyGraphOutput(result)
+		output.KBSnapshot = snapshot
+		output.Status = dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+		return json.Marshal(output)
+	}
+}
+
+// registerJobRoutes adds the asynchronous transitive dependency job endpoints to the supplied gateway mux.
+func (d DependencyHTTPServer) registerJobRoutes(mux *runtime.ServeMux) error {
+	routes := []struct {
+		method, path string
+		handler      runtime.HandlerFunc
+	}{
+		{http.MethodPost, transitiveJobsPath, d.SubmitTransitiveJob},
+		{http.MethodGet, transitiveJobPath, d.GetTransitiveJob},
+		{http.MethodDelete, transitiveJobPath, d.CancelTransitiveJob},
+		{http.MethodGet, transitiveJobResultPath, d.GetTransitiveJobResult},
+	}
+	for _, route := range routes {
+		if err := mux.HandlePath(route.method, route.path, route.handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubmitTransitiveJob validates a transitive dependency request and queues it as an asynchronous job.
+func (d DependencyHTTPServer) SubmitTransitiveJob(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing transitive dependency job submission...")
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem reading transitive dependency request", err))
+		return
+	}
+	var request transitiveDependencyRequest
+	if err = json.Unmarshal(body, &request); err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem parsing transitive dependency request", err))
+		return
+	}
+	if _, err = convertRESTToTransitiveDependencyDTO(s, d.config, request); err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	if _, err = checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey))); err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	job, err := d.jobs.Submit(body)
+	if err != nil {
+		writeHTTPError(w, s, jobServiceError(err))
+		return
+	}
+	s.Infof("Queued transitive dependency job %v", job.ID)
+	writeHTTPResponse(w, s, http.StatusAccepted, convertToTransitiveJobOutput(job))
+}
+
+// GetTransitiveJob reports the state and progress of an asynchronous transitive dependency job.
+func (d DependencyHTTPServer) GetTransitiveJob(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, s := requestLogger(r)
+	job, err := d.jobs.Get(params["job_id"])
+	if err != nil {
+		writeHTTPError(w, s, jobServiceError(err))
+		return
+	}
+	writeHTTPResponse(w, s, http.StatusOK, convertToTransitiveJobOutput(job))
+}
+
+// CancelTransitiveJob cancels a queued or running asynchronous transitive dependency job.
+func (d DependencyHTTPServer) CancelTransitiveJob(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, s := requestLogger(r)
+	job, err := d.jobs.Cancel(params["job_id"])
+	if err != nil {
+		writeHTTPError(w, s, jobServiceError(err))
+		return
+	}
+	if job.State.Finished() {
+		s.Infof("Cancelled transitive dependency job %v", job.ID)
+	} else {
+		s.Infof("Requested the cancellation of transitive dependency job %v", job.ID)
+	}
+	writeHTTPResponse(w, s, http.StatusAccepted, convertToTransitiveJobOutput(job))
+}
+
+// GetTransitiveJobResult returns the transitive dependency graph of a completed job.
+func (d DependencyHTTPServer) GetTransitiveJobResult(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, s := requestLogger(r)
+	job, err := d.jobs.Get(params["job_id"])
+	if err != nil {
+		writeHTTPError(w, s, jobServiceError(err))
+		return
+	}
+	switch job.State {
+	case jobs.StateCompleted:
+		writeHTTPResponse(w, s, http.StatusOK, json.RawMessage(job.Result))
+	case jobs.StateFailed:
+		writeHTTPError(w, s, errors.NewConflictError(fmt.Sprintf("job %s failed: %s", job.ID, job.Error), nil))
+	default:
+		writeHTTPError(w, s, errors.NewConflictError(fmt.Sprintf("job %s is %s", job.ID, job.State), nil))
+	}
+}
+
+// jobServiceError converts a job manager error into the matching service error.
+func jobServiceError(err error) error {
+	switch {
+	case goerrors.Is(err, jobs.ErrJobNotFound):
+		return errors.NewNotFoundError("job")
+	case goerrors.Is(err, jobs.ErrQueueFull):
+		return errors.NewServiceUnavailableError("transitive job queue is full, please retry later", err)
+	case goerrors.Is(err, jobs.ErrJobFinished):
+		return errors.NewConflictError("job has already finished", err)
+	}
+	return errors.NewInternalError("problem accessing the transitive job", err)
+}
+
+// convertToTransitiveJobOutput converts a job into its REST representation.
+func convertToTransitiveJobOutput(job jobs.Job) dtos.TransitiveJobOutput {
+	return dtos.TransitiveJobOutput{
+		JobID:           job.ID,
+		State:           string(job.State),
+		Progress:        dtos.TransitiveProgressOutput{NodesExplored: job.Progress.NodesExplored, QueueDepth: job.Progress.QueueDepth},
+		Error:           job.Error,
+		CancelRequested: job.CancelRequested,
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+		Status:          dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"},
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// upgradeSimulationRequest is the body of an upgrade impact simulation request: a transitive dependency request,
+// the packages to pin (purl -> version or constraint), and the licenses denied by policy.
+type upgradeSimulationRequest struct {
+	transitiveDependencyRequest
+	Overrides map[string]string `json:"overrides"`
+	Policy    struct {
+		DenyLicenses []string `json:"deny_licenses"`
+	} `json:"policy"`
+}
+
+// SimulateUpgrade collects the transitive dependencies of a set of components with and without the requested
+// overrides, and returns the resulting graph delta and policy impact.
+func (d DependencyHTTPServer) SimulateUpgrade(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing upgrade impact simulation request...")
+	var request upgradeSimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem parsing upgrade simulation request", err))
+		return
+	}
+	if len(request.Components) == 0 {
+		writeHTTPError(w, s, errors.NewBadRequestError("'components' are required", nil))
+		return
+	}
+	if len(request.Overrides) == 0 {
+		writeHTTPError(w, s, errors.NewBadRequestError("'overrides' are required", nil))
+		return
+	}
+	dto, err := convertRESTToTransitiveDependencyDTO(s, d.config, request.transitiveDependencyRequest)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	transitiveDependenciesUc := usecase.NewTransitiveDependencies(ctx, s, d.db, d.config)
+	output, err := transitiveDependenciesUc.SimulateUpgrade(s, dto, request.Overrides, request.Policy.DenyLicenses)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	output.KBSnapshot = snapshot
+	if len(snapshot) > 0 {
+		w.Header().Set(kbSnapshotKey, snapshot)
+	}
+	output.Status = dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	writeHTTPResponse(w, s, http.StatusOK, output)
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+	"scanoss.com/dependencies/pkg/usecase"
+)
+
+// Message type of the final message of a transitive dependency stream.
+const transitiveStreamSummary = "summary"
+
+// StreamTransitiveDependencies streams the nodes, edges and unresolved requirements of a transitive dependency
+// collection as newline-delimited JSON while they are discovered, ending with a summary message.
+// The usual depth, limit and timeout apply, and the collection stops if the client disconnects.
+func (d DependencyHTTPServer) StreamTransitiveDependencies(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ctx, s := requestLogger(r)
+	s.Info("Processing transitive dependency stream request...")
+	var request transitiveDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeHTTPError(w, s, errors.NewBadRequestError("problem parsing transitive dependency request", err))
+		return
+	}
+	transitiveDependencyDTO, err := convertRESTToTransitiveDependencyDTO(s, d.config, request)
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	snapshot, err := checkKBSnapshot(ctx, s, d.db, strings.TrimSpace(r.Header.Get(kbMinSnapshotKey)))
+	if err != nil {
+		writeHTTPError(w, s, err)
+		return
+	}
+	// The response starts with the first message, so errors found before any are still returned with their HTTP code
+	streaming := false
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	send := func(message dtos.TransitiveStreamOutput) {
+		if !streaming {
+			streaming = true
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			if len(snapshot) > 0 {
+				w.Header().Set(kbSnapshotKey, snapshot)
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+		if err := encoder.Encode(message); err != nil {
+			s.Debugf("Problem writing transitive dependency stream message: %v", err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	transitiveDependenciesUc := usecase.NewTransitiveDependencies(ctx, s, d.db, d.config)
+	result, err := transitiveDependenciesUc.StreamTransitiveDependencies(s, transitiveDependencyDTO,
+		func(event transitiveDep.GraphEvent) {
+			send(convertToTransitiveStreamOutput(event))
+		})
+	if ctx.Err() != nil {
+		s.Infof("Transitive dependency stream cancelled: %v", ctx.Err())
+		return
+	}
+	if err != nil && !streaming {
+		writeHTTPError(w, s, err)
+		return
+	}
+	summary := convertToTransitiveSummaryOutput(result)
+	summary.KBSnapshot = snapshot
+	summary.Status = dtos.StatusOutput{Status: common.StatusCode_SUCCESS.String(), Message: "Success"}
+	if err != nil {
+		s.Errorf("Transitive dependency stream failed: %v", err)
+		serviceErr, ok := errors.GetServiceError(err)
+		if !ok {
+			serviceErr = errors.NewInternalError("internal server error", err)
+		}
+		summary.Status = dtos.StatusOutput{Status: common.StatusCode_FAILED.String(), Message: serviceErr.Message}
+	}
+	send(dtos.TransitiveStreamOutput{Type: transitiveStreamSummary, Summary: &summary})
+}
+
+// convertToTransitiveStreamOutput converts a dependency graph event into a stream message.
+func convertToTransitiveStreamOutput(event transitiveDep.GraphEvent) dtos.TransitiveStreamOutput {
+	node := dtos.TransitiveComponentOutput{Purl: event.Dependency.Purl, Version: event.Dependency.Version,
+		Requirement: event.Requirement, Curated: event.Curated}
+	message := dtos.TransitiveStreamOutput{Type: event.Type}
+	switch event.Type {
+	case transitiveDep.GraphEventNode:
+		message.Node = &node
+	case transitiveDep.GraphEventEdge:
+		message.Edge = &dtos.TransitiveEdgeOutput{
+			From: dtos.TransitiveComponentOutput{Purl: event.Parent.Purl, Version: event.Parent.Version},
+			To:   node,
+		}
+	case transitiveDep.GraphEventUnresolved:
+		unresolved := convertToUnresolvedComponentOutputs([]transitiveDep.UnresolvedDependency{event.Unresolved})
+		message.Unresolved = &unresolved[0]
+	}
+	return message
+}
+
+// convertToTransitiveSummaryOutput converts the outcome of a streamed collection into its summary message.
+func convertToTransitiveSummaryOutput(result usecase.TransitiveDependencyResult) dtos.TransitiveSummaryOutput {
+	output := convertToTransitiveDependencyGraphOutput(result)
+	return dtos.TransitiveSummaryOutput{
+		Completion:         result.Completion,
+		Dependencies:       len(result.Dependencies),
+		Unresolved:         len(result.Unresolved),
+		Resolved:           output.Resolved,
+		ResolutionStrategy: result.Strategy,
+	}
+}
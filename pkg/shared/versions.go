@@ -0,0 +1,434 @@
+package shared
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// mavenQualifierOrder ranks the well-known Maven qualifiers. Unknown qualifiers sort after these,
+// alphabetically, as described in the Maven ComparableVersion specification.
+var mavenQualifierOrder = map[string]int{
+	"alpha":     1,
+	"a":         1,
+	"beta":      2,
+	"b":         2,
+	"milestone": 3,
+	"m":         3,
+	"rc":        4,
+	"cr":        4,
+	"snapshot":  5,
+	"":          6,
+	"ga":        6,
+	"final":     6,
+	"release":   6,
+	"sp":        7,
+}
+
+// CompareVersions compares two versions following the ordering rules of the given ecosystem.
+// Returns -1 if a < b, 0 if a == b and 1 if a > b.
+func CompareVersions(ecosystem, a, b string) int {
+	switch ecosystem {
+	case "maven":
+		return compareMavenVersions(a, b)
+	case "gem":
+		return compareGemVersions(a, b)
+	}
+	return compareSemanticVersions(a, b)
+}
+
+// compareSemanticVersions compares two semver-like versions (npm, crates, composer).
+// Build metadata is ignored and a version with a pre-release tag sorts before its release.
+func compareSemanticVersions(a, b string) int {
+	coreA, preA := SplitPreRelease(a)
+	coreB, preB := SplitPreRelease(b)
+	if c := compareSegments(strings.Split(coreA, "."), strings.Split(coreB, ".")); c != 0 {
+		return c
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return compareSegments(strings.Split(preA, "."), strings.Split(preB, "."))
+}
+
+// SplitPreRelease separates the version core from its pre-release tag, dropping any build metadata.
+func SplitPreRelease(version string) (string, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+	if i := strings.Index(version, "-"); i >= 0 {
+		return version[:i], version[i+1:]
+	}
+	return version, ""
+}
+
+// compareSegments compares dot separated version segments. Numeric segments compare numerically
+// and sort before alphanumeric ones. Missing segments are treated as zero.
+func compareSegments(a, b []string) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		segA, segB := "0", "0"
+		if i < len(a) {
+			segA = a[i]
+		}
+		if i < len(b) {
+			segB = b[i]
+		}
+		if c := compareSegment(segA, segB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareSegment compares a single version segment.
+func compareSegment(a, b string) int {
+	numA, errA := strconv.Atoi(a)
+	numB, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(numA, numB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// compareMavenVersions compares two Maven versions. Numeric parts are compared first, followed by
+// the qualifier (i.e. 1.0-alpha < 1.0-rc1 < 1.0-SNAPSHOT < 1.0 < 1.0-sp1).
+func compareMavenVersions(a, b string) int {
+	numA, qualA := splitMavenVersion(a)
+	numB, qualB := splitMavenVersion(b)
+	if c := compareSegments(numA, numB); c != 0 {
+		return c
+	}
+	rankA, tailA := mavenQualifierRank(qualA)
+	rankB, tailB := mavenQualifierRank(qualB)
+	if rankA != rankB {
+		return compareInts(rankA, rankB)
+	}
+	return compareSegment(tailA, tailB)
+}
+
+// splitMavenVersion splits a Maven version into its numeric parts and a lowercase qualifier.
+func splitMavenVersion(version string) ([]string, string) {
+	version = strings.ToLower(strings.TrimSpace(version))
+	var numbers []string
+	for len(version) > 0 {
+		end := strings.IndexFunc(version, func(r rune) bool { return r < '0' || r > '9' })
+		if end == 0 {
+			break
+		}
+		if end < 0 {
+			numbers = append(numbers, version)
+			version = ""
+			break
+		}
+		numbers = append(numbers, version[:end])
+		version = version[end:]
+		if version[0] != '.' {
+			break
+		}
+		version = version[1:]
+	}
+	return numbers, strings.TrimLeft(version, ".-")
+}
+
+// mavenQualifierRank returns the rank of a qualifier and any trailing number (i.e. rc2 -> rc, 2).
+func mavenQualifierRank(qualifier string) (int, string) {
+	name := strings.TrimRight(qualifier, "0123456789.-")
+	tail := strings.TrimLeft(strings.TrimPrefix(qualifier, name), ".-")
+	if tail == "" {
+		tail = "0"
+	}
+	if rank, ok := mavenQualifierOrder[name]; ok {
+		return rank, tail
+	}
+	return len(mavenQualifierOrder) + 1, qualifier
+}
+
+// compareGemVersions compares two RubyGems versions. Any segment holding letters marks a pre-release,
+// and sorts before numeric segments (i.e. 1.0.0.rc1 < 1.0.0 < 1.0.0.1), as Gem::Version does.
+func compareGemVersions(a, b string) int {
+	segA, segB := gemSegments(a), gemSegments(b)
+	for i := 0; i < max(len(segA), len(segB)); i++ {
+		x, y := "0", "0"
+		if i < len(segA) {
+			x = segA[i]
+		}
+		if i < len(segB) {
+			y = segB[i]
+		}
+		numX, errX := strconv.Atoi(x)
+		numY, errY := strconv.Atoi(y)
+		var c int
+		switch {
+		case errX == nil && errY == nil:
+			c = compareInts(numX, numY)
+		case errX == nil:
+			c = 1
+		case errY == nil:
+			c = -1
+		default:
+			c = strings.Compare(x, y)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// gemSegments splits a RubyGems version on dots and between digits and letters (i.e. 1.0.0.rc1 -> 1, 0, 0, rc, 1).
+// A hyphen stands for a ".pre." segment, as in Gem::Version.
+func gemSegments(version string) []string {
+	version = strings.ReplaceAll(strings.TrimSpace(version), "-", ".pre.")
+	var segments []string
+	for _, part := range strings.Split(version, ".") {
+		for len(part) > 0 {
+			digits := part[0] >= '0' && part[0] <= '9'
+			end := strings.IndexFunc(part, func(r rune) bool { return (r >= '0' && r <= '9') != digits })
+			if end < 0 {
+				end = len(part)
+			}
+			segments = append(segments, part[:end])
+			part = part[end:]
+		}
+	}
+	return segments
+}
+
+// compareInts compares two integers returning -1, 0 or 1.
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// rangeOperators are the characters making up the operator of a range comparator.
+const rangeOperators = "<>=!^~"
+
+// wildcardEcosystems accept x-range comparators, with "x", "X" or "*" standing for any number (i.e. 1.x, 1.2.* or ^5.x).
+var wildcardEcosystems = map[string]bool{"npm": true, "composer": true, "cargo": true, "crates": true}
+
+// hyphenEcosystems accept hyphen ranges (i.e. 1.0.0 - 2.0.0, inclusive).
+var hyphenEcosystems = map[string]bool{"npm": true, "composer": true}
+
+// VersionInRange reports whether the given version satisfies the given range, following the ordering rules of the ecosystem.
+// A range is a list of comparators (i.e. ">=1.0.0 <2.0.0" or ">=1.0.0, <2.0.0") and alternatives can be joined with "||".
+// Caret (^1.2.3), tilde (~1.2.3) and pessimistic (~> 1.2) comparators are also supported, and depending on the
+// ecosystem x-ranges (1.x, or the partial version 1.2 for npm) and hyphen ranges (1.0.0 - 2.0.0).
+// An empty range (or "*") matches any version, and a bare version only matches itself.
+func VersionInRange(ecosystem, version, versionRange string) bool {
+	for _, alternative := range strings.Split(versionRange, "||") {
+		if versionSatisfiesAll(ecosystem, version, alternative) {
+			return true
+		}
+	}
+	return false
+}
+
+// versionSatisfiesAll reports whether the given version satisfies every comparator of the given list.
+func versionSatisfiesAll(ecosystem, version, comparators string) bool {
+	fields := strings.FieldsFunc(comparators, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	for i := 0; i < len(fields); i++ {
+		if hyphenEcosystems[ecosystem] && i+2 < len(fields) && fields[i+1] == "-" {
+			// A partial upper bound covers all its versions (i.e. 1.0.0 - 2 is >=1.0.0 <3.0.0)
+			if !versionSatisfies(ecosystem, version, ">=", fields[i], false) ||
+				!versionSatisfies(ecosystem, version, "<=", fields[i+2], true) {
+				return false
+			}
+			i += 2
+			continue
+		}
+		comparator := fields[i]
+		if strings.Trim(comparator, rangeOperators) == "" && i+1 < len(fields) {
+			i++
+			comparator += fields[i] // The operator was separated from its version (i.e. ">= 1.0.0")
+		}
+		if comparator == "*" {
+			continue
+		}
+		operator := comparator[:len(comparator)-len(strings.TrimLeft(comparator, rangeOperators))]
+		target := strings.TrimPrefix(comparator, operator)
+		if !versionSatisfies(ecosystem, version, operator, target, ecosystem == "npm") {
+			return false
+		}
+	}
+	return true
+}
+
+// versionSatisfies reports whether the given version satisfies a single comparator.
+// Partial targets (i.e. 1.2) are treated as x-ranges if requested.
+func versionSatisfies(ecosystem, version, operator, target string, partial bool) bool {
+	if len(target) == 0 {
+		return false
+	}
+	if prefix, isXRange := xRange(ecosystem, target, partial); isXRange {
+		return xRangeSatisfies(ecosystem, version, operator, prefix)
+	}
+	c := CompareVersions(ecosystem, version, target)
+	switch operator {
+	case "", "=", "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case "^", "~", "~>":
+		upper, valid := upperBound(operator, target)
+		return valid && c >= 0 && CompareVersions(ecosystem, version, upper) < 0
+	}
+	return false
+}
+
+// xRange returns the numeric segments before the wildcard of an x-range target (i.e. 1.2.x -> 1, 2).
+// If requested, a partial version (i.e. 1.2) is an x-range too. The last value is false for any other target.
+func xRange(ecosystem, target string, partial bool) ([]string, bool) {
+	if !wildcardEcosystems[ecosystem] {
+		return nil, false
+	}
+	core, preRelease := SplitPreRelease(target)
+	if len(preRelease) > 0 {
+		return nil, false
+	}
+	segments := strings.Split(core, ".")
+	prefix := segments
+	for i, segment := range segments {
+		if segment == "x" || segment == "X" || segment == "*" {
+			prefix = segments[:i]
+			for _, rest := range segments[i+1:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return nil, false
+				}
+			}
+			break
+		}
+	}
+	for _, segment := range prefix {
+		if _, err := strconv.Atoi(segment); err != nil {
+			return nil, false
+		}
+	}
+	return prefix, len(prefix) < len(segments) || (partial && len(segments) < 3)
+}
+
+// xRangeSatisfies reports whether the given version satisfies a comparator on the x-range with the given prefix
+// (i.e. 1.x covers >=1.0.0 <2.0.0, so >1.x means >=2.0.0 and ^0.2.x means >=0.2.0 <0.3.0).
+func xRangeSatisfies(ecosystem, version, operator string, prefix []string) bool {
+	if len(prefix) == 0 { // Any version
+		return operator == "" || operator == "=" || operator == "==" || operator == ">=" || operator == "<="
+	}
+	lower := slices.Clone(prefix)
+	for len(lower) < 3 {
+		lower = append(lower, "0")
+	}
+	upper, valid := upperBound(operator, strings.Join(prefix, "."))
+	if operator != "^" && operator != "~" { // The last segment of the prefix is bumped
+		upper, valid = upperBound("~>", strings.Join(append(slices.Clone(prefix), "0"), "."))
+	}
+	if !valid {
+		return false
+	}
+	aboveLower := CompareVersions(ecosystem, version, strings.Join(lower, ".")) >= 0
+	belowUpper := CompareVersions(ecosystem, version, upper) < 0
+	switch operator {
+	case "", "=", "==", "^", "~":
+		return aboveLower && belowUpper
+	case "!=":
+		return !aboveLower || !belowUpper
+	case ">":
+		return !belowUpper
+	case ">=":
+		return aboveLower
+	case "<":
+		return !aboveLower
+	case "<=":
+		return belowUpper
+	}
+	return false
+}
+
+// upperBound returns the (exclusive) upper bound of a caret, tilde or pessimistic comparator target
+// (i.e. ^1.2.3 -> 2.0.0, ^0.2.3 -> 0.3.0, ~1.2.3 -> 1.3.0 and ~> 1.2 -> 2.0).
+func upperBound(operator, target string) (string, bool) {
+	core, _ := SplitPreRelease(target)
+	segments := strings.Split(core, ".")
+	bump := 0
+	switch operator {
+	case "^": // The first non-zero segment (or the last one)
+		for bump < len(segments)-1 && segments[bump] == "0" {
+			bump++
+		}
+	case "~": // The minor segment (or the major one if there is none)
+		bump = min(1, len(segments)-1)
+	case "~>": // The segment before the last one (or the only one)
+		bump = max(len(segments)-2, 0)
+	}
+	number, err := strconv.Atoi(segments[bump])
+	if err != nil {
+		return "", false
+	}
+	segments = append(segments[:bump], strconv.Itoa(number+1))
+	return strings.Join(segments, "."), true
+}
+
+// IsPreRelease reports whether the given version is a pre-release, following the rules of the ecosystem
+// (i.e. 1.0.0-rc.1 or, for Maven, 1.0-alpha1 and 1.0-SNAPSHOT, but not 31.1-jre).
+func IsPreRelease(ecosystem, version string) bool {
+	if ecosystem == "maven" {
+		_, qualifier := splitMavenVersion(version)
+		rank, _ := mavenQualifierRank(qualifier)
+		return rank < mavenQualifierOrder[""]
+	}
+	if ecosystem == "gem" { // Any letter marks a pre-release (i.e. 1.0.0.rc1)
+		return strings.IndexFunc(version, unicode.IsLetter) >= 0
+	}
+	_, preRelease := SplitPreRelease(version)
+	return len(preRelease) > 0
+}
+
+// VersionNumbers returns the major, minor and patch numbers of the given version (missing numbers are zero).
+// The last value is false if the version does not start with a number.
+func VersionNumbers(ecosystem, version string) (int, int, int, bool) {
+	var segments []string
+	if ecosystem == "maven" {
+		segments, _ = splitMavenVersion(version)
+	} else {
+		core, _ := SplitPreRelease(version)
+		segments = strings.Split(core, ".")
+	}
+	var numbers [3]int
+	for i := range numbers {
+		if i >= len(segments) {
+			break
+		}
+		number, err := strconv.Atoi(segments[i])
+		if err != nil {
+			if i == 0 {
+				return 0, 0, 0, false
+			}
+			break
+		}
+		numbers[i] = number
+	}
+	return numbers[0], numbers[1], numbers[2], true
+}
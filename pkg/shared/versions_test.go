@@ -0,0 +1,143 @@
+package shared
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		a         string
+		b         string
+		expected  int
+	}{
+		{ecosystem: "npm", a: "1.2.3", b: "1.2.3", expected: 0},
+		{ecosystem: "npm", a: "1.2.3", b: "1.10.0", expected: -1},
+		{ecosystem: "npm", a: "v2.0.0", b: "1.99.99", expected: 1},
+		{ecosystem: "npm", a: "1.0.0-alpha", b: "1.0.0", expected: -1},
+		{ecosystem: "npm", a: "1.0.0-alpha.2", b: "1.0.0-alpha.10", expected: -1},
+		{ecosystem: "npm", a: "1.0.0-beta", b: "1.0.0-alpha.1", expected: 1},
+		{ecosystem: "npm", a: "1.0.0+build.1", b: "1.0.0", expected: 0},
+		{ecosystem: "crates", a: "0.2", b: "0.2.0", expected: 0},
+		{ecosystem: "maven", a: "1.0-alpha1", b: "1.0-beta1", expected: -1},
+		{ecosystem: "maven", a: "1.0-rc2", b: "1.0-rc10", expected: -1},
+		{ecosystem: "maven", a: "1.0-SNAPSHOT", b: "1.0", expected: -1},
+		{ecosystem: "maven", a: "1.0", b: "1.0.0.Final", expected: 0},
+		{ecosystem: "maven", a: "1.0-sp1", b: "1.0", expected: 1},
+		{ecosystem: "maven", a: "2.0.1", b: "2.0.0-jre", expected: 1},
+		{ecosystem: "maven", a: "31.1-jre", b: "32.0-jre", expected: -1},
+		{ecosystem: "gem", a: "1.0.0.rc1", b: "1.0.0", expected: -1},
+		{ecosystem: "gem", a: "1.0.0.rc1", b: "1.0.0.rc2", expected: -1},
+		{ecosystem: "gem", a: "1.0.0.beta", b: "1.0.0.alpha", expected: 1},
+		{ecosystem: "gem", a: "1.0.0.rc1", b: "0.9.9", expected: 1},
+		{ecosystem: "gem", a: "1.0.0-rc1", b: "1.0.0.pre.rc1", expected: 0},
+		{ecosystem: "gem", a: "1.0", b: "1.0.0", expected: 0},
+		{ecosystem: "gem", a: "1.0.0.1", b: "1.0.0", expected: 1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.ecosystem, tt.a, tt.b); got != tt.expected {
+			t.Errorf("CompareVersions(%v, %v, %v) = %v, expected %v", tt.ecosystem, tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestVersionInRange(t *testing.T) {
+	tests := []struct {
+		ecosystem    string
+		version      string
+		versionRange string
+		expected     bool
+	}{
+		{ecosystem: "npm", version: "1.2.3", versionRange: "", expected: true},
+		{ecosystem: "npm", version: "1.2.3", versionRange: "*", expected: true},
+		{ecosystem: "npm", version: "1.2.3", versionRange: "1.2.3", expected: true},
+		{ecosystem: "npm", version: "v1.2.3", versionRange: "=1.2.3", expected: true},
+		{ecosystem: "npm", version: "1.2.4", versionRange: "1.2.3", expected: false},
+		{ecosystem: "npm", version: "1.5.0", versionRange: ">=1.0.0 <2.0.0", expected: true},
+		{ecosystem: "npm", version: "2.0.0", versionRange: ">=1.0.0, <2.0.0", expected: false},
+		{ecosystem: "npm", version: "1.0.0", versionRange: ">= 1.0.0 < 2.0.0", expected: true},
+		{ecosystem: "npm", version: "3.1.0", versionRange: "<2.0.0 || >=3.0.0", expected: true},
+		{ecosystem: "npm", version: "2.5.0", versionRange: "<2.0.0 || >=3.0.0", expected: false},
+		{ecosystem: "npm", version: "1.0.0", versionRange: "!=1.0.0", expected: false},
+		{ecosystem: "npm", version: "1.0.0", versionRange: ">=", expected: false},
+		{ecosystem: "maven", version: "1.0-SNAPSHOT", versionRange: "<1.0", expected: true},
+		{ecosystem: "maven", version: "1.0.0.Final", versionRange: "<=1.0", expected: true},
+		{ecosystem: "npm", version: "4.0.10", versionRange: "^4.0.8", expected: true},
+		{ecosystem: "npm", version: "5.0.0", versionRange: "^4.0.8", expected: false},
+		{ecosystem: "npm", version: "4.0.7", versionRange: "^4.0.8", expected: false},
+		{ecosystem: "npm", version: "0.2.9", versionRange: "^0.2.3", expected: true},
+		{ecosystem: "npm", version: "0.3.0", versionRange: "^0.2.3", expected: false},
+		{ecosystem: "npm", version: "1.2.9", versionRange: "~1.2.3", expected: true},
+		{ecosystem: "npm", version: "1.3.0", versionRange: "~1.2.3", expected: false},
+		{ecosystem: "gem", version: "2.9.1", versionRange: "~> 2.2", expected: true},
+		{ecosystem: "gem", version: "2.3.0", versionRange: "~> 2.2.0", expected: false},
+		{ecosystem: "npm", version: "1.0.0", versionRange: "^x", expected: false},
+		{ecosystem: "npm", version: "1.9.3", versionRange: "1.x", expected: true},
+		{ecosystem: "npm", version: "2.0.0", versionRange: "1.x", expected: false},
+		{ecosystem: "npm", version: "4.7.1", versionRange: "4.x.x", expected: true},
+		{ecosystem: "npm", version: "3.9.9", versionRange: "4.x.x", expected: false},
+		{ecosystem: "npm", version: "1.2.7", versionRange: "1.2", expected: true},
+		{ecosystem: "npm", version: "1.3.0", versionRange: "1.2", expected: false},
+		{ecosystem: "npm", version: "5.9.0", versionRange: "^5.x", expected: true},
+		{ecosystem: "npm", version: "6.0.0", versionRange: "^5.x", expected: false},
+		{ecosystem: "npm", version: "0.9.0", versionRange: "^0.x", expected: true},
+		{ecosystem: "npm", version: "0.2.5", versionRange: "^0.2.x", expected: true},
+		{ecosystem: "npm", version: "0.3.0", versionRange: "^0.2.x", expected: false},
+		{ecosystem: "npm", version: "1.2.9", versionRange: "~1.2.x", expected: true},
+		{ecosystem: "npm", version: "1.3.0", versionRange: "~1.2.x", expected: false},
+		{ecosystem: "npm", version: "1.9.0", versionRange: ">1.x", expected: false},
+		{ecosystem: "npm", version: "2.0.0", versionRange: ">1.x", expected: true},
+		{ecosystem: "npm", version: "1.9.0", versionRange: "<=1.x", expected: true},
+		{ecosystem: "npm", version: "0.9.0", versionRange: "<1.x", expected: true},
+		{ecosystem: "npm", version: "1.0.0", versionRange: "<1.x", expected: false},
+		{ecosystem: "npm", version: "1.0.0", versionRange: "<= 1.0", expected: true},
+		{ecosystem: "npm", version: "3.0.0", versionRange: "x", expected: true},
+		{ecosystem: "npm", version: "1.5.0", versionRange: "1.0.0 - 2.0.0", expected: true},
+		{ecosystem: "npm", version: "2.0.0", versionRange: "1.0.0 - 2.0.0", expected: true},
+		{ecosystem: "npm", version: "2.0.1", versionRange: "1.0.0 - 2.0.0", expected: false},
+		{ecosystem: "npm", version: "0.9.9", versionRange: "1.0.0 - 2.0.0", expected: false},
+		{ecosystem: "npm", version: "2.9.9", versionRange: "1.0 - 2", expected: true},
+		{ecosystem: "npm", version: "3.0.0", versionRange: "1.0 - 2", expected: false},
+		{ecosystem: "npm", version: "3.1.0", versionRange: "1.0.0 - 2.0.0 || ^3.0.0", expected: true},
+		{ecosystem: "composer", version: "1.0.9", versionRange: "1.0.*", expected: true},
+		{ecosystem: "composer", version: "1.1.0", versionRange: "1.0.*", expected: false},
+		{ecosystem: "composer", version: "2.0.5", versionRange: "1.0 - 2.0", expected: true},
+		{ecosystem: "composer", version: "2.1.0", versionRange: "1.0 - 2.0", expected: false},
+		{ecosystem: "crates", version: "1.4.0", versionRange: "1.*", expected: true},
+		{ecosystem: "maven", version: "1.5", versionRange: "1.x", expected: false},
+		{ecosystem: "gem", version: "1.0.0.rc1", versionRange: ">= 1.0.0", expected: false},
+		{ecosystem: "gem", version: "1.0.0.rc1", versionRange: "~> 0.9", expected: true},
+	}
+	for _, tt := range tests {
+		if got := VersionInRange(tt.ecosystem, tt.version, tt.versionRange); got != tt.expected {
+			t.Errorf("VersionInRange(%v, %v, %q) = %v, expected %v", tt.ecosystem, tt.version, tt.versionRange, got, tt.expected)
+		}
+	}
+}
+
+func TestVersionNumbers(t *testing.T) {
+	tests := []struct {
+		ecosystem           string
+		version             string
+		major, minor, patch int
+		ok                  bool
+		preRelease          bool
+	}{
+		{ecosystem: "npm", version: "4.0.10", major: 4, minor: 0, patch: 10, ok: true},
+		{ecosystem: "npm", version: "v2.1", major: 2, minor: 1, ok: true},
+		{ecosystem: "npm", version: "1.0.0-rc.1", major: 1, ok: true, preRelease: true},
+		{ecosystem: "maven", version: "31.1-jre", major: 31, minor: 1, ok: true},
+		{ecosystem: "maven", version: "1.0-SNAPSHOT", major: 1, ok: true, preRelease: true},
+		{ecosystem: "npm", version: "latest"},
+		{ecosystem: "gem", version: "1.0.0.rc1", major: 1, ok: true, preRelease: true},
+		{ecosystem: "gem", version: "2.1.0.beta", major: 2, minor: 1, ok: true, preRelease: true},
+		{ecosystem: "gem", version: "7.1.3", major: 7, minor: 1, patch: 3, ok: true},
+	}
+	for _, tt := range tests {
+		major, minor, patch, ok := VersionNumbers(tt.ecosystem, tt.version)
+		if major != tt.major || minor != tt.minor || patch != tt.patch || ok != tt.ok {
+			t.Errorf("VersionNumbers(%v, %v) = %v, %v, %v, %v", tt.ecosystem, tt.version, major, minor, patch, ok)
+		}
+		if got := IsPreRelease(tt.ecosystem, tt.version); got != tt.preRelease {
+			t.Errorf("IsPreRelease(%v, %v) = %v, expected %v", tt.ecosystem, tt.version, got, tt.preRelease)
+		}
+	}
+}
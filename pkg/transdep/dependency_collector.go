@@ -4,6 +4,7 @@ package transdep
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -17,13 +18,30 @@ type DependencyJob struct {
 	Requirement string
 	Depth       int
 	Ecosystem   string
+	Curated     bool // Added by a curation
+	Overridden  bool // Requirement replaced by an override or pin
 }
 
 type Result struct {
 	Parent                 DependencyJob
 	TransitiveDependencies []DependencyJob
+	Unresolved             []UnresolvedRequirement
 }
 
+// CollectorProgress reports how far a dependency collection has gone.
+type CollectorProgress struct {
+	NodesExplored int // Jobs whose dependencies have been collected
+	QueueDepth    int // Jobs still pending
+}
+
+// Outcomes of a dependency collection.
+const (
+	CollectionComplete  = "complete"      // Every job was processed
+	CollectionLimited   = "limit_reached" // Stopped by the result handler (i.e. the response limit was reached)
+	CollectionTimedOut  = "timed_out"     // Stopped by the collection timeout
+	CollectionCancelled = "cancelled"     // Stopped by the caller (i.e. a client disconnect)
+)
+
 type DependencyCollectorCfg struct {
 	MaxWorkers    int
 	MaxQueueLimit int
@@ -32,6 +50,7 @@ type DependencyCollectorCfg struct {
 
 type DependencyCollector struct {
 	ResultHandler   func(Result) bool
+	ProgressHandler func(CollectorProgress) // Optional. Called after each result is processed
 	Config          DependencyCollectorCfg
 	jobs            []DependencyJob
 	dependencyModel *models.DependencyModel
@@ -41,6 +60,11 @@ type DependencyCollector struct {
 	resultChannel   chan Result
 	jobChannel      chan DependencyJob
 	pendingJobs     int
+	exploredJobs    int
+	completion      string
+	resolvers       map[string]RequirementResolver
+	overrides       map[string]string
+	pins            []Pin
 	S               *zap.SugaredLogger
 }
 
@@ -60,15 +84,76 @@ func NewDependencyCollector(
 		resultChannel:   make(chan Result, config.MaxQueueLimit),
 		jobChannel:      make(chan DependencyJob, config.MaxQueueLimit),
 		pendingJobs:     0,
+		resolvers:       make(map[string]RequirementResolver),
 		S:               logger,
 	}
 }
 
+// RegisterRequirementResolver sets the resolver used to turn requirements into versions for the given ecosystem.
+// Ecosystems without a registered resolver pick the first version found in the requirement.
+func (dc *DependencyCollector) RegisterRequirementResolver(ecosystem string, resolver RequirementResolver) {
+	dc.resolvers[ecosystem] = resolver
+}
+
+// getRequirementResolver returns the requirement resolver registered for the given ecosystem.
+func (dc *DependencyCollector) getRequirementResolver(ecosystem string) RequirementResolver {
+	if resolver, ok := dc.resolvers[ecosystem]; ok {
+		return resolver
+	}
+	return rangeResolver{}
+}
+
+// SetOverrides pins the given packages (keyed by purl name) to a version or constraint wherever they appear
+// in the graph, replacing the requirements declared for them. Must be called before InitJobs.
+func (dc *DependencyCollector) SetOverrides(overrides map[string]string) {
+	dc.overrides = overrides
+}
+
+// ApplyOverrides returns a copy of the given jobs, with those for overridden packages (keyed by purl name)
+// pinned to the override. Override constraints are resolved to a version the same way as those of dependencies.
+func ApplyOverrides(jobs []DependencyJob, overrides map[string]string) ([]DependencyJob, error) {
+	pinned := make([]DependencyJob, 0, len(jobs))
+	for _, job := range jobs {
+		if override, found := overrides[job.PurlName]; found {
+			version, err := rangeResolver{}.ResolveVersion(job, models.UnresolvedDependency{Purl: job.PurlName, Requirement: override})
+			if err != nil {
+				return nil, fmt.Errorf("cannot resolve override %v of %v: %w", override, job.PurlName, err)
+			}
+			job.Version, job.Requirement, job.Overridden = version, override, true
+		}
+		pinned = append(pinned, job)
+	}
+	return pinned, nil
+}
+
+// SetPins pins (or excludes) the packages matching each pin wherever they appear below the entry dependencies.
+// The first matching pin applies, and overrides take precedence. Must be called before Start.
+func (dc *DependencyCollector) SetPins(pins []Pin) {
+	dc.pins = pins
+}
+
+// pinFor returns the override or first pin matching the given purl name, if any.
+func (dc *DependencyCollector) pinFor(purlName string) (Pin, bool) {
+	if override, found := dc.overrides[purlName]; found {
+		return Pin{Pattern: purlName, Version: override}, true
+	}
+	for _, pin := range dc.pins {
+		if pin.Matches(purlName) {
+			return pin, true
+		}
+	}
+	return Pin{}, false
+}
+
 func (dc *DependencyCollector) InitJobs(inputJobs []DependencyJob) error {
 	if len(inputJobs) == 0 {
 		return errors.New("empty jobs to initialize dependency collector")
 	}
-	dc.jobs = inputJobs
+	jobs, err := ApplyOverrides(inputJobs, dc.overrides)
+	if err != nil {
+		return err
+	}
+	dc.jobs = jobs
 	dc.pendingJobs = len(dc.jobs)
 	return nil
 }
@@ -103,6 +188,20 @@ func (dc *DependencyCollector) Start() {
 	dc.S.Info("All workers have exited. Processing completed.")
 }
 
+// Completion reports how the last collection ended (one of the Collection* outcomes).
+func (dc *DependencyCollector) Completion() string {
+	return dc.completion
+}
+
+// interrupted records why the collection stopped before processing every job.
+func (dc *DependencyCollector) interrupted() {
+	if dc.ctx.Err() != nil {
+		dc.completion = CollectionCancelled
+	} else {
+		dc.completion = CollectionTimedOut
+	}
+}
+
 // processResult monitors the result channel, processes dependency results
 // using ResultHandler, and manages the job queue. It tracks job completion
 // and signals when processing should terminate. Returns on context
@@ -114,6 +213,7 @@ func (dc *DependencyCollector) processResult(wg *sync.WaitGroup, ctx context.Con
 		case <-ctx.Done():
 			// Context was cancelled, stop processing results
 			dc.S.Debug("Results processor stopping due to context cancellation")
+			dc.interrupted()
 			return
 
 		case result := <-dc.resultChannel:
@@ -124,6 +224,7 @@ func (dc *DependencyCollector) processResult(wg *sync.WaitGroup, ctx context.Con
 			*/
 			if dc.ResultHandler(result) {
 				dc.S.Debug("Result handler signaled to stop processing")
+				dc.completion = CollectionLimited
 				cancel()
 				return
 			}
@@ -135,6 +236,7 @@ func (dc *DependencyCollector) processResult(wg *sync.WaitGroup, ctx context.Con
 						dc.pendingJobs++
 						// Job was added successfully
 					case <-ctx.Done():
+						dc.interrupted()
 						return
 					default:
 						dc.S.Debug("Skipping dependency due to max queue limit reached")
@@ -143,9 +245,14 @@ func (dc *DependencyCollector) processResult(wg *sync.WaitGroup, ctx context.Con
 			}
 			// Decrement counter after adding all new jobs
 			dc.pendingJobs--
+			dc.exploredJobs++
+			if dc.ProgressHandler != nil {
+				dc.ProgressHandler(CollectorProgress{NodesExplored: dc.exploredJobs, QueueDepth: dc.pendingJobs})
+			}
 			// Check if we're done with all jobs
 			if dc.pendingJobs == 0 {
 				dc.S.Debug("No more pending jobs, signaling completion")
+				dc.completion = CollectionComplete
 				cancel()
 				return
 			}
@@ -186,14 +293,30 @@ func (dc *DependencyCollector) worker(id int, jobs chan DependencyJob, wg *sync.
 			newJobDepth := job.Depth - 1
 
 			// sanitize versions
+			resolver := dc.getRequirementResolver(job.Ecosystem)
 			var transitiveDependenciesJobs []DependencyJob
+			var unresolved []UnresolvedRequirement
 			for _, ud := range transitiveDependencies {
-				fixedVersion, err := PickFirstVersionFromRange(ud.Requirement)
+				pin, overridden := dc.pinFor(ud.Purl)
+				if overridden && pin.Exclude {
+					dc.S.Debugf("Excluding dependency %s of %s\n", ud.Purl, job.PurlName)
+					continue
+				}
+				if overridden {
+					ud.Requirement = pin.Version
+				}
+				fixedVersion, err := resolver.ResolveVersion(job, ud)
 				if err != nil {
-					dc.S.Debugf("Cannot resolve requirement %s\n", ud.Requirement)
+					dc.S.Debugf("Cannot resolve requirement %s of %s: %v\n", ud.Requirement, ud.Purl, err)
+					unresolved = append(unresolved, UnresolvedRequirement{
+						PurlName: ud.Purl, Requirement: ud.Requirement, Ecosystem: job.Ecosystem, Reason: unresolvedReason(ud.Requirement, err),
+					})
 					continue
 				}
-				transitiveDependenciesJobs = append(transitiveDependenciesJobs, DependencyJob{PurlName: ud.Purl, Version: fixedVersion, Ecosystem: job.Ecosystem, Depth: newJobDepth})
+				transitiveDependenciesJobs = append(transitiveDependenciesJobs, DependencyJob{
+					PurlName: ud.Purl, Version: fixedVersion, Requirement: ud.Requirement, Ecosystem: job.Ecosystem, Depth: newJobDepth,
+					Curated: ud.Curated, Overridden: overridden,
+				})
 			}
 
 			// Send result, but also handle context cancellation
@@ -201,6 +324,7 @@ func (dc *DependencyCollector) worker(id int, jobs chan DependencyJob, wg *sync.
 			case results <- Result{
 				Parent:                 job,
 				TransitiveDependencies: transitiveDependenciesJobs,
+				Unresolved:             unresolved,
 			}:
 				dc.S.Debugf("Worker %d: Completed job %s at depth %d\n", id, job.PurlName, newJobDepth)
 			case <-ctx.Done():
@@ -2,6 +2,8 @@ package transdep
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -55,7 +57,7 @@ func setupTestDependencyCollector(t *testing.T) (*DependencyCollector, *Dependen
 		ctx,
 		ProcessCollectorResult(s, depGraph, 1),
 		dependencyCollectorCfg,
-		models.NewDependencyModel(ctx, s, db),
+		models.NewDependencyModel(ctx, s, db, nil, nil),
 		s)
 	// Return cleanup function
 	cleanup := func() {
@@ -315,6 +317,10 @@ func TestProcessResultCancellation(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("Processor did not exit in time after context cancellation")
 	}
+	// The collector's own context is still live, so the stop is reported as a timeout
+	if dc.Completion() != CollectionTimedOut {
+		t.Errorf("Expected completion %v, got %v", CollectionTimedOut, dc.Completion())
+	}
 }
 
 // TestProcessResultJobCompletion tests that the processResult method
@@ -352,6 +358,9 @@ func TestProcessResultJobCompletion(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("Processor did not exit in time after all jobs completed")
 	}
+	if dc.Completion() != CollectionComplete {
+		t.Errorf("Expected completion %v, got %v", CollectionComplete, dc.Completion())
+	}
 }
 
 // TestResultHandlerStopsProcessing tests that when the ResultHandler returns true,
@@ -398,4 +407,114 @@ func TestResultHandlerStopsProcessing(t *testing.T) {
 	if handlerCalls != 1 {
 		t.Errorf("Expected handler to be called once, got %d", handlerCalls)
 	}
+	if dc.Completion() != CollectionLimited {
+		t.Errorf("Expected completion %v, got %v", CollectionLimited, dc.Completion())
+	}
+}
+
+func TestDependencyCollector_Overrides(t *testing.T) {
+	transitiveDependencyCollector, _, cleanup := setupTestDependencyCollector(t)
+	defer cleanup()
+	transitiveDependencyCollector.SetOverrides(map[string]string{"scanoss": "^0.15.7", "isbinaryfile": "4.0.0", "uuid": "^8.0.0"})
+	err := transitiveDependencyCollector.InitJobs([]DependencyJob{{PurlName: "scanoss", Version: "0.15.6", Requirement: "0.15.6", Ecosystem: "npm", Depth: 1}})
+	if err != nil {
+		t.Fatalf("InitJobs() unexpected error = %v", err)
+	}
+	root := transitiveDependencyCollector.jobs[0]
+	if root.Version != "0.15.7" || root.Requirement != "^0.15.7" || !root.Overridden {
+		t.Errorf("InitJobs() expected the root job to be pinned to 0.15.7, got %+v", root)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobs := make(chan DependencyJob, 1)
+	results := make(chan Result, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go transitiveDependencyCollector.worker(1, jobs, &wg, results, ctx)
+	jobs <- root
+	result := <-results
+	cancel()
+	wg.Wait()
+	want := map[string]string{"isbinaryfile": "4.0.0", "uuid": "8.0.0"}
+	for _, job := range result.TransitiveDependencies {
+		if version, found := want[job.PurlName]; found {
+			if job.Version != version || !job.Overridden {
+				t.Errorf("worker() expected %v to be pinned to %v, got %+v", job.PurlName, version, job)
+			}
+			delete(want, job.PurlName)
+		} else if job.Overridden {
+			t.Errorf("worker() expected %v not to be overridden, got %+v", job.PurlName, job)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("worker() expected dependencies on %v", want)
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	entries := []DependencyJob{
+		{PurlName: "scanoss", Version: "0.15.6", Requirement: "0.15.6", Ecosystem: "npm", Depth: 1},
+		{PurlName: "uuid", Version: "8.3.2", Requirement: "8.3.2", Ecosystem: "npm", Depth: 1},
+	}
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		want      []DependencyJob
+		wantErr   bool
+	}{
+		{name: "exact version", overrides: map[string]string{"scanoss": "0.15.7"}, want: []DependencyJob{
+			{PurlName: "scanoss", Version: "0.15.7", Requirement: "0.15.7", Ecosystem: "npm", Depth: 1, Overridden: true}, entries[1]}},
+		{name: "constraint", overrides: map[string]string{"uuid": "^9.0.0"}, want: []DependencyJob{
+			entries[0], {PurlName: "uuid", Version: "9.0.0", Requirement: "^9.0.0", Ecosystem: "npm", Depth: 1, Overridden: true}}},
+		{name: "unresolvable constraint", overrides: map[string]string{"uuid": "latest"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyOverrides(entries, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyOverrides() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyCollector_Pins(t *testing.T) {
+	transitiveDependencyCollector, _, cleanup := setupTestDependencyCollector(t)
+	defer cleanup()
+	transitiveDependencyCollector.SetOverrides(map[string]string{"uuid": "8.0.0"})
+	transitiveDependencyCollector.SetPins([]Pin{
+		{Pattern: "%40types/*", Exclude: true},
+		{Pattern: "isbinaryfile", Version: "4.0.0"},
+		{Pattern: "uuid", Version: "9.0.0"},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jobs := make(chan DependencyJob, 1)
+	results := make(chan Result, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go transitiveDependencyCollector.worker(1, jobs, &wg, results, ctx)
+	jobs <- DependencyJob{PurlName: "scanoss", Version: "0.15.7", Requirement: "0.15.7", Ecosystem: "npm", Depth: 1}
+	result := <-results
+	cancel()
+	wg.Wait()
+	want := map[string]string{"isbinaryfile": "4.0.0", "uuid": "8.0.0"} // Overrides take precedence over pins
+	for _, job := range result.TransitiveDependencies {
+		if strings.HasPrefix(job.PurlName, "%40types/") {
+			t.Errorf("worker() expected %v to be excluded", job.PurlName)
+		}
+		if version, found := want[job.PurlName]; found {
+			if job.Version != version || !job.Overridden {
+				t.Errorf("worker() expected %v to be pinned to %v, got %+v", job.PurlName, version, job)
+			}
+			delete(want, job.PurlName)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("worker() expected dependencies on %v", want)
+	}
 }
@@ -2,6 +2,7 @@ package transdep
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -17,12 +18,27 @@ type DependencyGraph struct {
 	// The Dependency type works as a map key because it contains only comparable types.
 	// More info: https://go.dev/blog/maps#key-types
 	dependenciesOf map[Dependency][]Dependency
+	unresolved     map[UnresolvedDependency]struct{}
+	curated        map[Dependency]struct{}
+	requirements   map[Dependency]string
+}
+
+// UnresolvedDependency is a dependency declared by a node of the graph whose requirement could not be
+// resolved to a concrete version.
+type UnresolvedDependency struct {
+	Parent      Dependency
+	Purl        string
+	Requirement string
+	Reason      string
 }
 
 // NewDepGraph creates and initializes a new empty dependency graph.
 func NewDepGraph() *DependencyGraph {
 	return &DependencyGraph{
 		dependenciesOf: make(map[Dependency][]Dependency),
+		unresolved:     make(map[UnresolvedDependency]struct{}),
+		curated:        make(map[Dependency]struct{}),
+		requirements:   make(map[Dependency]string),
 	}
 }
 
@@ -60,33 +76,22 @@ func (dg *DependencyGraph) Connect(root Dependency, child Dependency) {
 }
 
 // String generates a string representation of the graph
-// The output shows all dependencies and their relationships
+// The output shows all dependencies and their relationships, ordered by purl and version
 // Each line follows the format: "<dependency> --> <child_dependency>"
 // Dependencies with no children show "<dependency> --> null".
+// See GraphExport for the supported export formats.
 func (dg *DependencyGraph) String() string {
 	var result strings.Builder
-	deps := make([]*Dependency, 0, len(dg.dependenciesOf))
-	for dep := range dg.dependenciesOf {
-		deps = append(deps, &dep)
-	}
-
-	sort.Slice(deps, func(i, j int) bool {
-		return deps[i].Purl < deps[j].Purl
-	})
-
-	for key, value := range dg.dependenciesOf {
-		children := value
-
+	for _, dep := range sortDependencies(dg.Flatten()) {
+		children := sortDependencies(slices.Clone(dg.dependenciesOf[dep]))
 		if len(children) == 0 {
-			_, _ = fmt.Fprintf(&result, "%s --> null\n", key.Purl)
+			_, _ = fmt.Fprintf(&result, "%s --> null\n", dep.Purl)
 			continue
 		}
-
 		for _, child := range children {
-			_, _ = fmt.Fprintf(&result, "%s --> %s\n", key.Purl, child.Purl)
+			_, _ = fmt.Fprintf(&result, "%s --> %s\n", dep.Purl, child.Purl)
 		}
 	}
-
 	return result.String()
 }
 
@@ -100,6 +105,91 @@ func (dg *DependencyGraph) Flatten() []Dependency {
 	return purls
 }
 
+// Children returns the direct dependencies of the given dependency, in insertion order.
+func (dg *DependencyGraph) Children(d Dependency) []Dependency {
+	return dg.dependenciesOf[d]
+}
+
+// WalkBreadthFirst visits every dependency reachable from the given roots in breadth-first order,
+// calling visit with the depth at which the dependency was first reached (roots have depth 0).
+// If visit returns false, the children of that dependency are not explored.
+func (dg *DependencyGraph) WalkBreadthFirst(roots []Dependency, visit func(d Dependency, depth int) bool) {
+	type queued struct {
+		dep   Dependency
+		depth int
+	}
+	visited := make(map[Dependency]struct{}, len(dg.dependenciesOf))
+	queue := make([]queued, 0, len(roots))
+	for _, root := range roots {
+		if _, seen := visited[root]; !seen && dg.isRegisteredDependency(root) {
+			visited[root] = struct{}{}
+			queue = append(queue, queued{dep: root})
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if !visit(current.dep, current.depth) {
+			continue
+		}
+		for _, child := range dg.dependenciesOf[current.dep] {
+			if _, seen := visited[child]; !seen {
+				visited[child] = struct{}{}
+				queue = append(queue, queued{dep: child, depth: current.depth + 1})
+			}
+		}
+	}
+}
+
+// AddUnresolved records a dependency whose requirement could not be resolved.
+func (dg *DependencyGraph) AddUnresolved(u UnresolvedDependency) {
+	dg.unresolved[u] = struct{}{}
+}
+
+// Unresolved returns the recorded unresolved dependencies, ordered by purl and parent.
+func (dg *DependencyGraph) Unresolved() []UnresolvedDependency {
+	unresolved := make([]UnresolvedDependency, 0, len(dg.unresolved))
+	for u := range dg.unresolved {
+		unresolved = append(unresolved, u)
+	}
+	sort.Slice(unresolved, func(i, j int) bool {
+		if unresolved[i].Purl != unresolved[j].Purl {
+			return unresolved[i].Purl < unresolved[j].Purl
+		}
+		if unresolved[i].Parent.Purl != unresolved[j].Parent.Purl {
+			return unresolved[i].Parent.Purl < unresolved[j].Parent.Purl
+		}
+		return unresolved[i].Parent.Version < unresolved[j].Parent.Version
+	})
+	return unresolved
+}
+
+// MarkCurated records a dependency that was added to the graph by a curation.
+func (dg *DependencyGraph) MarkCurated(d Dependency) {
+	dg.curated[d] = struct{}{}
+}
+
+// Curated returns the dependencies added to the graph by a curation, ordered by purl and version.
+func (dg *DependencyGraph) Curated() []Dependency {
+	curated := make([]Dependency, 0, len(dg.curated))
+	for d := range dg.curated {
+		curated = append(curated, d)
+	}
+	return sortDependencies(curated)
+}
+
+// SetRequirement records the requirement a dependency was first declared with.
+func (dg *DependencyGraph) SetRequirement(d Dependency, requirement string) {
+	if _, exists := dg.requirements[d]; !exists {
+		dg.requirements[d] = requirement
+	}
+}
+
+// Requirement returns the requirement a dependency was first declared with (empty if unknown).
+func (dg *DependencyGraph) Requirement(d Dependency) string {
+	return dg.requirements[d]
+}
+
 // GetDependenciesCount returns the total number of unique dependencies in the graph.
 func (dg *DependencyGraph) GetDependenciesCount() int {
 	return len(dg.dependenciesOf)
@@ -131,4 +131,8 @@ func TestGetGraphString(t *testing.T) {
 	if !strings.Contains(result, "pkg:/scanoss/scanoss.js --> pkg:npm/typescript") {
 		t.Errorf("Result missing 'pkg:/scanoss/scanoss.js --> pkg:npm/typescript'")
 	}
+
+	if want := "pkg:/scanoss/scanoss.js --> pkg:npm/typescript\npkg:npm/typescript --> null\n"; result != want {
+		t.Errorf("String() = %q, want %q", result, want)
+	}
 }
@@ -0,0 +1,69 @@
+package transdep
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// DependencyChange is a package whose versions differ between two sets of dependencies.
+// Added packages have no Before versions, and removed packages no After versions.
+type DependencyChange struct {
+	Purl   string
+	Before []string // Versions in the first set, oldest first
+	After  []string // Versions in the second set, oldest first
+}
+
+// DependencyDiff holds the packages added, removed and changed (to other versions) between two sets of dependencies.
+type DependencyDiff struct {
+	Added   []DependencyChange
+	Removed []DependencyChange
+	Changed []DependencyChange
+}
+
+// DiffDependencies compares two sets of dependencies package by package. A package is changed if
+// it is present in both sets with different versions. The changes are ordered by purl.
+func DiffDependencies(before, after []Dependency) DependencyDiff {
+	beforeVersions, afterVersions := groupVersions(before), groupVersions(after)
+	purls := make([]string, 0, len(beforeVersions)+len(afterVersions))
+	for purl := range beforeVersions {
+		purls = append(purls, purl)
+	}
+	for purl := range afterVersions {
+		if _, found := beforeVersions[purl]; !found {
+			purls = append(purls, purl)
+		}
+	}
+	sort.Strings(purls)
+	diff := DependencyDiff{Added: []DependencyChange{}, Removed: []DependencyChange{}, Changed: []DependencyChange{}}
+	for _, purl := range purls {
+		change := DependencyChange{Purl: purl, Before: beforeVersions[purl], After: afterVersions[purl]}
+		switch {
+		case len(change.Before) == 0:
+			diff.Added = append(diff.Added, change)
+		case len(change.After) == 0:
+			diff.Removed = append(diff.Removed, change)
+		case !slices.Equal(change.Before, change.After):
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	return diff
+}
+
+// groupVersions returns the distinct versions of each package of the given dependencies, ordered following
+// the rules of the package ecosystem.
+func groupVersions(dependencies []Dependency) map[string][]string {
+	versions := make(map[string][]string)
+	for _, d := range dependencies {
+		if !slices.Contains(versions[d.Purl], d.Version) {
+			versions[d.Purl] = append(versions[d.Purl], d.Version)
+		}
+	}
+	for purl, purlVersions := range versions {
+		ecosystem, _, _ := strings.Cut(strings.TrimPrefix(purl, "pkg:"), "/")
+		slices.SortFunc(purlVersions, func(a, b string) int { return shared.CompareVersions(ecosystem, a, b) })
+	}
+	return versions
+}
@@ -0,0 +1,38 @@
+package transdep
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDependencies(t *testing.T) {
+	before := []Dependency{
+		{Purl: "pkg:npm/left-pad", Version: "1.0.0"},
+		{Purl: "pkg:npm/lodash", Version: "4.17.9"},
+		{Purl: "pkg:npm/react", Version: "17.0.2"},
+		{Purl: "pkg:npm/debug", Version: "2.6.9"},
+		{Purl: "pkg:npm/debug", Version: "4.3.1"},
+	}
+	after := []Dependency{
+		{Purl: "pkg:npm/lodash", Version: "4.17.21"},
+		{Purl: "pkg:npm/react", Version: "17.0.2"},
+		{Purl: "pkg:npm/debug", Version: "4.3.1"},
+		{Purl: "pkg:npm/debug", Version: "2.6.9"},
+		{Purl: "pkg:npm/scheduler", Version: "0.20.2"},
+	}
+	want := DependencyDiff{
+		Added:   []DependencyChange{{Purl: "pkg:npm/scheduler", After: []string{"0.20.2"}}},
+		Removed: []DependencyChange{{Purl: "pkg:npm/left-pad", Before: []string{"1.0.0"}}},
+		Changed: []DependencyChange{{Purl: "pkg:npm/lodash", Before: []string{"4.17.9"}, After: []string{"4.17.21"}}},
+	}
+	if got := DiffDependencies(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffDependencies() = %+v, want %+v", got, want)
+	}
+	empty := DependencyDiff{Added: []DependencyChange{}, Removed: []DependencyChange{}, Changed: []DependencyChange{}}
+	if got := DiffDependencies(before, before); !reflect.DeepEqual(got, empty) {
+		t.Errorf("DiffDependencies() expected no differences for the same set, got %+v", got)
+	}
+	if got := DiffDependencies(nil, after[:1]); len(got.Added) != 1 || got.Added[0].Purl != "pkg:npm/lodash" {
+		t.Errorf("DiffDependencies() expected every dependency to be added, got %+v", got)
+	}
+}
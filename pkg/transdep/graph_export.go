@@ -0,0 +1,222 @@
+package transdep
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Graph export formats.
+const (
+	ExportFormatDOT     = "dot"     // Graphviz DOT
+	ExportFormatGraphML = "graphml" // GraphML (XML)
+	ExportFormatMermaid = "mermaid" // Mermaid flowchart
+	ExportFormatJSON    = "json"    // JSON nodes and edges
+)
+
+// ExportFormats lists the supported graph export formats.
+var ExportFormats = []string{ExportFormatDOT, ExportFormatGraphML, ExportFormatMermaid, ExportFormatJSON}
+
+// ExportNode is a node of an exported dependency graph.
+type ExportNode struct {
+	ID       string   `json:"id"`
+	Purl     string   `json:"purl"`
+	Version  string   `json:"version"`
+	Depth    int      `json:"depth"`    // Shortest distance from the entry dependencies (0 for entries, -1 if unreachable)
+	Licenses []string `json:"licenses"` // SPDX IDs (or names)
+}
+
+// ExportEdge is a link from a node of an exported dependency graph to one of its dependencies.
+type ExportEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GraphExport is an ordered copy of a dependency graph, so every export of the same graph is identical.
+type GraphExport struct {
+	KBSnapshot string       `json:"kb_snapshot,omitempty"`
+	Nodes      []ExportNode `json:"nodes"`
+	Edges      []ExportEdge `json:"edges"`
+}
+
+// NewGraphExport copies the given graph, with nodes ordered by purl and version, and edges by source and target.
+// Depths are counted from the given entry dependencies, and licenses (optional) returns the licenses of a node.
+func NewGraphExport(dg *DependencyGraph, entries []Dependency, licenses func(Dependency) []string) GraphExport {
+	dependencies := sortDependencies(dg.Flatten())
+	ids := make(map[Dependency]string, len(dependencies))
+	for i, dependency := range dependencies {
+		ids[dependency] = "n" + strconv.Itoa(i)
+	}
+	depths := make(map[Dependency]int, len(dependencies))
+	dg.WalkBreadthFirst(sortDependencies(slices.Clone(entries)), func(d Dependency, depth int) bool {
+		depths[d] = depth
+		return true
+	})
+	export := GraphExport{Nodes: make([]ExportNode, 0, len(dependencies)), Edges: []ExportEdge{}}
+	for _, dependency := range dependencies {
+		node := ExportNode{ID: ids[dependency], Purl: dependency.Purl, Version: dependency.Version, Depth: -1, Licenses: []string{}}
+		if depth, reached := depths[dependency]; reached {
+			node.Depth = depth
+		}
+		if licenses != nil {
+			node.Licenses = append(node.Licenses, licenses(dependency)...)
+		}
+		export.Nodes = append(export.Nodes, node)
+		children := sortDependencies(slices.Clone(dg.Children(dependency)))
+		for _, child := range slices.Compact(children) {
+			export.Edges = append(export.Edges, ExportEdge{From: ids[dependency], To: ids[child]})
+		}
+	}
+	return export
+}
+
+// Write writes the graph in the given export format.
+func (g GraphExport) Write(w io.Writer, format string) error {
+	switch format {
+	case ExportFormatDOT:
+		return g.writeDOT(w)
+	case ExportFormatGraphML:
+		return g.writeGraphML(w)
+	case ExportFormatMermaid:
+		return g.writeMermaid(w)
+	case ExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(g)
+	default:
+		return fmt.Errorf("unsupported graph export format: %v", format)
+	}
+}
+
+// label returns the display label of a node.
+func (n ExportNode) label() string {
+	return n.Purl + "@" + n.Version
+}
+
+// writeDOT writes the graph as a Graphviz digraph, with the node details as (custom) attributes.
+func (g GraphExport) writeDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	if len(g.KBSnapshot) > 0 {
+		_, _ = fmt.Fprintf(&b, "  kb_snapshot=%v;\n", dotQuote(g.KBSnapshot))
+	}
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		_, _ = fmt.Fprintf(&b, "  %v [label=%v, purl=%v, version=%v, depth=%v, license=%v];\n", node.ID, dotQuote(node.label()),
+			dotQuote(node.Purl), dotQuote(node.Version), node.Depth, dotQuote(strings.Join(node.Licenses, "/")))
+	}
+	for _, edge := range g.Edges {
+		_, _ = fmt.Fprintf(&b, "  %v -> %v;\n", edge.From, edge.To)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote returns the given text as a quoted DOT string.
+func dotQuote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
+
+// GraphML document structure.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Data        []graphMLData `xml:"data"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+// writeGraphML writes the graph as a GraphML document, with the node details as data keys.
+func (g GraphExport) writeGraphML(w io.Writer) error {
+	document := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "kb_snapshot", For: "graph", Name: "kb_snapshot", Type: "string"},
+			{ID: "purl", For: "node", Name: "purl", Type: "string"},
+			{ID: "version", For: "node", Name: "version", Type: "string"},
+			{ID: "depth", For: "node", Name: "depth", Type: "int"},
+			{ID: "license", For: "node", Name: "license", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "dependencies", EdgeDefault: "directed"},
+	}
+	if len(g.KBSnapshot) > 0 {
+		document.Graph.Data = []graphMLData{{Key: "kb_snapshot", Value: g.KBSnapshot}}
+	}
+	for _, node := range g.Nodes {
+		document.Graph.Nodes = append(document.Graph.Nodes, graphMLNode{ID: node.ID, Data: []graphMLData{
+			{Key: "purl", Value: node.Purl},
+			{Key: "version", Value: node.Version},
+			{Key: "depth", Value: strconv.Itoa(node.Depth)},
+			{Key: "license", Value: strings.Join(node.Licenses, "/")},
+		}})
+	}
+	for i, edge := range g.Edges {
+		document.Graph.Edges = append(document.Graph.Edges, graphMLEdge{ID: "e" + strconv.Itoa(i), Source: edge.From, Target: edge.To})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// writeMermaid writes the graph as a Mermaid flowchart, with the node details in their labels.
+func (g GraphExport) writeMermaid(w io.Writer) error {
+	var b strings.Builder
+	if len(g.KBSnapshot) > 0 {
+		_, _ = fmt.Fprintf(&b, "%%%% KB snapshot: %v\n", g.KBSnapshot)
+	}
+	b.WriteString("flowchart LR\n")
+	for _, node := range g.Nodes {
+		label := node.label() + "<br/>depth " + strconv.Itoa(node.Depth)
+		if len(node.Licenses) > 0 {
+			label += "<br/>" + strings.Join(node.Licenses, "/")
+		}
+		_, _ = fmt.Fprintf(&b, "  %v[\"%v\"]\n", node.ID, strings.ReplaceAll(label, `"`, "#quot;"))
+	}
+	for _, edge := range g.Edges {
+		_, _ = fmt.Fprintf(&b, "  %v --> %v\n", edge.From, edge.To)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
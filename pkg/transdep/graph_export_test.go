@@ -0,0 +1,111 @@
+package transdep
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// newTestGraphExport exports a small diamond-shaped graph, with a node unreachable from the entry dependency.
+func newTestGraphExport() GraphExport {
+	graph := NewDepGraph()
+	app := Dependency{Purl: "pkg:npm/app", Version: "1.0.0"}
+	left := Dependency{Purl: "pkg:npm/left", Version: "2.0.0"}
+	right := Dependency{Purl: "pkg:npm/right", Version: "3.0.0"}
+	shared := Dependency{Purl: "pkg:npm/shared", Version: "4.0.0"}
+	graph.Connect(app, right)
+	graph.Connect(app, left)
+	graph.Connect(right, shared)
+	graph.Connect(left, shared)
+	graph.Connect(left, shared)
+	graph.Connect(Dependency{Purl: "pkg:npm/orphan", Version: "0.1.0"}, Dependency{})
+	export := NewGraphExport(graph, []Dependency{app}, func(d Dependency) []string {
+		if d.Purl == "pkg:npm/shared" {
+			return []string{"Apache-2.0", "MIT"}
+		}
+		return nil
+	})
+	export.KBSnapshot = "2026.10.01"
+	return export
+}
+
+func TestNewGraphExport(t *testing.T) {
+	export := newTestGraphExport()
+	var nodes []string
+	for _, node := range export.Nodes {
+		nodes = append(nodes, node.ID+"="+node.label()+"/"+strings.Join(node.Licenses, "+"))
+	}
+	if got, want := strings.Join(nodes, " "), "n0=pkg:npm/app@1.0.0/ n1=pkg:npm/left@2.0.0/ n2=pkg:npm/orphan@0.1.0/ "+
+		"n3=pkg:npm/right@3.0.0/ n4=pkg:npm/shared@4.0.0/Apache-2.0+MIT"; got != want {
+		t.Errorf("NewGraphExport() nodes = %v, want %v", got, want)
+	}
+	depths := []int{0, 1, -1, 1, 2}
+	for i, node := range export.Nodes {
+		if node.Depth != depths[i] {
+			t.Errorf("NewGraphExport() depth of %v = %v, want %v", node.Purl, node.Depth, depths[i])
+		}
+	}
+	var edges []string
+	for _, edge := range export.Edges {
+		edges = append(edges, edge.From+">"+edge.To)
+	}
+	if got, want := strings.Join(edges, " "), "n0>n1 n0>n3 n1>n4 n3>n4"; got != want {
+		t.Errorf("NewGraphExport() edges = %v, want %v", got, want)
+	}
+}
+
+func TestGraphExportWrite(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []string // Expected lines (or fragments)
+	}{
+		{format: ExportFormatDOT, want: []string{
+			"digraph dependencies {",
+			`  kb_snapshot="2026.10.01";`,
+			`  n4 [label="pkg:npm/shared@4.0.0", purl="pkg:npm/shared", version="4.0.0", depth=2, license="Apache-2.0/MIT"];`,
+			"  n1 -> n4;",
+		}},
+		{format: ExportFormatGraphML, want: []string{
+			`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`,
+			`<data key="kb_snapshot">2026.10.01</data>`,
+			`<node id="n4">`,
+			`<data key="depth">2</data>`,
+			`<data key="license">Apache-2.0/MIT</data>`,
+			`<edge id="e2" source="n1" target="n4"></edge>`,
+		}},
+		{format: ExportFormatMermaid, want: []string{
+			"%% KB snapshot: 2026.10.01",
+			"flowchart LR",
+			`  n4["pkg:npm/shared@4.0.0<br/>depth 2<br/>Apache-2.0/MIT"]`,
+			"  n1 --> n4",
+		}},
+		{format: ExportFormatJSON, want: []string{`"kb_snapshot": "2026.10.01"`, `"depth": 2`, `"from": "n1"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var first, second bytes.Buffer
+			if err := newTestGraphExport().Write(&first, tt.format); err != nil {
+				t.Fatalf("Write() unexpected error = %v", err)
+			}
+			_ = newTestGraphExport().Write(&second, tt.format)
+			if first.String() != second.String() {
+				t.Errorf("Write() expected identical exports, got:\n%v\nand:\n%v", first.String(), second.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(first.String(), want) {
+					t.Errorf("Write() expected %q in:\n%v", want, first.String())
+				}
+			}
+		})
+	}
+	var output bytes.Buffer
+	_ = newTestGraphExport().Write(&output, ExportFormatJSON)
+	var decoded GraphExport
+	if err := json.Unmarshal(output.Bytes(), &decoded); err != nil || len(decoded.Nodes) != 5 || len(decoded.Edges) != 4 {
+		t.Errorf("Write() expected a JSON graph with 5 nodes and 4 edges, got %+v (%v)", decoded, err)
+	}
+	if err := newTestGraphExport().Write(&output, "svg"); err == nil {
+		t.Errorf("Write() expected an error for an unsupported format")
+	}
+}
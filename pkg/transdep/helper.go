@@ -4,19 +4,67 @@ import (
 	"go.uber.org/zap"
 )
 
+// Types of the graph events reported while a dependency graph is collected.
+const (
+	GraphEventNode       = "node"       // A dependency was added to the graph for the first time
+	GraphEventEdge       = "edge"       // A dependency was connected to its parent
+	GraphEventUnresolved = "unresolved" // A requirement could not be resolved to a version
+)
+
+// GraphEvent is a change made to the dependency graph as a collector result is processed.
+type GraphEvent struct {
+	Type        string
+	Parent      Dependency           // Parent of the edge (edge events only)
+	Dependency  Dependency           // New node, or child of the edge
+	Requirement string               // Requirement the dependency was resolved from
+	Curated     bool                 // Added by a curation
+	Unresolved  UnresolvedDependency // Unresolved events only
+}
+
 // ProcessCollectorResult process collector results and save a result in an adjacencyList structure.
 func ProcessCollectorResult(s *zap.SugaredLogger, depGraph *DependencyGraph, maxDependencyResponseSize int) func(Result) bool {
+	return StreamCollectorResult(s, depGraph, maxDependencyResponseSize, nil)
+}
+
+// StreamCollectorResult behaves like ProcessCollectorResult, also reporting each change to the graph to the
+// given (optional) event handler as it happens.
+func StreamCollectorResult(s *zap.SugaredLogger, depGraph *DependencyGraph, maxDependencyResponseSize int,
+	onEvent func(GraphEvent)) func(Result) bool {
+	emit := func(event GraphEvent) {
+		if onEvent != nil {
+			onEvent(event)
+		}
+	}
 	return func(result Result) bool {
 		parentDep, err := ExtractDependencyFromJob(result.Parent)
 		if err != nil {
 			s.Errorf("failed to convert dependency:%v, %v", result.Parent, err)
 			return false
 		}
+		for _, ur := range result.Unresolved {
+			purl, purlErr := GetPurlFromPurlName(ur.PurlName, "", ur.Ecosystem)
+			if purlErr != nil {
+				s.Errorf("failed to convert unresolved dependency:%v, %v", ur.PurlName, purlErr)
+				continue
+			}
+			unresolved := UnresolvedDependency{Parent: parentDep, Purl: purl.ToString(), Requirement: ur.Requirement, Reason: ur.Reason}
+			depGraph.AddUnresolved(unresolved)
+			emit(GraphEvent{Type: GraphEventUnresolved, Unresolved: unresolved})
+		}
 		for _, td := range result.TransitiveDependencies {
 			tDep, tdErr := ExtractDependencyFromJob(td)
 			if tdErr == nil {
+				isNew := !depGraph.isRegisteredDependency(tDep)
 				// Connects a dependency within a child
 				depGraph.Connect(parentDep, tDep)
+				if td.Curated {
+					depGraph.MarkCurated(tDep)
+				}
+				depGraph.SetRequirement(tDep, td.Requirement)
+				if isNew {
+					emit(GraphEvent{Type: GraphEventNode, Dependency: tDep, Requirement: td.Requirement, Curated: td.Curated})
+				}
+				emit(GraphEvent{Type: GraphEventEdge, Parent: parentDep, Dependency: tDep, Requirement: td.Requirement, Curated: td.Curated})
 				// Stop if a max limit response is reached
 				if depGraph.GetDependenciesCount() == maxDependencyResponseSize {
 					return true
@@ -92,4 +92,32 @@ func TestProcessCollectorResult(t *testing.T) {
 			t.Error("should signal to stop processing")
 		}
 	})
+	t.Run("reports graph events", func(t *testing.T) {
+		mockGraph := NewDepGraph()
+		parent := DependencyJob{PurlName: "parent-dep", Version: "1.0.0", Ecosystem: "npm", Depth: 1}
+		child := DependencyJob{PurlName: "child-dep", Version: "1.0.0", Requirement: "^1.0.0", Ecosystem: "npm", Depth: 0}
+		other := DependencyJob{PurlName: "other-dep", Version: "1.0.0", Ecosystem: "npm", Depth: 1}
+		var events []GraphEvent
+		callback := StreamCollectorResult(s, mockGraph, 10, func(event GraphEvent) {
+			events = append(events, event)
+		})
+		callback(Result{Parent: parent, TransitiveDependencies: []DependencyJob{child},
+			Unresolved: []UnresolvedRequirement{{PurlName: "missing-dep", Requirement: "latest", Ecosystem: "npm", Reason: "DIST_TAG"}}})
+		callback(Result{Parent: other, TransitiveDependencies: []DependencyJob{child}})
+		wantTypes := []string{GraphEventUnresolved, GraphEventNode, GraphEventEdge, GraphEventEdge}
+		if len(events) != len(wantTypes) {
+			t.Fatalf("expected %v events, got %#v", len(wantTypes), events)
+		}
+		for i, event := range events {
+			if event.Type != wantTypes[i] {
+				t.Errorf("event %v: expected type %v, got %v", i, wantTypes[i], event.Type)
+			}
+		}
+		if events[1].Dependency.Purl != "pkg:npm/child-dep" || events[1].Requirement != "^1.0.0" {
+			t.Errorf("expected a node event for the child dependency, got %#v", events[1])
+		}
+		if events[3].Parent.Purl != "pkg:npm/other-dep" || events[3].Dependency != events[1].Dependency {
+			t.Errorf("expected an edge from the second parent to the existing child, got %#v", events[3])
+		}
+	})
 }
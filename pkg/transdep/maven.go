@@ -0,0 +1,200 @@
+package transdep
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+// maxMavenModelDepth limits how far parent and BOM import chains are followed.
+const maxMavenModelDepth = 10
+
+var mavenPropertyRegex = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// mavenEffectiveModel is the result of merging a POM with its parents and imported BOMs.
+type mavenEffectiveModel struct {
+	properties map[string]string
+	managed    map[string]string // dependency purl name -> managed version
+}
+
+// MavenResolver resolves Maven requirements by interpolating properties and applying the
+// dependencyManagement inherited from parent POMs and imported BOMs.
+type MavenResolver struct {
+	metadataModel *models.MavenMetadataModel
+	s             *zap.SugaredLogger
+	mutex         sync.RWMutex
+	cache         map[string]mavenEffectiveModel
+}
+
+// NewMavenResolver creates a new instance of the Maven requirement resolver.
+func NewMavenResolver(metadataModel *models.MavenMetadataModel, s *zap.SugaredLogger) *MavenResolver {
+	return &MavenResolver{
+		metadataModel: metadataModel,
+		s:             s,
+		cache:         make(map[string]mavenEffectiveModel),
+	}
+}
+
+// ResolveVersion determines the concrete version of a dependency declared in the parent's POM.
+func (r *MavenResolver) ResolveVersion(parent DependencyJob, dependency models.UnresolvedDependency) (string, error) {
+	model := r.effectiveModel(parent.PurlName, parent.Version, 0)
+	requirement := strings.TrimSpace(dependency.Requirement)
+	if requirement == "" {
+		managed, ok := model.managed[dependency.Purl]
+		if !ok {
+			return "", &UnresolvedRequirementError{Reason: ReasonUnmanagedVersion, Detail: fmt.Sprintf("no managed version for %s", dependency.Purl)}
+		}
+		requirement = managed
+	}
+	interpolated, err := interpolateMavenProperties(requirement, model.properties)
+	if err != nil {
+		return "", err
+	}
+	return PickMavenVersion(interpolated)
+}
+
+// effectiveModel builds (and caches) the merged properties and dependency management of a POM.
+func (r *MavenResolver) effectiveModel(purlName, version string, depth int) mavenEffectiveModel {
+	key := purlName + "@" + version
+	r.mutex.RLock()
+	model, exists := r.cache[key]
+	r.mutex.RUnlock()
+	if exists {
+		return model
+	}
+	model = mavenEffectiveModel{properties: make(map[string]string), managed: make(map[string]string)}
+	metadata, err := r.metadataModel.GetMetadata(purlName, version)
+	if err != nil {
+		r.s.Warnf("Problem retrieving maven metadata for %v: %v", key, err)
+	}
+	// Start with everything inherited from the parent POM
+	if len(metadata.ParentPurlName) > 0 && depth < maxMavenModelDepth {
+		parent := r.effectiveModel(metadata.ParentPurlName, metadata.ParentVersion, depth+1)
+		for k, v := range parent.properties {
+			model.properties[k] = v
+		}
+		for k, v := range parent.managed {
+			model.managed[k] = v
+		}
+	}
+	for k, v := range metadata.Properties {
+		model.properties[k] = v
+	}
+	for k, v := range builtInMavenProperties(purlName, version, metadata) {
+		model.properties[k] = v
+	}
+	// Explicitly managed dependencies override inherited ones, imported BOMs only fill the gaps
+	var imports []models.ManagedDependency
+	for _, md := range metadata.DependencyManagement {
+		if md.Scope == "import" {
+			imports = append(imports, md)
+			continue
+		}
+		model.managed[md.Purl] = md.Requirement
+	}
+	for k, v := range model.managed {
+		if interpolated, intErr := interpolateMavenProperties(v, model.properties); intErr == nil {
+			model.managed[k] = interpolated
+		}
+	}
+	for _, bom := range imports {
+		r.importBOM(&model, bom, depth)
+	}
+	r.mutex.Lock()
+	r.cache[key] = model
+	r.mutex.Unlock()
+	return model
+}
+
+// importBOM merges the managed dependencies of an imported BOM into the given model.
+func (r *MavenResolver) importBOM(model *mavenEffectiveModel, bom models.ManagedDependency, depth int) {
+	if depth >= maxMavenModelDepth {
+		r.s.Debugf("Skipping BOM import %v: maximum depth reached", bom.Purl)
+		return
+	}
+	interpolated, err := interpolateMavenProperties(bom.Requirement, model.properties)
+	if err != nil {
+		r.s.Debugf("Cannot resolve BOM import %v@%v: %v", bom.Purl, bom.Requirement, err)
+		return
+	}
+	bomVersion, err := PickMavenVersion(interpolated)
+	if err != nil {
+		r.s.Debugf("Cannot resolve BOM import %v@%v: %v", bom.Purl, bom.Requirement, err)
+		return
+	}
+	imported := r.effectiveModel(bom.Purl, bomVersion, depth+1)
+	for k, v := range imported.managed {
+		if _, exists := model.managed[k]; !exists {
+			model.managed[k] = v
+		}
+	}
+}
+
+// builtInMavenProperties returns the project properties implicitly available to a POM.
+func builtInMavenProperties(purlName, version string, metadata models.MavenMetadata) map[string]string {
+	groupID, artifactID, _ := strings.Cut(purlName, "/")
+	properties := map[string]string{
+		"project.groupId":    groupID,
+		"project.artifactId": artifactID,
+		"project.version":    version,
+		"pom.version":        version,
+		"version":            version,
+	}
+	if len(metadata.ParentPurlName) > 0 {
+		parentGroupID, parentArtifactID, _ := strings.Cut(metadata.ParentPurlName, "/")
+		properties["project.parent.groupId"] = parentGroupID
+		properties["project.parent.artifactId"] = parentArtifactID
+		properties["project.parent.version"] = metadata.ParentVersion
+	}
+	return properties
+}
+
+// interpolateMavenProperties replaces all ${property} references with their values.
+func interpolateMavenProperties(value string, properties map[string]string) (string, error) {
+	for i := 0; i < maxMavenModelDepth && strings.Contains(value, "${"); i++ {
+		value = mavenPropertyRegex.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := properties[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+	}
+	if match := mavenPropertyRegex.FindStringSubmatch(value); match != nil {
+		return "", &UnresolvedRequirementError{Reason: ReasonUnresolvedProperty, Detail: fmt.Sprintf("unknown property %s", match[1])}
+	}
+	return value, nil
+}
+
+// PickMavenVersion extracts a concrete version from a Maven version requirement.
+// Soft requirements (i.e. 1.0) are used as is, while ranges (i.e. [1.0,2.0)) use their lower bound
+// (or an inclusive upper bound when there is no lower one).
+func PickMavenVersion(requirement string) (string, error) {
+	requirement = strings.TrimSpace(requirement)
+	if requirement == "" {
+		return "", &UnresolvedRequirementError{Reason: ReasonUnmanagedVersion, Detail: "empty requirement"}
+	}
+	if requirement == "LATEST" || requirement == "RELEASE" {
+		return "", &UnresolvedRequirementError{Reason: ReasonDistTag, Detail: requirement}
+	}
+	if !strings.ContainsAny(requirement[:1], "[(") {
+		return requirement, nil
+	}
+	// Only consider the first range of a multi-range requirement (i.e. (,1.0],[1.2,))
+	end := strings.IndexAny(requirement, "])")
+	if end < 0 {
+		return "", &UnresolvedRequirementError{Reason: ReasonInvalidRange, Detail: requirement}
+	}
+	lower, upper, isRange := strings.Cut(requirement[1:end], ",")
+	lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+	switch {
+	case lower != "":
+		return lower, nil
+	case isRange && upper != "" && requirement[end] == ']':
+		return upper, nil
+	}
+	return "", &UnresolvedRequirementError{Reason: ReasonInvalidRange, Detail: requirement}
+}
@@ -0,0 +1,137 @@
+package transdep
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+func TestMavenResolver_ResolveVersion(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	err = models.LoadTestSQLData(db, nil, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	resolver := NewMavenResolver(models.NewMavenMetadataModel(ctx, s, db), s)
+	app := DependencyJob{PurlName: "com.example/app", Version: "1.0.0", Ecosystem: "maven"}
+	lib := DependencyJob{PurlName: "com.example/lib", Version: "1.0.0", Ecosystem: "maven"}
+
+	tests := []struct {
+		name       string
+		parent     DependencyJob
+		dependency models.UnresolvedDependency
+		want       string
+		wantReason string
+	}{
+		{
+			name:       "Built-in project property",
+			parent:     app,
+			dependency: models.UnresolvedDependency{Purl: "com.example/lib", Requirement: "${project.version}"},
+			want:       "1.0.0",
+		},
+		{
+			name:       "Property declared in the POM",
+			parent:     app,
+			dependency: models.UnresolvedDependency{Purl: "com.google.guava/guava", Requirement: "${guava.version}"},
+			want:       "31.1-jre",
+		},
+		{
+			name:       "Property inherited from the parent POM",
+			parent:     lib,
+			dependency: models.UnresolvedDependency{Purl: "org.apache.commons/commons-lang3", Requirement: "${commons.version}"},
+			want:       "3.12.0",
+		},
+		{
+			name:       "Version managed by an imported BOM",
+			parent:     app,
+			dependency: models.UnresolvedDependency{Purl: "org.slf4j/slf4j-api", Requirement: ""},
+			want:       "2.0.7",
+		},
+		{
+			name:       "Version range",
+			parent:     app,
+			dependency: models.UnresolvedDependency{Purl: "junit/junit", Requirement: "[4.12,5.0)"},
+			want:       "4.12",
+		},
+		{
+			name:       "Unknown property",
+			parent:     app,
+			dependency: models.UnresolvedDependency{Purl: "com.example/missing", Requirement: "${missing.version}"},
+			wantReason: ReasonUnresolvedProperty,
+		},
+		{
+			name:       "Unmanaged version",
+			parent:     app,
+			dependency: models.UnresolvedDependency{Purl: "com.example/unmanaged", Requirement: ""},
+			wantReason: ReasonUnmanagedVersion,
+		},
+		{
+			name:       "POM without metadata",
+			parent:     DependencyJob{PurlName: "com.example/unknown", Version: "1.0.0", Ecosystem: "maven"},
+			dependency: models.UnresolvedDependency{Purl: "com.example/lib", Requirement: "1.2.3"},
+			want:       "1.2.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.ResolveVersion(tt.parent, tt.dependency)
+			if tt.wantReason != "" {
+				var unresolvedErr *UnresolvedRequirementError
+				if !errors.As(err, &unresolvedErr) || unresolvedErr.Reason != tt.wantReason {
+					t.Errorf("ResolveVersion() error = %v, want reason %v", err, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVersion() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickMavenVersion(t *testing.T) {
+	tests := []struct {
+		requirement string
+		want        string
+		wantErr     bool
+	}{
+		{requirement: "1.0", want: "1.0"},
+		{requirement: "[1.0]", want: "1.0"},
+		{requirement: "[1.0,2.0)", want: "1.0"},
+		{requirement: "(,1.0]", want: "1.0"},
+		{requirement: "(,1.0],[1.2,)", want: "1.0"},
+		{requirement: "(,1.0)", wantErr: true},
+		{requirement: "[1.0", wantErr: true},
+		{requirement: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.requirement, func(t *testing.T) {
+			got, err := PickMavenVersion(tt.requirement)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PickMavenVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PickMavenVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package transdep
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// Pin forces the version of the packages matching a pattern wherever they appear below the entry dependencies
+// (like npm overrides, Yarn resolutions, Maven dependencyManagement or Cargo [patch]), or leaves them out of the graph.
+type Pin struct {
+	Pattern string // Purl name pattern (i.e. "%40types/*"), as stored in the KB
+	Version string // Version or constraint replacing the declared requirements
+	Exclude bool   // Leave the matching packages (and their dependencies) out of the graph
+}
+
+// NewPin parses a pin for the given ecosystem from a purl pattern (i.e. "pkg:npm/@types/*"). Any versions in
+// the pattern are ignored, and "*" matches any sequence of characters except "/".
+func NewPin(purlPattern string, version string, exclude bool, ecosystem string) (Pin, error) {
+	p, err := packageurl.FromString(strings.TrimSpace(purlPattern))
+	if err != nil {
+		return Pin{}, fmt.Errorf("invalid pin purl %v: %w", purlPattern, err)
+	}
+	if p.Type != ecosystem {
+		return Pin{}, fmt.Errorf("pin %v does not belong to the %v ecosystem", purlPattern, ecosystem)
+	}
+	version = strings.TrimSpace(version)
+	if exclude == (len(version) > 0) {
+		return Pin{}, fmt.Errorf("pin %v requires either a version or an exclusion", purlPattern)
+	}
+	pattern, err := ExtractPackageIdentifierFromPurl(strings.TrimSpace(purlPattern))
+	if err != nil {
+		return Pin{}, fmt.Errorf("invalid pin purl %v: %w", purlPattern, err)
+	}
+	if _, err = path.Match(pattern, ""); err != nil {
+		return Pin{}, fmt.Errorf("invalid pin pattern %v: %w", purlPattern, err)
+	}
+	return Pin{Pattern: pattern, Version: version, Exclude: exclude}, nil
+}
+
+// Matches reports whether the given purl name matches the pin pattern.
+func (p Pin) Matches(purlName string) bool {
+	matched, _ := path.Match(p.Pattern, purlName)
+	return matched
+}
@@ -0,0 +1,52 @@
+package transdep
+
+import (
+	"testing"
+)
+
+func TestNewPin(t *testing.T) {
+	tests := []struct {
+		name      string
+		purl      string
+		version   string
+		exclude   bool
+		ecosystem string
+		want      Pin
+		wantErr   bool
+		matches   []string
+		ignores   []string
+	}{
+		{name: "scoped wildcard", purl: "pkg:npm/@types/*", version: "^1.0.0", ecosystem: "npm",
+			want: Pin{Pattern: "%40types/*", Version: "^1.0.0"}, matches: []string{"%40types/node"}, ignores: []string{"node", "%40babel/core"}},
+		{name: "exact with version", purl: "pkg:npm/chai@4.3.6", version: "4.3.7", ecosystem: "npm",
+			want: Pin{Pattern: "chai", Version: "4.3.7"}, matches: []string{"chai"}, ignores: []string{"chai-http"}},
+		{name: "maven group", purl: "pkg:maven/org.apache.*/*", exclude: true, ecosystem: "maven",
+			want: Pin{Pattern: "org.apache.*/*", Exclude: true}, matches: []string{"org.apache.commons/commons-lang3"}, ignores: []string{"com.google/guava"}},
+		{name: "other ecosystem", purl: "pkg:pypi/requests", version: "2.0.0", ecosystem: "npm", wantErr: true},
+		{name: "no version", purl: "pkg:npm/chai", ecosystem: "npm", wantErr: true},
+		{name: "version and exclusion", purl: "pkg:npm/chai", version: "4.3.7", exclude: true, ecosystem: "npm", wantErr: true},
+		{name: "bad pattern", purl: "pkg:npm/chai[", version: "4.3.7", ecosystem: "npm", wantErr: true},
+		{name: "invalid purl", purl: "chai", version: "4.3.7", ecosystem: "npm", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPin(tt.purl, tt.version, tt.exclude, tt.ecosystem)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NewPin() = %+v, want %+v", got, tt.want)
+			}
+			for _, purlName := range tt.matches {
+				if !got.Matches(purlName) {
+					t.Errorf("Matches(%v) = false, want true", purlName)
+				}
+			}
+			for _, purlName := range tt.ignores {
+				if got.Matches(purlName) {
+					t.Errorf("Matches(%v) = true, want false", purlName)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package transdep
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// ResolutionStrategy models how a package manager selects the versions that end up installed when
+// several paths through the dependency graph require different versions of the same package.
+type ResolutionStrategy interface {
+	// Name returns a short identifier for the strategy.
+	Name() string
+	// Resolve returns the effective set of dependencies reachable from the given roots.
+	Resolve(dg *DependencyGraph, roots []Dependency) []Dependency
+}
+
+// resolutionStrategies maps each registered ecosystem to the conflict resolution it applies.
+var resolutionStrategies = map[string]ResolutionStrategy{
+	"composer": singleVersionStrategy{ecosystem: "composer"},
+	"crates":   semverCompatibleStrategy{ecosystem: "crates"},
+	"gem":      singleVersionStrategy{ecosystem: "gem"},
+	"maven":    nearestWinsStrategy{},
+	"npm":      nestedStrategy{},
+}
+
+// GetResolutionStrategy returns the resolution strategy for the given ecosystem.
+// Ecosystems without a specific strategy keep every version found (nested).
+func GetResolutionStrategy(ecosystem string) ResolutionStrategy {
+	if strategy, ok := resolutionStrategies[ecosystem]; ok {
+		return strategy
+	}
+	return nestedStrategy{}
+}
+
+// nearestWinsStrategy implements Maven's "nearest definition wins" mediation. The version closest to
+// the roots is selected (first declaration wins on a tie) and the subtrees of losing versions are omitted.
+type nearestWinsStrategy struct{}
+
+func (nearestWinsStrategy) Name() string { return "nearest-wins" }
+
+func (nearestWinsStrategy) Resolve(dg *DependencyGraph, roots []Dependency) []Dependency {
+	selected := make(map[string]struct{})
+	var resolved []Dependency
+	dg.WalkBreadthFirst(roots, func(d Dependency, _ int) bool {
+		if _, exists := selected[d.Purl]; exists {
+			return false // A nearer version has already been selected, so skip this one and its children
+		}
+		selected[d.Purl] = struct{}{}
+		resolved = append(resolved, d)
+		return true
+	})
+	return sortDependencies(resolved)
+}
+
+// nestedStrategy implements npm's nested installs. Conflicting versions are installed side by side
+// (nested under their dependents) while identical versions are de-duplicated (hoisted).
+type nestedStrategy struct{}
+
+func (nestedStrategy) Name() string { return "nested" }
+
+func (nestedStrategy) Resolve(dg *DependencyGraph, roots []Dependency) []Dependency {
+	return sortDependencies(reachableDependencies(dg, roots))
+}
+
+// semverCompatibleStrategy implements Cargo's unification. Versions of a package that are semver
+// compatible (same major, or same minor/patch for 0.x releases) are unified into the highest one,
+// and the subtrees of the unified versions are omitted.
+type semverCompatibleStrategy struct {
+	ecosystem string
+}
+
+func (semverCompatibleStrategy) Name() string { return "semver-compatible" }
+
+func (s semverCompatibleStrategy) Resolve(dg *DependencyGraph, roots []Dependency) []Dependency {
+	return resolveHighest(s.ecosystem, dg, roots, func(d Dependency) string {
+		return d.Purl + "@" + semverCompatibilityKey(d.Version)
+	})
+}
+
+// singleVersionStrategy implements package managers that install a single version of each package
+// (i.e. Composer and Bundler). The highest version required is selected, and the subtrees of the others are omitted.
+type singleVersionStrategy struct {
+	ecosystem string
+}
+
+func (singleVersionStrategy) Name() string { return "single-version" }
+
+func (s singleVersionStrategy) Resolve(dg *DependencyGraph, roots []Dependency) []Dependency {
+	return resolveHighest(s.ecosystem, dg, roots, func(d Dependency) string {
+		return d.Purl
+	})
+}
+
+// reachableDependencies returns all the dependencies reachable from the given roots.
+func reachableDependencies(dg *DependencyGraph, roots []Dependency) []Dependency {
+	var reachable []Dependency
+	dg.WalkBreadthFirst(roots, func(d Dependency, _ int) bool {
+		reachable = append(reachable, d)
+		return true
+	})
+	return reachable
+}
+
+// resolveHighest installs the highest version requested of each group of dependencies produced by groupKey,
+// following only the dependencies of the installed versions. As dropping a version can also drop the requests
+// made by its subtree, the versions are selected again from the remaining requests until they settle.
+func resolveHighest(ecosystem string, dg *DependencyGraph, roots []Dependency, groupKey func(Dependency) string) []Dependency {
+	requested := make(map[Dependency]struct{})
+	for _, d := range reachableDependencies(dg, roots) {
+		requested[d] = struct{}{}
+	}
+	var installed map[Dependency]struct{}
+	// The rounds are bounded in case the selection keeps alternating
+	for range len(requested) + 1 {
+		var nextRequested map[Dependency]struct{}
+		nextRequested, installed = walkSelected(dg, roots, highestPerGroup(ecosystem, requested, groupKey), groupKey)
+		if maps.Equal(nextRequested, requested) {
+			break
+		}
+		requested = nextRequested
+	}
+	resolved := make([]Dependency, 0, len(installed))
+	for d := range installed {
+		resolved = append(resolved, d)
+	}
+	return sortDependencies(resolved)
+}
+
+// walkSelected walks the graph from the roots, installing the selected version of each requested dependency
+// instead of the requested one (and so only following the dependencies of the selected versions).
+// It returns the dependencies requested along the way, and the ones installed.
+func walkSelected(dg *DependencyGraph, roots []Dependency, selected map[string]Dependency,
+	groupKey func(Dependency) string) (map[Dependency]struct{}, map[Dependency]struct{}) {
+	requested := make(map[Dependency]struct{})
+	installed := make(map[Dependency]struct{})
+	var queue []Dependency
+	for _, root := range roots {
+		if dg.isRegisteredDependency(root) {
+			queue = append(queue, root)
+		}
+	}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		requested[d] = struct{}{}
+		if winner, exists := selected[groupKey(d)]; exists {
+			d = winner
+		}
+		if _, exists := installed[d]; exists {
+			continue
+		}
+		installed[d] = struct{}{}
+		queue = append(queue, dg.dependenciesOf[d]...)
+	}
+	return requested, installed
+}
+
+// highestPerGroup selects the highest version of each group of dependencies produced by groupKey.
+func highestPerGroup(ecosystem string, dependencies map[Dependency]struct{}, groupKey func(Dependency) string) map[string]Dependency {
+	highest := make(map[string]Dependency)
+	for d := range dependencies {
+		key := groupKey(d)
+		current, exists := highest[key]
+		if !exists {
+			highest[key] = d
+			continue
+		}
+		// Equivalent versions (i.e. 1.0 and 1.0.0) are told apart by name, to keep the selection deterministic
+		if c := shared.CompareVersions(ecosystem, d.Version, current.Version); c > 0 || (c == 0 && d.Version < current.Version) {
+			highest[key] = d
+		}
+	}
+	return highest
+}
+
+// semverCompatibilityKey returns the part of a version that must match for two versions to be
+// considered compatible (i.e. 1.2.3 -> 1, 0.2.3 -> 0.2, 0.0.3 -> 0.0.3).
+func semverCompatibilityKey(version string) string {
+	core, _ := shared.SplitPreRelease(version)
+	parts := strings.Split(core, ".")
+	for i, part := range parts {
+		if strings.TrimLeft(part, "0") != "" || i == len(parts)-1 {
+			return strings.Join(parts[:i+1], ".")
+		}
+	}
+	return core
+}
+
+// sortDependencies orders dependencies by purl and version to produce deterministic output.
+func sortDependencies(dependencies []Dependency) []Dependency {
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].Purl != dependencies[j].Purl {
+			return dependencies[i].Purl < dependencies[j].Purl
+		}
+		return dependencies[i].Version < dependencies[j].Version
+	})
+	return dependencies
+}
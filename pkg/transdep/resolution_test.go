@@ -0,0 +1,149 @@
+package transdep
+
+import (
+	"reflect"
+	"testing"
+)
+
+// buildConflictGraph creates a graph where two paths require different versions of the same packages.
+//
+//	root --> a@1.0.0 --> c@1.2.0 --> d@1.0.0
+//	root --> b@1.0.0 --> a@1.5.0
+//	                 --> c@1.4.0
+//	                 --> c@2.0.0
+func buildConflictGraph(purlPrefix string) (*DependencyGraph, Dependency) {
+	root := Dependency{Purl: purlPrefix + "root", Version: "1.0.0"}
+	a1 := Dependency{Purl: purlPrefix + "a", Version: "1.0.0"}
+	a2 := Dependency{Purl: purlPrefix + "a", Version: "1.5.0"}
+	b := Dependency{Purl: purlPrefix + "b", Version: "1.0.0"}
+	c1 := Dependency{Purl: purlPrefix + "c", Version: "1.2.0"}
+	c2 := Dependency{Purl: purlPrefix + "c", Version: "1.4.0"}
+	c3 := Dependency{Purl: purlPrefix + "c", Version: "2.0.0"}
+	d := Dependency{Purl: purlPrefix + "d", Version: "1.0.0"}
+	graph := NewDepGraph()
+	graph.Connect(root, a1)
+	graph.Connect(root, b)
+	graph.Connect(a1, c1)
+	graph.Connect(c1, d)
+	graph.Connect(b, a2)
+	graph.Connect(b, c2)
+	graph.Connect(b, c3)
+	return graph, root
+}
+
+func TestResolutionStrategies(t *testing.T) {
+	tests := []struct {
+		name         string
+		ecosystem    string
+		strategyName string
+		expected     []string
+	}{
+		{
+			name:         "Maven nearest definition wins",
+			ecosystem:    "maven",
+			strategyName: "nearest-wins",
+			expected:     []string{"a@1.0.0", "b@1.0.0", "c@1.2.0", "d@1.0.0", "root@1.0.0"},
+		},
+		{
+			name:         "npm keeps nested versions",
+			ecosystem:    "npm",
+			strategyName: "nested",
+			expected:     []string{"a@1.0.0", "a@1.5.0", "b@1.0.0", "c@1.2.0", "c@1.4.0", "c@2.0.0", "d@1.0.0", "root@1.0.0"},
+		},
+		{
+			name:         "Cargo unifies semver compatible versions",
+			ecosystem:    "crates",
+			strategyName: "semver-compatible",
+			expected:     []string{"a@1.5.0", "b@1.0.0", "c@1.4.0", "c@2.0.0", "root@1.0.0"},
+		},
+		{
+			name:         "Composer installs a single version",
+			ecosystem:    "composer",
+			strategyName: "single-version",
+			expected:     []string{"a@1.5.0", "b@1.0.0", "c@2.0.0", "root@1.0.0"},
+		},
+		{
+			name:         "Unknown ecosystem defaults to nested",
+			ecosystem:    "unknown",
+			strategyName: "nested",
+			expected:     []string{"a@1.0.0", "a@1.5.0", "b@1.0.0", "c@1.2.0", "c@1.4.0", "c@2.0.0", "d@1.0.0", "root@1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, root := buildConflictGraph("")
+			strategy := GetResolutionStrategy(tt.ecosystem)
+			if strategy.Name() != tt.strategyName {
+				t.Errorf("expected strategy %v, got %v", tt.strategyName, strategy.Name())
+			}
+			var got []string
+			for _, d := range strategy.Resolve(graph, []Dependency{root}) {
+				got = append(got, d.Purl+"@"+d.Version)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSingleVersionDropsDiscardedRequests(t *testing.T) {
+	// x@2.0.0 is only requested by a@1.0.0, which is replaced by a@1.5.0, so x@1.0.0 is installed instead
+	//
+	//	root --> a@1.0.0 --> x@2.0.0
+	//	root --> b@1.0.0 --> a@1.5.0
+	//	root --> x@1.0.0
+	root := Dependency{Purl: "root", Version: "1.0.0"}
+	a1 := Dependency{Purl: "a", Version: "1.0.0"}
+	graph := NewDepGraph()
+	graph.Connect(root, a1)
+	graph.Connect(a1, Dependency{Purl: "x", Version: "2.0.0"})
+	graph.Connect(root, Dependency{Purl: "b", Version: "1.0.0"})
+	graph.Connect(Dependency{Purl: "b", Version: "1.0.0"}, Dependency{Purl: "a", Version: "1.5.0"})
+	graph.Connect(root, Dependency{Purl: "x", Version: "1.0.0"})
+	var got []string
+	for _, d := range GetResolutionStrategy("composer").Resolve(graph, []Dependency{root}) {
+		got = append(got, d.Purl+"@"+d.Version)
+	}
+	expected := []string{"a@1.5.0", "b@1.0.0", "root@1.0.0", "x@1.0.0"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSemverCompatibilityKey(t *testing.T) {
+	tests := map[string]string{
+		"1.2.3":        "1",
+		"0.2.3":        "0.2",
+		"0.0.3":        "0.0.3",
+		"v2.0.0-beta1": "2",
+		"0.0.0":        "0.0.0",
+	}
+	for version, expected := range tests {
+		if got := semverCompatibilityKey(version); got != expected {
+			t.Errorf("semverCompatibilityKey(%v) = %v, expected %v", version, got, expected)
+		}
+	}
+}
+
+func TestWalkBreadthFirst(t *testing.T) {
+	graph, root := buildConflictGraph("")
+	depths := make(map[string]int)
+	graph.WalkBreadthFirst([]Dependency{root}, func(d Dependency, depth int) bool {
+		depths[d.Purl+"@"+d.Version] = depth
+		return true
+	})
+	expected := map[string]int{"root@1.0.0": 0, "a@1.0.0": 1, "b@1.0.0": 1, "c@1.2.0": 2, "a@1.5.0": 2, "c@1.4.0": 2, "c@2.0.0": 2, "d@1.0.0": 3}
+	if !reflect.DeepEqual(depths, expected) {
+		t.Errorf("expected depths %v, got %v", expected, depths)
+	}
+	// Unknown roots are ignored
+	count := 0
+	graph.WalkBreadthFirst([]Dependency{{Purl: "missing", Version: "1.0.0"}}, func(_ Dependency, _ int) bool {
+		count++
+		return true
+	})
+	if count != 0 {
+		t.Errorf("expected no dependencies to be visited, got %v", count)
+	}
+}
@@ -0,0 +1,107 @@
+package transdep
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"scanoss.com/dependencies/pkg/models"
+)
+
+// Reason codes describing why a requirement could not be resolved to a concrete version.
+const (
+	ReasonUnresolvedProperty = "UNRESOLVED_PROPERTY" // Requirement references a property that could not be interpolated
+	ReasonUnmanagedVersion   = "UNMANAGED_VERSION"   // No version declared and none provided by dependency management
+	ReasonInvalidRange       = "INVALID_RANGE"       // Requirement is a version range that could not be parsed
+	ReasonAnyVersion         = "ANY_VERSION"         // Requirement accepts any version (i.e. *, x or empty)
+	ReasonDistTag            = "DIST_TAG"            // Requirement is a tag rather than a version (i.e. latest, next)
+	ReasonGitReference       = "GIT_REFERENCE"       // Requirement points to a git repository
+	ReasonRemoteURL          = "REMOTE_URL"          // Requirement points to a remote archive
+	ReasonLocalPath          = "LOCAL_PATH"          // Requirement points to a file or directory on disk
+	ReasonWorkspace          = "WORKSPACE_PROTOCOL"  // Requirement refers to a package of the same workspace
+)
+
+var (
+	distTagRegex      = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*$`)
+	gitShorthandRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+(#.*)?$`)
+	gitPrefixes       = []string{"git+", "git://", "git@", "github:", "gitlab:", "bitbucket:", "gist:"}
+	localPathPrefixes = []string{"file:", "link:", "./", "../", "/", "~/"}
+)
+
+// UnresolvedRequirementError reports a requirement that could not be resolved to a concrete version.
+type UnresolvedRequirementError struct {
+	Reason string
+	Detail string
+}
+
+// Error implements the error interface.
+func (e *UnresolvedRequirementError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Detail)
+}
+
+// UnresolvedRequirement is a declared dependency that could not be expanded into a job.
+type UnresolvedRequirement struct {
+	PurlName    string
+	Requirement string
+	Ecosystem   string
+	Reason      string
+}
+
+// RequirementResolver determines the concrete version to collect for a dependency declared by a parent job.
+type RequirementResolver interface {
+	ResolveVersion(parent DependencyJob, dependency models.UnresolvedDependency) (string, error)
+}
+
+// ClassifyRequirement returns the reason code explaining why a requirement does not name a concrete version.
+func ClassifyRequirement(requirement string) string {
+	requirement = strings.TrimSpace(requirement)
+	lower := strings.ToLower(requirement)
+	switch {
+	case requirement == "" || requirement == "*" || lower == "x":
+		return ReasonAnyVersion
+	case strings.HasPrefix(lower, "workspace:"):
+		return ReasonWorkspace
+	case hasAnyPrefix(lower, localPathPrefixes):
+		return ReasonLocalPath
+	case hasAnyPrefix(lower, gitPrefixes) || strings.HasSuffix(lower, ".git") || strings.Contains(lower, ".git#"):
+		return ReasonGitReference
+	case strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://"):
+		return ReasonRemoteURL
+	case gitShorthandRegex.MatchString(requirement):
+		return ReasonGitReference
+	case distTagRegex.MatchString(requirement):
+		return ReasonDistTag
+	}
+	return ReasonInvalidRange
+}
+
+// unresolvedReason returns the reason code carried by a resolution error.
+func unresolvedReason(requirement string, err error) string {
+	var unresolvedErr *UnresolvedRequirementError
+	if errors.As(err, &unresolvedErr) {
+		return unresolvedErr.Reason
+	}
+	return ClassifyRequirement(requirement)
+}
+
+// hasAnyPrefix reports whether s begins with any of the given prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeResolver is the default resolver, picking the first version found in the requirement.
+type rangeResolver struct{}
+
+func (rangeResolver) ResolveVersion(_ DependencyJob, dependency models.UnresolvedDependency) (string, error) {
+	version, err := PickFirstVersionFromRange(dependency.Requirement)
+	if err != nil {
+		return "", &UnresolvedRequirementError{Reason: ClassifyRequirement(dependency.Requirement), Detail: err.Error()}
+	}
+	return version, nil
+}
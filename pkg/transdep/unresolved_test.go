@@ -0,0 +1,49 @@
+package transdep
+
+import (
+	"errors"
+	"testing"
+
+	"scanoss.com/dependencies/pkg/models"
+)
+
+func TestClassifyRequirement(t *testing.T) {
+	tests := []struct {
+		requirement string
+		want        string
+	}{
+		{requirement: "", want: ReasonAnyVersion},
+		{requirement: "*", want: ReasonAnyVersion},
+		{requirement: "x", want: ReasonAnyVersion},
+		{requirement: "latest", want: ReasonDistTag},
+		{requirement: "next", want: ReasonDistTag},
+		{requirement: "workspace:^", want: ReasonWorkspace},
+		{requirement: "file:../lib", want: ReasonLocalPath},
+		{requirement: "./vendor/lib", want: ReasonLocalPath},
+		{requirement: "git+https://github.com/scanoss/dependencies.git", want: ReasonGitReference},
+		{requirement: "github:scanoss/dependencies#main", want: ReasonGitReference},
+		{requirement: "scanoss/dependencies", want: ReasonGitReference},
+		{requirement: "https://registry.example.com/lib.tgz", want: ReasonRemoteURL},
+		{requirement: ">= 1.x", want: ReasonInvalidRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.requirement, func(t *testing.T) {
+			if got := ClassifyRequirement(tt.requirement); got != tt.want {
+				t.Errorf("ClassifyRequirement(%q) = %v, want %v", tt.requirement, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeResolver_ResolveVersion(t *testing.T) {
+	resolver := rangeResolver{}
+	version, err := resolver.ResolveVersion(DependencyJob{}, models.UnresolvedDependency{Purl: "lib", Requirement: "^1.2.3"})
+	if err != nil || version != "1.2.3" {
+		t.Errorf("ResolveVersion() = %v, %v, want 1.2.3", version, err)
+	}
+	_, err = resolver.ResolveVersion(DependencyJob{}, models.UnresolvedDependency{Purl: "lib", Requirement: "latest"})
+	var unresolvedErr *UnresolvedRequirementError
+	if !errors.As(err, &unresolvedErr) || unresolvedErr.Reason != ReasonDistTag {
+		t.Errorf("ResolveVersion() error = %v, want reason %v", err, ReasonDistTag)
+	}
+}
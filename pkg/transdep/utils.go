@@ -61,6 +61,11 @@ func ExtractPackageIdentifierFromPurl(purl string) (string, error) {
 		return fmt.Sprintf("%s/%s", p.Namespace, p.Name), nil
 	}
 
+	// For scoped npm packages, keep the scope (with its "@" encoded, as stored in the KB)
+	if p.Type == "npm" && p.Namespace != "" {
+		return fmt.Sprintf("%s/%s", strings.Replace(p.Namespace, "@", "%40", 1), p.Name), nil
+	}
+
 	// Return just the name component
 	return p.Name, nil
 }
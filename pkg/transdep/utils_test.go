@@ -430,6 +430,12 @@ func TestExtractPackageIdentifierFromPurl(t *testing.T) {
 			expected: "ai.databand/dbnd-api-deequ",
 			wantErr:  false,
 		},
+		{
+			name:     "get package identifier for scoped npm package",
+			input:    "pkg:npm/%40ourcorp/ui",
+			expected: "%40ourcorp/ui",
+			wantErr:  false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
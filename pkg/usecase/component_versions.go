@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"slices"
+
+	purlutils "github.com/scanoss/go-purl-helper/pkg"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/models"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// Page sizes of a component versions request.
+const (
+	defaultVersionsPageSize = 50
+	maxVersionsPageSize     = 1000
+)
+
+// GetComponentVersions returns a page of the versions of a component known to the KB, sorted newest first (following
+// the ordering rules of its ecosystem) and limited to those matching the requested version constraint.
+func (d DependencyUseCase) GetComponentVersions(request dtos.ComponentVersionsInput) (dtos.ComponentVersionsOutput, error) {
+	purl, err := purlutils.PurlFromString(request.Purl)
+	if err != nil {
+		return dtos.ComponentVersionsOutput{}, errors.NewBadRequestError("invalid purl", err)
+	}
+	purlName, err := purlutils.PurlNameFromString(request.Purl)
+	if err != nil {
+		return dtos.ComponentVersionsOutput{}, errors.NewBadRequestError("invalid purl", err)
+	}
+	if request.Page < 0 || request.PageSize < 0 || request.PageSize > maxVersionsPageSize {
+		return dtos.ComponentVersionsOutput{}, errors.NewBadRequestError("invalid page or page size", nil)
+	}
+	page, pageSize := max(request.Page, 1), request.PageSize
+	if pageSize == 0 {
+		pageSize = defaultVersionsPageSize
+	}
+	versions, err := d.allUrls.GetComponentVersions(purlName, purl.Type)
+	if err != nil {
+		return dtos.ComponentVersionsOutput{}, errors.NewInternalError("problem retrieving component versions", err)
+	}
+	if len(versions) == 0 {
+		return dtos.ComponentVersionsOutput{}, errors.NewNotFoundError("versions for the given component")
+	}
+	if len(request.Constraint) > 0 {
+		versions = slices.DeleteFunc(versions, func(v models.ComponentVersion) bool {
+			return !shared.VersionInRange(purl.Type, v.Version, request.Constraint)
+		})
+	}
+	slices.SortStableFunc(versions, func(a, b models.ComponentVersion) int {
+		return shared.CompareVersions(purl.Type, b.Version, a.Version)
+	})
+	output := dtos.ComponentVersionsOutput{Purl: "pkg:" + purl.Type + "/" + purlName, Total: len(versions),
+		Page: page, PageSize: pageSize, Versions: []dtos.ComponentVersionOutput{}}
+	start := len(versions)
+	if page-1 <= len(versions)/pageSize { // Pages past the end would overflow the multiplication
+		start = min((page-1)*pageSize, len(versions))
+	}
+	versions = versions[start:min(start+pageSize, len(versions))]
+	withDependencies := d.getDependencyVersions(purlName, purl.Type)
+	for _, version := range versions {
+		versionOutput := d.componentVersionOutput(version)
+		versionOutput.HasDependencies = withDependencies[version.Version]
+		output.Versions = append(output.Versions, versionOutput)
+	}
+	return output, nil
+}
+
+// componentVersionOutput converts the KB details of a component version, splitting its license into its parts.
+func (d DependencyUseCase) componentVersionOutput(version models.ComponentVersion) dtos.ComponentVersionOutput {
+	output := dtos.ComponentVersionOutput{Version: version.Version, Date: version.Date}
+	if len(version.License) > 0 {
+		output.Licenses = d.resolveLicenses(models.AllURL{License: version.License,
+			LicenseID: version.LicenseID, IsSpdx: version.IsSpdx})
+	}
+	return output
+}
+
+// getDependencyVersions returns the set of versions of a component with dependency data
+// (empty if its ecosystem has no dependency table).
+func (d DependencyUseCase) getDependencyVersions(purlName, purlType string) map[string]bool {
+	withDependencies := make(map[string]bool)
+	if _, supported := shared.RegisteredEcosystems[purlType]; !supported {
+		return withDependencies
+	}
+	dependencyModel := models.NewDependencyModel(d.ctx, d.s, d.db, nil, models.NewPrivatePackageModel(d.ctx, d.s, d.db, d.config))
+	versions, err := dependencyModel.GetDependencyVersions(purlName, purlType)
+	if err != nil {
+		d.s.Warnf("Problem looking up the dependency versions of %v - %v: %v", purlType, purlName, err)
+		return withDependencies
+	}
+	for _, version := range versions {
+		withDependencies[version] = true
+	}
+	return withDependencies
+}
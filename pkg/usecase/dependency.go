@@ -19,8 +19,14 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/jmoiron/sqlx"
 	componentHelper "github.com/scanoss/go-component-helper/componenthelper"
@@ -28,32 +34,75 @@ import (
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
 	purlutils "github.com/scanoss/go-purl-helper/pkg"
 	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/cache"
 	myconfig "scanoss.com/dependencies/pkg/config"
 	"scanoss.com/dependencies/pkg/dtos"
 	"scanoss.com/dependencies/pkg/models"
 )
 
 type DependencyUseCase struct {
-	ctx     context.Context
-	s       *zap.SugaredLogger
-	db      *sqlx.DB
-	allUrls *models.AllUrlsModel
-	lic     *models.LicenseModel
+	ctx      context.Context
+	s        *zap.SugaredLogger
+	db       *sqlx.DB
+	allUrls  *models.AllUrlsModel
+	lic      *models.LicenseModel
+	curation *models.CurationModel
+	private  *models.PrivatePackageModel
+	config   *myconfig.ServerConfig
+	pool     *DecorationPool
 }
 
 // NewDependencies creates a new instance of the Dependency Use Case.
-func NewDependencies(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, config *myconfig.ServerConfig) *DependencyUseCase {
+// Components are decorated using the slots of the given pool, shared by all requests (unlimited if nil).
+func NewDependencies(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, config *myconfig.ServerConfig,
+	pool *DecorationPool) *DependencyUseCase {
+	curation := models.NewCurationModel(ctx, s, db, config)
+	private := models.NewPrivatePackageModel(ctx, s, db, config)
 	return &DependencyUseCase{ctx: ctx, s: s,
 		db: db,
 		allUrls: models.NewAllURLModel(ctx, s, db, models.NewProjectModel(ctx, s, db),
 			models.NewGolangProjectModel(ctx, s, db, config),
 			models.NewMineModel(ctx, s, db),
 			database.NewDBSelectContext(s, db, nil, config.Database.Trace),
+			models.NewLiveMetadataModel(ctx, s, db, config),
+			curation,
+			private,
 		),
-		lic: models.NewLicenseModel(ctx, s, db),
+		lic:      models.NewLicenseModel(ctx, s, db),
+		curation: curation,
+		private:  private,
+		config:   config,
+		pool:     pool,
 	}
 }
 
+// DecorationPool caps the number of components decorated concurrently across all the requests sharing it.
+type DecorationPool struct {
+	slots chan struct{}
+}
+
+// NewDecorationPool creates a decoration pool with the given number of slots (at least one).
+func NewDecorationPool(size int) *DecorationPool {
+	return &DecorationPool{slots: make(chan struct{}, max(size, 1))}
+}
+
+// acquire waits for a free slot in the pool, returning the function that frees it.
+func (p *DecorationPool) acquire() func() {
+	if p == nil {
+		return func() {}
+	}
+	p.slots <- struct{}{}
+	return func() { <-p.slots }
+}
+
+// decorationCache keeps decorated components between requests (nil if response caching is disabled).
+var decorationCache atomic.Pointer[cache.DecorationCache]
+
+// SetDecorationCache enables caching of decorated components in the given cache (or disables it if nil).
+func SetDecorationCache(c *cache.DecorationCache) {
+	decorationCache.Store(c)
+}
+
 // GetDependencies takes the Dependency Input request, searches for component details and returns a Dependency Output struct.
 func (d DependencyUseCase) GetDependencies(request dtos.DependencyInput) (dtos.DependencyOutput, bool, error) {
 	var depFileOutputs []dtos.DependencyFileOutput
@@ -63,96 +112,218 @@ func (d DependencyUseCase) GetDependencies(request dtos.DependencyInput) (dtos.D
 		fileOutput.File = file.File
 		fileOutput.ID = "dependency"
 		fileOutput.Status = "pending"
-		var depOutputs []dtos.DependenciesOutput
 		d.s.Infof("Processing %v purls for %v...", len(file.Purls), file.File)
+		fileOutput.Dependencies = d.decorateComponents(file)
+		if request.Outdated {
+			d.addOutdatedReports(fileOutput.Dependencies)
+		}
+		if request.LicenseWarnings {
+			d.addLicenseWarnings(fileOutput.Dependencies)
+		}
+		depFileOutputs = append(depFileOutputs, fileOutput)
+	}
+	d.s.Debugf("Output dependencies: %v", depFileOutputs)
+	return dtos.DependencyOutput{Files: depFileOutputs}, false, nil
+}
 
-		var depOutput dtos.DependenciesOutput
-		processedComponents := componentHelper.GetComponentsVersion(componentHelper.ComponentVersionCfg{
-			MaxWorkers: 5,
+// decorateComponents runs each purl of the given file through the decoration pipeline (version resolution,
+// then URL and license lookup) on a pool of workers, returning the results in the order of the purls.
+// The URL details of all resolved components are looked up in a batch, between the two concurrent passes.
+// Each request uses up to Components.Workers workers, and all requests share the slots of the decoration pool.
+// Purls already decorated for the current KB snapshot and overlays are served from the decoration cache (if enabled).
+func (d DependencyUseCase) decorateComponents(file dtos.DependencyFileInput) []dtos.DependenciesOutput {
+	results := make([][]dtos.DependenciesOutput, len(file.Purls))
+	responseCache := decorationCache.Load()
+	var generation string
+	pending := make([]int, 0, len(file.Purls))
+	if responseCache != nil {
+		var err error
+		if generation, err = d.cacheGeneration(); err != nil {
+			d.s.Warnf("Skipping the decoration cache: %v", err)
+			responseCache = nil
+		}
+	}
+	for i, purl := range file.Purls {
+		if responseCache != nil && d.cacheable(purl.Purl) {
+			if output, found := responseCache.Get(generation, purl.Purl, purl.Requirement); found {
+				results[i] = []dtos.DependenciesOutput{output}
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+	if responseCache != nil {
+		d.s.Debugf("Found %v of %v purls in the decoration cache", len(file.Purls)-len(pending), len(file.Purls))
+	}
+	processed := make([][]componentHelper.Component, len(file.Purls))
+	d.runWorkers(len(pending), func(j int) {
+		i := pending[j]
+		processed[i] = componentHelper.GetComponentsVersion(componentHelper.ComponentVersionCfg{
+			MaxWorkers: 1,
 			DB:         d.db,
 			Ctx:        d.ctx,
 			S:          d.s,
-			Input:      file.Purls,
+			Input:      file.Purls[i : i+1],
 		})
-
+	})
+	var components []componentHelper.Component
+	for _, processedComponents := range processed {
 		for _, processedComponent := range processedComponents {
-			depOutput = dtos.DependenciesOutput{
-				Purl:        processedComponent.Purl,
-				Requirement: processedComponent.Requirement,
-				Version:     processedComponent.Version,
-				URL:         processedComponent.URL,
-				Component:   processedComponent.Name,
-				Status:      processedComponent.Status,
-			}
-			// avoid processing invalid components not found components
-			if processedComponent.Status.StatusCode == domain.InvalidPurl {
-				depOutput.Status = processedComponent.Status
-				depOutput.Component = processedComponent.Name
-				depOutputs = append(depOutputs, depOutput)
-				continue
+			if processedComponent.Status.StatusCode != domain.InvalidPurl {
+				components = append(components, processedComponent)
 			}
+		}
+	}
+	var batch models.URLBatch
+	if len(components) > 0 {
+		var err error
+		if batch, err = d.allUrls.GetURLsBatch(components); err != nil {
+			d.s.Warnf("Problem encountered looking up URLs in a batch for %v, looking them up one by one: %v", file.File, err)
+		}
+	}
+	d.runWorkers(len(pending), func(j int) {
+		i := pending[j]
+		complete := true
+		for _, processedComponent := range processed[i] {
+			output, found := d.decorateComponent(file.File, processedComponent, batch)
+			results[i] = append(results[i], output)
+			complete = complete && found
+		}
+		// Only cache complete decorations (not those hitting a lookup problem)
+		if responseCache != nil && complete && len(results[i]) == 1 && d.cacheable(file.Purls[i].Purl) {
+			responseCache.Set(generation, file.Purls[i].Purl, file.Purls[i].Requirement, results[i][0])
+		}
+	})
+	var depOutputs []dtos.DependenciesOutput
+	for _, result := range results {
+		depOutputs = append(depOutputs, result...)
+	}
+	return depOutputs
+}
 
-			// Look up component details (URL, license, version) from the all_urls table
-			url, err := d.allUrls.GetURLsByPurlString(processedComponent)
-			if err != nil {
-				d.s.Warnf("Problem encountered extracting URLs for: %v, %v - %v.", file.File, processedComponent.Purl, err)
-				depOutput.Status = domain.ComponentStatus{
-					Message:    "component not found",
-					StatusCode: domain.NoInfo,
-				}
-				depOutputs = append(depOutputs, depOutput)
-				continue
+// runWorkers calls work for each index up to count, on up to Components.Workers workers (each holding a decoration slot).
+func (d DependencyUseCase) runWorkers(count int, work func(i int)) {
+	indexes := make(chan int, count)
+	for i := range count {
+		indexes <- i
+	}
+	close(indexes)
+	var wg sync.WaitGroup
+	for range min(max(d.config.Components.Workers, 1), count) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				release := d.pool.acquire()
+				work(i)
+				release()
 			}
+		}()
+	}
+	wg.Wait()
+}
 
-			// Skip components with no license data available
-			if url.License == "" {
-				// Preserve the upstream status from go-component-helper
-				if processedComponent.Status.StatusCode != domain.Success {
-					depOutputs = append(depOutputs, depOutput)
-					continue
-				}
-				depOutput.Licenses = []dtos.DependencyLicense{}
-				depOutput.Status = domain.ComponentStatus{
-					StatusCode: domain.NoInfo,
-					Message:    "No license information found",
-				}
-				depOutputs = append(depOutputs, depOutput)
-				continue
-			}
+// decorateComponent looks up the URL and license details of a component whose version has been resolved
+// (from the given batch, if present). The second value is false if the details could not be looked up.
+func (d DependencyUseCase) decorateComponent(fileName string, processedComponent componentHelper.Component, batch models.URLBatch) (dtos.DependenciesOutput, bool) {
+	depOutput := dtos.DependenciesOutput{
+		Purl:        processedComponent.Purl,
+		Requirement: processedComponent.Requirement,
+		Version:     processedComponent.Version,
+		URL:         processedComponent.URL,
+		Component:   processedComponent.Name,
+		Status:      processedComponent.Status,
+	}
+	// avoid processing invalid components not found components
+	if processedComponent.Status.StatusCode == domain.InvalidPurl {
+		return depOutput, true
+	}
 
-			if len(url.Version) == 0 {
-				depOutput.Status = processedComponent.Status
-				depOutputs = append(depOutputs, depOutput)
-				continue
-			}
+	// Look up component details (URL, license, version) from the all_urls table
+	url, err := d.allUrls.GetURLsByPurlStringFromBatch(processedComponent, batch)
+	if err != nil {
+		d.s.Warnf("Problem encountered extracting URLs for: %v, %v - %v.", fileName, processedComponent.Purl, err)
+		depOutput.Status = domain.ComponentStatus{
+			Message:    "component not found",
+			StatusCode: domain.NoInfo,
+		}
+		return depOutput, false
+	}
 
-			depOutput.Version = url.Version
-			if processedComponent.Requirement != "" {
-				v := purlutils.GetVersionFromReqOperator(processedComponent.Requirement)
-				// Compare versions ignoring the "v" prefix (e.g., "v1.2.3" == "1.2.3")
-				// If the version does not satisfy the requirement, mark the status accordingly.
-				if strings.TrimPrefix(url.Version, "v") != strings.TrimPrefix(v, "v") {
-					depOutput.Status = domain.ComponentStatus{
-						StatusCode: domain.RequirementNotMet,
-						Message:    fmt.Sprintf("Requirement not met, showing information for version '%s'", url.Version),
-					}
-					depOutput.Version = v
-				}
-			}
+	depOutput.Comment = curationComment(url)
+	// Private packages are unknown to the KB, so their status comes from the private registry instead
+	if url.Private {
+		depOutput.Status = privateComponentStatus(url)
+	}
 
-			// Fall back to the component URL from the URL lookup if the output has no URL set
-			if len(depOutput.URL) == 0 && len(url.URL) > 0 {
-				depOutput.URL = url.URL
-			}
+	// Skip components with no license data available
+	if url.License == "" {
+		// Preserve the upstream status from go-component-helper (or the private registry)
+		if depOutput.Status.StatusCode != domain.Success {
+			return depOutput, true
+		}
+		depOutput.Licenses = []dtos.DependencyLicense{}
+		depOutput.Status = domain.ComponentStatus{
+			StatusCode: domain.NoInfo,
+			Message:    d.noLicenseMessage(),
+		}
+		return depOutput, true
+	}
+
+	if len(url.Version) == 0 {
+		depOutput.Status = processedComponent.Status
+		return depOutput, true
+	}
 
-			depOutput.Licenses = d.resolveLicenses(url)
-			depOutputs = append(depOutputs, depOutput)
+	depOutput.Version = url.Version
+	if processedComponent.Requirement != "" {
+		v := purlutils.GetVersionFromReqOperator(processedComponent.Requirement)
+		// Compare versions ignoring the "v" prefix (e.g., "v1.2.3" == "1.2.3")
+		// If the version does not satisfy the requirement, mark the status accordingly.
+		if strings.TrimPrefix(url.Version, "v") != strings.TrimPrefix(v, "v") {
+			depOutput.Status = domain.ComponentStatus{
+				StatusCode: domain.RequirementNotMet,
+				Message:    fmt.Sprintf("Requirement not met, showing information for version '%s'", url.Version),
+			}
+			depOutput.Version = v
 		}
-		fileOutput.Dependencies = depOutputs
-		depFileOutputs = append(depFileOutputs, fileOutput)
 	}
-	d.s.Debugf("Output dependencies: %v", depFileOutputs)
-	return dtos.DependencyOutput{Files: depFileOutputs}, false, nil
+
+	// Fall back to the component URL from the URL lookup if the output has no URL set (or it was curated)
+	if (len(depOutput.URL) == 0 || slices.Contains(url.Curated, models.CuratedURL)) && len(url.URL) > 0 {
+		depOutput.URL = url.URL
+	}
+
+	depOutput.Licenses = d.resolveLicenses(url)
+	return depOutput, true
+}
+
+// curationComment describes the component details that were overridden by a curation (if any).
+func curationComment(url models.AllURL) string {
+	if len(url.Curated) == 0 {
+		return ""
+	}
+	comment := "Curated: " + strings.Join(url.Curated, ", ")
+	if len(url.CurationReasons) > 0 {
+		comment += " (" + strings.Join(url.CurationReasons, "; ") + ")"
+	}
+	return comment
+}
+
+// privateComponentStatus reports whether a private package was found in the private registry.
+func privateComponentStatus(url models.AllURL) domain.ComponentStatus {
+	if len(url.Version) == 0 {
+		return domain.ComponentStatus{StatusCode: domain.ComponentNotFound, Message: "component not found in the private registry"}
+	}
+	return domain.ComponentStatus{StatusCode: domain.Success}
+}
+
+// noLicenseMessage explains why no license information could be found for a component.
+func (d DependencyUseCase) noLicenseMessage() string {
+	if d.config.LiveMetadata.Offline {
+		return "No license information found (live lookup skipped: offline mode)"
+	}
+	return "No license information found"
 }
 
 // resolveLicenses resolves the license information for a component URL,
@@ -178,3 +349,27 @@ func (d DependencyUseCase) resolveLicenses(url models.AllURL) []dtos.DependencyL
 	}
 	return licenses
 }
+
+// cacheGeneration identifies the data decorated components are built from: the KB snapshot, the curations and the
+// private packages file. Live lookups can change at any time, so the decoration cache is not used while they are online.
+func (d DependencyUseCase) cacheGeneration() (string, error) {
+	if d.config.LiveMetadata.Enabled && !d.config.LiveMetadata.Offline {
+		return "", errors.New("live metadata lookups are enabled")
+	}
+	snapshot, err := models.NewKBMetadataModel(d.ctx, d.s, d.db).GetSnapshot()
+	if err != nil {
+		return "", fmt.Errorf("problem reading the knowledge base snapshot: %v", err)
+	}
+	curations, err := d.curation.Generation()
+	if err != nil {
+		return "", err
+	}
+	overlays := sha256.Sum256([]byte(curations + "|" + d.private.Generation()))
+	return snapshot + "|" + hex.EncodeToString(overlays[:8]), nil
+}
+
+// cacheable reports whether the decoration of the given purl can be cached. Golang components are looked up on
+// pkg.go.dev whenever the server is not offline (even with live metadata lookups disabled), so they are only cached then.
+func (d DependencyUseCase) cacheable(purl string) bool {
+	return d.config.LiveMetadata.Offline || !strings.HasPrefix(purl, "pkg:golang/")
+}
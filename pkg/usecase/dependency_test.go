@@ -19,7 +19,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/jmoiron/sqlx"
@@ -27,6 +29,7 @@ import (
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	_ "modernc.org/sqlite"
+	"scanoss.com/dependencies/pkg/cache"
 	myconfig "scanoss.com/dependencies/pkg/config"
 	"scanoss.com/dependencies/pkg/dtos"
 	"scanoss.com/dependencies/pkg/models"
@@ -86,7 +89,7 @@ func TestDependencyUseCase(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to load Config: %v", err)
 	}
-	depUc := NewDependencies(ctx, s, db, myConfig)
+	depUc := NewDependencies(ctx, s, db, myConfig, NewDecorationPool(myConfig.Components.MaxWorkers))
 	requestDto, err := dtos.ParseDependencyInput(s, []byte(depRequestData))
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when parsing input json", err)
@@ -170,7 +173,7 @@ func TestDependencyUseCaseOutput(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to load Config: %v", err)
 	}
-	depUc := NewDependencies(ctx, s, db, myConfig)
+	depUc := NewDependencies(ctx, s, db, myConfig, NewDecorationPool(myConfig.Components.MaxWorkers))
 
 	tests := []struct {
 		name           string
@@ -217,3 +220,147 @@ func TestDependencyUseCaseOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestDependencyUseCaseOrder(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared S", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	err = models.LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Components.Workers = 4
+	depUc := NewDependencies(ctx, s, db, myConfig, NewDecorationPool(myConfig.Components.MaxWorkers))
+	purls := []string{"pkg:npm/sort-paths", "pkg:npm/lodash", "pkg:npm/electron-debug", "pkg:npm/isbinaryfile",
+		"pkg:deb/debian/goffice", "pkg:npm/%40grpc/grpc-js", "pkg:npm/scanoss", "pkg:npm/tar"}
+	input := dtos.DependencyFileInput{File: "package.json"}
+	for _, purl := range purls {
+		input.Purls = append(input.Purls, componenthelper.ComponentDTO{Purl: purl})
+	}
+	for run := 0; run < 3; run++ {
+		dependencies, _, err := depUc.GetDependencies(dtos.DependencyInput{Files: []dtos.DependencyFileInput{input}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := dependencies.Files[0].Dependencies
+		if len(got) != len(purls) {
+			t.Fatalf("expected %v dependencies, got %v", len(purls), len(got))
+		}
+		for i, dependency := range got {
+			if dependency.Purl != purls[i] {
+				t.Errorf("run %v: expected dependency %v to be %v, got %v", run, i, purls[i], dependency.Purl)
+			}
+		}
+	}
+}
+
+func TestDependencyUseCaseCache(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared S", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	err = models.LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.LiveMetadata.NpmURL = "http://127.0.0.1:0" // Never reach the real registry once live lookups are enabled
+	depUc := NewDependencies(ctx, s, db, myConfig, NewDecorationPool(myConfig.Components.MaxWorkers))
+	input := dtos.DependencyInput{Files: []dtos.DependencyFileInput{{File: "package.json", Purls: []componenthelper.ComponentDTO{
+		{Purl: "pkg:npm/isbinaryfile", Requirement: "^4.0.8"}, {Purl: "pkg:npm/sort-paths"}, {Purl: "pkg:npm/electron-debug"},
+	}}}}
+	uncached, _, err := depUc.GetDependencies(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	backend := cache.NewLRUBackend(100)
+	SetDecorationCache(cache.NewDecorationCache(s, backend, time.Hour))
+	defer SetDecorationCache(nil)
+	for run := 0; run < 2; run++ {
+		cached, _, err := depUc.GetDependencies(input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(cached, uncached) {
+			t.Errorf("run %v: expected the same output as without a cache, got %+v, want %+v", run, cached, uncached)
+		}
+	}
+	if backend.Len() != len(input.Files[0].Purls) {
+		t.Errorf("expected %v cached purls, got %v", len(input.Files[0].Purls), backend.Len())
+	}
+	// Cached decorations are served until the KB snapshot changes
+	if _, err = db.Exec("DELETE FROM all_urls"); err != nil {
+		t.Fatalf("failed to delete all urls: %v", err)
+	}
+	cached, _, err := depUc.GetDependencies(input)
+	if err != nil || !reflect.DeepEqual(cached, uncached) {
+		t.Errorf("expected the cached output, got %+v, err = %v", cached, err)
+	}
+	if err = models.NewKBMetadataModel(ctx, s, db).SetSnapshot("2026.11.01"); err != nil {
+		t.Fatalf("failed to set the KB snapshot: %v", err)
+	}
+	updated, _, err := depUc.GetDependencies(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reflect.DeepEqual(updated, uncached) {
+		t.Errorf("expected the output to be decorated again for the new KB snapshot, got %+v", updated)
+	}
+	// Curation changes are picked up straight away too
+	_, err = db.Exec("INSERT INTO curations (purl_type, purl_name, license, reason) VALUES ('npm', 'sort-paths', 'Apache-2.0', 'test')")
+	if err != nil {
+		t.Fatalf("failed to add a curation: %v", err)
+	}
+	curated, _, err := depUc.GetDependencies(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reflect.DeepEqual(curated, updated) {
+		t.Errorf("expected the output to be decorated again for the new curation, got %+v", curated)
+	}
+	// Live lookups can change at any time, so the cache is not used while they are online
+	myConfig.LiveMetadata.Enabled, myConfig.LiveMetadata.Offline = true, false
+	_, err = db.Exec("INSERT INTO curations (purl_type, purl_name, license, reason) VALUES ('npm', 'electron-debug', 'MIT', 'test')")
+	if err != nil {
+		t.Fatalf("failed to add a curation: %v", err)
+	}
+	cachedPurls := backend.Len()
+	if _, _, err = depUc.GetDependencies(input); err != nil || backend.Len() != cachedPurls {
+		t.Errorf("expected the cache not to be used with online live lookups (%v entries, was %v), err = %v",
+			backend.Len(), cachedPurls, err)
+	}
+	myConfig.LiveMetadata.Offline = true
+	if _, _, err = depUc.GetDependencies(input); err != nil || backend.Len() == cachedPurls {
+		t.Errorf("expected the cache to be used with offline live lookups, err = %v", err)
+	}
+	// Golang components are looked up on pkg.go.dev unless offline, even with live metadata lookups disabled
+	myConfig.LiveMetadata.Enabled, myConfig.LiveMetadata.Offline = false, false
+	if depUc.cacheable("pkg:golang/github.com/scanoss/papi") || !depUc.cacheable("pkg:npm/sort-paths") {
+		t.Errorf("expected only golang components not to be cached while online")
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	purlutils "github.com/scanoss/go-purl-helper/pkg"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// GetLicenseChanges returns every version of a component (within the requested range) whose license differs
+// from the license of the previous version, oldest first. Versions without a known license are skipped.
+func (d DependencyUseCase) GetLicenseChanges(request dtos.LicenseChangesInput) (dtos.LicenseChangesOutput, error) {
+	purl, err := purlutils.PurlFromString(request.Purl)
+	if err != nil {
+		return dtos.LicenseChangesOutput{}, errors.NewBadRequestError("invalid purl", err)
+	}
+	purlName, err := purlutils.PurlNameFromString(request.Purl)
+	if err != nil {
+		return dtos.LicenseChangesOutput{}, errors.NewBadRequestError("invalid purl", err)
+	}
+	if len(request.From) > 0 && len(request.To) > 0 && shared.CompareVersions(purl.Type, request.From, request.To) > 0 {
+		return dtos.LicenseChangesOutput{}, errors.NewBadRequestError("from version is newer than the to version", nil)
+	}
+	versions, err := d.allUrls.GetComponentVersions(purlName, purl.Type)
+	if err != nil {
+		return dtos.LicenseChangesOutput{}, errors.NewInternalError("problem retrieving component versions", err)
+	}
+	if len(versions) == 0 {
+		return dtos.LicenseChangesOutput{}, errors.NewNotFoundError("versions for the given component")
+	}
+	var selected []dtos.ComponentVersionOutput
+	for _, version := range versions {
+		switch {
+		case len(request.Constraint) > 0 && !shared.VersionInRange(purl.Type, version.Version, request.Constraint):
+		case len(request.From) > 0 && shared.CompareVersions(purl.Type, version.Version, request.From) < 0:
+		case len(request.To) > 0 && shared.CompareVersions(purl.Type, version.Version, request.To) > 0:
+		default:
+			selected = append(selected, d.componentVersionOutput(version))
+		}
+	}
+	changes, compared := licenseChanges(purl.Type, selected)
+	return dtos.LicenseChangesOutput{Purl: "pkg:" + purl.Type + "/" + purlName, Versions: compared, Changes: changes}, nil
+}
+
+// licenseChanges sorts the given versions (oldest first) and returns those whose license differs from the license
+// of the previous version, along with the number of versions with a known license compared.
+func licenseChanges(purlType string, versions []dtos.ComponentVersionOutput) ([]dtos.LicenseChangeOutput, int) {
+	versions = slices.DeleteFunc(slices.Clone(versions), func(v dtos.ComponentVersionOutput) bool {
+		return len(licenseKey(v.Licenses)) == 0
+	})
+	slices.SortStableFunc(versions, func(a, b dtos.ComponentVersionOutput) int {
+		return shared.CompareVersions(purlType, a.Version, b.Version)
+	})
+	changes := []dtos.LicenseChangeOutput{}
+	for i := 1; i < len(versions); i++ {
+		previous, current := versions[i-1], versions[i]
+		if licenseKey(previous.Licenses) != licenseKey(current.Licenses) {
+			changes = append(changes, dtos.LicenseChangeOutput{Version: current.Version, Date: current.Date,
+				Licenses: current.Licenses, PreviousVersion: previous.Version, PreviousLicenses: previous.Licenses})
+		}
+	}
+	return changes, len(versions)
+}
+
+// addLicenseWarnings flags each decorated dependency whose license differs from the license of the latest
+// (non pre-release) version of its component. The versions of each component are only looked up once.
+func (d DependencyUseCase) addLicenseWarnings(dependencies []dtos.DependenciesOutput) {
+	components, indexes := groupComponents(dependencies)
+	d.runWorkers(len(components), func(j int) {
+		key := components[j]
+		versions, err := d.allUrls.GetComponentVersions(key.purlName, key.purlType)
+		if err != nil {
+			d.s.Warnf("Problem looking up the versions of %v - %v: %v", key.purlType, key.purlName, err)
+			return
+		}
+		outputs := make([]dtos.ComponentVersionOutput, 0, len(versions))
+		for _, version := range versions {
+			outputs = append(outputs, d.componentVersionOutput(version))
+		}
+		for _, i := range indexes[key] {
+			dependencies[i].LicenseWarning = licenseWarning(key.purlType, dependencies[i].Version, dependencies[i].Licenses, outputs)
+		}
+	})
+}
+
+// licenseWarning compares the licenses of a dependency with the licenses of the latest version of its component
+// with a known license (ignoring pre-releases, unless there are no other versions).
+// Returns nil if they match, or either license is unknown.
+func licenseWarning(purlType, version string, licenses []dtos.DependencyLicense, versions []dtos.ComponentVersionOutput) *dtos.LicenseWarningOutput {
+	if len(licenseKey(licenses)) == 0 {
+		return nil
+	}
+	var latest *dtos.ComponentVersionOutput
+	for _, preReleases := range []bool{false, true} {
+		for i, v := range versions {
+			if len(licenseKey(v.Licenses)) == 0 || (!preReleases && shared.IsPreRelease(purlType, v.Version)) {
+				continue
+			}
+			if latest == nil || shared.CompareVersions(purlType, v.Version, latest.Version) > 0 {
+				latest = &versions[i]
+			}
+		}
+		if latest != nil {
+			break
+		}
+	}
+	if latest == nil || latest.Version == version || licenseKey(latest.Licenses) == licenseKey(licenses) {
+		return nil
+	}
+	return &dtos.LicenseWarningOutput{LatestVersion: latest.Version, LatestLicenses: latest.Licenses,
+		Message: fmt.Sprintf("License %v differs from the license of the latest version %v (%v)",
+			licenseIDs(licenses), latest.Version, licenseIDs(latest.Licenses))}
+}
+
+// licenseKey returns a normalised (case-insensitive, sorted, de-duplicated) representation of a set of licenses,
+// for comparison. Empty if no license is known.
+func licenseKey(licenses []dtos.DependencyLicense) string {
+	return strings.ToLower(licenseIDs(licenses))
+}
+
+// licenseIDs returns the sorted, de-duplicated SPDX IDs (or names) of a set of licenses, separated by slashes.
+func licenseIDs(licenses []dtos.DependencyLicense) string {
+	var ids []string
+	for _, license := range licenses {
+		id := strings.TrimSpace(license.SpdxID)
+		if len(id) == 0 {
+			id = strings.TrimSpace(license.Name)
+		}
+		if len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	ids = slices.CompactFunc(ids, strings.EqualFold)
+	return strings.Join(ids, "/")
+}
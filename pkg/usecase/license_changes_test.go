@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-component-helper/componenthelper"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/dependencies/pkg/config"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/models"
+)
+
+func TestLicenseChanges(t *testing.T) {
+	apache := []dtos.DependencyLicense{{Name: "Apache License 2.0", SpdxID: "Apache-2.0", IsSpdx: true}}
+	busl := []dtos.DependencyLicense{{Name: "Business Source License 1.1", SpdxID: "BUSL-1.1"}}
+	dual := []dtos.DependencyLicense{{SpdxID: "MIT"}, {SpdxID: "Apache-2.0"}}
+	tests := []struct {
+		name         string
+		versions     []dtos.ComponentVersionOutput
+		wantChanges  []dtos.LicenseChangeOutput
+		wantCompared int
+	}{
+		{name: "relicensed", versions: []dtos.ComponentVersionOutput{
+			{Version: "1.10.0", Licenses: busl}, {Version: "1.2.0", Licenses: apache}, {Version: "1.9.0", Licenses: apache}},
+			wantChanges:  []dtos.LicenseChangeOutput{{Version: "1.10.0", Licenses: busl, PreviousVersion: "1.9.0", PreviousLicenses: apache}},
+			wantCompared: 3},
+		{name: "unknown licenses skipped", versions: []dtos.ComponentVersionOutput{
+			{Version: "1.0.0", Licenses: apache}, {Version: "1.1.0"}, {Version: "1.2.0", Licenses: apache}},
+			wantChanges: []dtos.LicenseChangeOutput{}, wantCompared: 2},
+		{name: "license order ignored", versions: []dtos.ComponentVersionOutput{
+			{Version: "1.0.0", Licenses: dual}, {Version: "1.1.0", Licenses: []dtos.DependencyLicense{{SpdxID: "apache-2.0"}, {SpdxID: "MIT"}}}},
+			wantChanges: []dtos.LicenseChangeOutput{}, wantCompared: 2},
+		{name: "changed back", versions: []dtos.ComponentVersionOutput{
+			{Version: "1.0.0", Licenses: apache}, {Version: "2.0.0", Licenses: busl}, {Version: "3.0.0", Licenses: apache}},
+			wantChanges: []dtos.LicenseChangeOutput{
+				{Version: "2.0.0", Licenses: busl, PreviousVersion: "1.0.0", PreviousLicenses: apache},
+				{Version: "3.0.0", Licenses: apache, PreviousVersion: "2.0.0", PreviousLicenses: busl}},
+			wantCompared: 3},
+		{name: "no versions", wantChanges: []dtos.LicenseChangeOutput{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, compared := licenseChanges("npm", tt.versions)
+			if !reflect.DeepEqual(changes, tt.wantChanges) || compared != tt.wantCompared {
+				t.Errorf("licenseChanges() = %+v, %v, want %+v, %v", changes, compared, tt.wantChanges, tt.wantCompared)
+			}
+		})
+	}
+}
+
+func TestLicenseWarning(t *testing.T) {
+	mit := []dtos.DependencyLicense{{Name: "MIT", SpdxID: "MIT", IsSpdx: true}}
+	busl := []dtos.DependencyLicense{{Name: "Business Source License 1.1", SpdxID: "BUSL-1.1"}}
+	versions := []dtos.ComponentVersionOutput{
+		{Version: "1.0.0", Licenses: mit}, {Version: "2.0.0", Licenses: busl}, {Version: "3.0.0-rc.1", Licenses: mit}, {Version: "3.0.0"},
+	}
+	tests := []struct {
+		name     string
+		version  string
+		licenses []dtos.DependencyLicense
+		versions []dtos.ComponentVersionOutput
+		want     *dtos.LicenseWarningOutput
+	}{
+		{name: "differs from latest", version: "1.0.0", licenses: mit, versions: versions,
+			want: &dtos.LicenseWarningOutput{LatestVersion: "2.0.0", LatestLicenses: busl,
+				Message: "License MIT differs from the license of the latest version 2.0.0 (BUSL-1.1)"}},
+		{name: "same as latest", version: "1.5.0", licenses: busl, versions: versions},
+		{name: "latest version", version: "2.0.0", licenses: mit, versions: versions},
+		{name: "unknown license", version: "1.0.0", versions: versions},
+		{name: "only pre-releases", version: "1.0.0", licenses: busl,
+			versions: []dtos.ComponentVersionOutput{{Version: "2.0.0-beta.1", Licenses: mit}},
+			want: &dtos.LicenseWarningOutput{LatestVersion: "2.0.0-beta.1", LatestLicenses: mit,
+				Message: "License BUSL-1.1 differs from the license of the latest version 2.0.0-beta.1 (MIT)"}},
+		{name: "no versions", version: "1.0.0", licenses: mit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := licenseWarning("npm", tt.version, tt.licenses, tt.versions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("licenseWarning() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyUseCaseLicenseChanges(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared S", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	err = models.LoadTestSQLData(db, ctx, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when loading test data", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	// Relicense isbinaryfile from version 4.0.0
+	myConfig.Curation.File = filepath.Join(t.TempDir(), "curations.json")
+	curations := `[{"purl": "pkg:npm/isbinaryfile", "version_range": ">=4.0.0", "license": "Apache-2.0"}]`
+	if err = os.WriteFile(myConfig.Curation.File, []byte(curations), 0o600); err != nil {
+		t.Fatalf("failed to write curation file: %v", err)
+	}
+	depUc := NewDependencies(ctx, s, db, myConfig, NewDecorationPool(myConfig.Components.MaxWorkers))
+
+	output, err := depUc.GetLicenseChanges(dtos.LicenseChangesInput{Purl: "pkg:npm/isbinaryfile", From: "3.0.0", To: "4.0.8"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(output.Changes) != 1 || output.Changes[0].Version != "4.0.0" || output.Changes[0].PreviousVersion != "4.0.0-rc1" ||
+		licenseIDs(output.Changes[0].Licenses) != "Apache-2.0" || licenseIDs(output.Changes[0].PreviousLicenses) != "MIT" {
+		t.Errorf("expected a single change from MIT to Apache-2.0 in 4.0.0, got %+v", output)
+	}
+	output, err = depUc.GetLicenseChanges(dtos.LicenseChangesInput{Purl: "pkg:npm/isbinaryfile", Constraint: "^4.0.0"})
+	if err != nil || len(output.Changes) != 0 || output.Versions == 0 {
+		t.Errorf("expected no changes within 4.x, got %+v, %v", output, err)
+	}
+	if _, err = depUc.GetLicenseChanges(dtos.LicenseChangesInput{Purl: "pkg:npm/isbinaryfile", From: "4.0.0", To: "3.0.0"}); err == nil {
+		t.Errorf("expected an error for a from version newer than the to version")
+	}
+	if _, err = depUc.GetLicenseChanges(dtos.LicenseChangesInput{Purl: "pkg:npm/not-a-real-package-xyz"}); err == nil {
+		t.Errorf("expected an error for an unknown component")
+	}
+
+	dependencies, _, err := depUc.GetDependencies(dtos.DependencyInput{LicenseWarnings: true, Files: []dtos.DependencyFileInput{
+		{File: "package.json", Purls: []componenthelper.ComponentDTO{{Purl: "pkg:npm/isbinaryfile@3.0.2"}, {Purl: "pkg:npm/isbinaryfile@4.0.6"}}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	old, current := dependencies.Files[0].Dependencies[0], dependencies.Files[0].Dependencies[1]
+	if old.LicenseWarning == nil || old.LicenseWarning.LatestVersion != "4.0.8" || licenseIDs(old.LicenseWarning.LatestLicenses) != "Apache-2.0" {
+		t.Errorf("expected a warning that 4.0.8 is licensed under Apache-2.0, got %+v", old.LicenseWarning)
+	}
+	if current.LicenseWarning != nil {
+		t.Errorf("expected no warning for 4.0.6, got %+v", current.LicenseWarning)
+	}
+}
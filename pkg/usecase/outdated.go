@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"slices"
+
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/domain"
+	purlutils "github.com/scanoss/go-purl-helper/pkg"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/shared"
+)
+
+// componentKey identifies a component (regardless of its version).
+type componentKey struct{ purlType, purlName string }
+
+// groupComponents returns the distinct components of the given decorated dependencies (in order of appearance),
+// along with the indexes of the dependencies of each one. Invalid purls are skipped.
+func groupComponents(dependencies []dtos.DependenciesOutput) ([]componentKey, map[componentKey][]int) {
+	indexes := make(map[componentKey][]int)
+	var components []componentKey
+	for i, dependency := range dependencies {
+		if dependency.Status.StatusCode == domain.InvalidPurl {
+			continue
+		}
+		purl, err := purlutils.PurlFromString(dependency.Purl)
+		if err != nil {
+			continue
+		}
+		purlName, err := purlutils.PurlNameFromString(dependency.Purl)
+		if err != nil {
+			continue
+		}
+		key := componentKey{purlType: purl.Type, purlName: purlName}
+		if _, found := indexes[key]; !found {
+			components = append(components, key)
+		}
+		indexes[key] = append(indexes[key], i)
+	}
+	return components, indexes
+}
+
+// addOutdatedReports works out how far each decorated dependency is behind the latest versions known to the KB.
+// The versions of each component are only looked up once, on the decoration worker pool.
+func (d DependencyUseCase) addOutdatedReports(dependencies []dtos.DependenciesOutput) {
+	components, indexes := groupComponents(dependencies)
+	d.runWorkers(len(components), func(j int) {
+		key := components[j]
+		versions, err := d.allUrls.GetVersionsByPurlNameType(key.purlName, key.purlType)
+		if err != nil {
+			d.s.Warnf("Problem looking up the versions of %v - %v: %v", key.purlType, key.purlName, err)
+			return
+		}
+		for _, i := range indexes[key] {
+			dependencies[i].Outdated = outdatedReport(key.purlType, dependencies[i].Version, dependencies[i].Requirement, versions)
+		}
+	})
+}
+
+// outdatedReport compares a version (and its requirement) with the known versions of its component,
+// following the ordering rules of the ecosystem. Pre-releases are ignored, unless there are no other versions.
+// Returns nil if no versions are known.
+func outdatedReport(purlType, version, requirement string, versions []string) *dtos.OutdatedOutput {
+	releases := slices.DeleteFunc(slices.Clone(versions), func(v string) bool { return shared.IsPreRelease(purlType, v) })
+	if len(releases) == 0 {
+		releases = slices.Clone(versions)
+	}
+	if len(releases) == 0 {
+		return nil
+	}
+	slices.SortFunc(releases, func(a, b string) int { return shared.CompareVersions(purlType, a, b) })
+	report := &dtos.OutdatedOutput{Latest: releases[len(releases)-1]}
+	if len(requirement) > 0 {
+		for _, release := range slices.Backward(releases) {
+			if shared.VersionInRange(purlType, release, requirement) {
+				report.LatestSatisfying = release
+				break
+			}
+		}
+	}
+	if len(version) == 0 {
+		return report
+	}
+	report.Outdated = shared.CompareVersions(purlType, report.Latest, version) > 0
+	major, minor, patch, ok := shared.VersionNumbers(purlType, version)
+	if !ok {
+		return report
+	}
+	majors, minors, patches := map[int]bool{}, map[int]bool{}, map[int]bool{}
+	for _, release := range releases {
+		if shared.CompareVersions(purlType, release, version) <= 0 {
+			continue
+		}
+		releaseMajor, releaseMinor, releasePatch, ok := shared.VersionNumbers(purlType, release)
+		switch {
+		case !ok:
+		case releaseMajor > major:
+			majors[releaseMajor] = true
+		case releaseMajor == major && releaseMinor > minor:
+			minors[releaseMinor] = true
+		case releaseMajor == major && releaseMinor == minor && releasePatch > patch:
+			patches[releasePatch] = true
+		}
+	}
+	report.MajorsBehind, report.MinorsBehind, report.PatchesBehind = len(majors), len(minors), len(patches)
+	return report
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"scanoss.com/dependencies/pkg/dtos"
+)
+
+func TestOutdatedReport(t *testing.T) {
+	versions := []string{"1.0.0", "1.0.1", "1.0.2", "1.1.0", "1.2.0", "1.2.1", "2.0.0", "3.0.0", "3.1.0-rc.1"}
+	tests := []struct {
+		name        string
+		purlType    string
+		version     string
+		requirement string
+		versions    []string
+		want        *dtos.OutdatedOutput
+	}{
+		{name: "behind", purlType: "npm", version: "1.0.1", requirement: "^1.0.0", versions: versions,
+			want: &dtos.OutdatedOutput{Latest: "3.0.0", LatestSatisfying: "1.2.1", Outdated: true, MajorsBehind: 2, MinorsBehind: 2, PatchesBehind: 1}},
+		{name: "latest", purlType: "npm", version: "3.0.0", versions: versions, want: &dtos.OutdatedOutput{Latest: "3.0.0"}},
+		{name: "no version", purlType: "npm", requirement: "~1.0.0", versions: versions,
+			want: &dtos.OutdatedOutput{Latest: "3.0.0", LatestSatisfying: "1.0.2"}},
+		{name: "only pre-releases", purlType: "npm", version: "1.0.0-beta.1", versions: []string{"1.0.0-beta.2", "1.0.0-beta.1"},
+			want: &dtos.OutdatedOutput{Latest: "1.0.0-beta.2", Outdated: true}},
+		{name: "maven", purlType: "maven", version: "31.1-jre", versions: []string{"32.0.0-jre", "31.1-jre", "33.0-SNAPSHOT", "31.1.1-jre"},
+			want: &dtos.OutdatedOutput{Latest: "32.0.0-jre", Outdated: true, MajorsBehind: 1, PatchesBehind: 1}},
+		{name: "no versions", purlType: "npm", version: "1.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := outdatedReport(tt.purlType, tt.version, tt.requirement, tt.versions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("outdatedReport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
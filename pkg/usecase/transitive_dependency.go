@@ -64,7 +64,44 @@ func toJobCollection(s *zap.SugaredLogger, dto dtos.TransitiveDependencyDTO) (De
 	}, nil
 }
 
+// toPins converts the pins of a TransitiveDependencyDTO for its ecosystem.
+func toPins(dto dtos.TransitiveDependencyDTO) ([]transitiveDep.Pin, error) {
+	pins := make([]transitiveDep.Pin, 0, len(dto.Pins))
+	for _, pin := range dto.Pins {
+		converted, err := transitiveDep.NewPin(pin.Purl, pin.Version, pin.Exclude, dto.Ecosystem)
+		if err != nil {
+			return nil, errors.NewBadRequestError(err.Error(), nil)
+		}
+		pins = append(pins, converted)
+	}
+	return pins, nil
+}
+
+// TransitiveDependencyResult holds the outcome of a transitive dependency collection.
+type TransitiveDependencyResult struct {
+	// Dependencies is the raw set of dependencies found in the graph (excluding the entry dependencies).
+	Dependencies []transitiveDep.Dependency
+	// Resolved is the effective set the ecosystem's package manager would install.
+	Resolved []transitiveDep.Dependency
+	// Strategy is the name of the conflict resolution strategy used to build Resolved.
+	Strategy string
+	// Unresolved lists the declared dependencies whose requirement could not be resolved to a version.
+	Unresolved []transitiveDep.UnresolvedDependency
+	// Curated lists the dependencies that were added to the graph by a curation.
+	Curated []transitiveDep.Dependency
+	// Completion reports how the collection ended (i.e. complete or limit_reached).
+	Completion string
+	// Graph is the collected dependency graph, including the entry dependencies.
+	Graph *transitiveDep.DependencyGraph
+	// Entries lists the entry dependencies the graph was collected from.
+	Entries []transitiveDep.Dependency
+}
+
 type TransitiveDependencyUseCase struct {
+	// ProgressHandler (optional) is called as the dependency collection progresses.
+	ProgressHandler func(transitiveDep.CollectorProgress)
+	// Overrides (optional) pins packages, keyed by purl name, to a version or constraint wherever they appear.
+	Overrides       map[string]string
 	ctx             context.Context
 	S               *zap.SugaredLogger
 	db              *sqlx.DB
@@ -74,36 +111,75 @@ type TransitiveDependencyUseCase struct {
 
 // NewTransitiveDependencies creates a new instance of the Dependency Use Case.
 func NewTransitiveDependencies(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, config *myconfig.ServerConfig) *TransitiveDependencyUseCase {
+	dependencyModel := models.NewDependencyModel(ctx, s, db, models.NewCurationModel(ctx, s, db, config),
+		models.NewPrivatePackageModel(ctx, s, db, config))
 	return &TransitiveDependencyUseCase{
 		ctx:             ctx,
 		S:               s,
 		db:              db,
-		dependencyModel: models.NewDependencyModel(ctx, s, db),
+		dependencyModel: dependencyModel,
 		config:          config,
 	}
 }
 
-func (d TransitiveDependencyUseCase) createEntryDependenciesIndex(dependencyJobs []transitiveDep.DependencyJob) map[string]struct{} {
-	inputSet := make(map[string]struct{})
+func (d TransitiveDependencyUseCase) createEntryDependencies(dependencyJobs []transitiveDep.DependencyJob) []transitiveDep.Dependency {
+	entryDependencies := make([]transitiveDep.Dependency, 0, len(dependencyJobs))
 	for _, dj := range dependencyJobs {
 		dep, err := transitiveDep.ExtractDependencyFromJob(dj)
 		if err != nil {
 			d.S.Errorf("failed to convert dependency:%v, %v", dj, err)
 			continue
 		}
+		entryDependencies = append(entryDependencies, dep)
+	}
+	return entryDependencies
+}
+
+func (d TransitiveDependencyUseCase) createEntryDependenciesIndex(entryDependencies []transitiveDep.Dependency) map[string]struct{} {
+	inputSet := make(map[string]struct{})
+	for _, dep := range entryDependencies {
 		inputSet[dep.Purl+"@"+dep.Version] = struct{}{}
 	}
 	return inputSet
 }
 
+// excludeEntryDependencies filters out the dependencies that were supplied as input.
+func excludeEntryDependencies(dependencies []transitiveDep.Dependency, entryDependenciesIndex map[string]struct{}) []transitiveDep.Dependency {
+	var filtered []transitiveDep.Dependency
+	for _, dep := range dependencies {
+		if _, ok := entryDependenciesIndex[dep.Purl+"@"+dep.Version]; !ok {
+			filtered = append(filtered, dep)
+		}
+	}
+	return filtered
+}
+
 // GetTransitiveDependencies takes the Dependency Input request, searches for component details and returns a Dependency Output struct.
-func (d TransitiveDependencyUseCase) GetTransitiveDependencies(s *zap.SugaredLogger, transitiveDependencyDTO dtos.TransitiveDependencyDTO) ([]transitiveDep.Dependency, error) {
+func (d TransitiveDependencyUseCase) GetTransitiveDependencies(s *zap.SugaredLogger, transitiveDependencyDTO dtos.TransitiveDependencyDTO) (TransitiveDependencyResult, error) {
+	return d.StreamTransitiveDependencies(s, transitiveDependencyDTO, nil)
+}
+
+// StreamTransitiveDependencies behaves like GetTransitiveDependencies, also reporting each node, edge and unresolved
+// requirement to the given handler as soon as it is discovered. Nodes for the entry dependencies are not reported.
+// The handler is called from a single goroutine.
+func (d TransitiveDependencyUseCase) StreamTransitiveDependencies(s *zap.SugaredLogger, transitiveDependencyDTO dtos.TransitiveDependencyDTO,
+	onEvent func(transitiveDep.GraphEvent)) (TransitiveDependencyResult, error) {
 	jobCollection, err := toJobCollection(s, transitiveDependencyDTO)
 	if err != nil {
-		return nil, err
+		return TransitiveDependencyResult{}, err
+	}
+	pins, err := toPins(transitiveDependencyDTO)
+	if err != nil {
+		return TransitiveDependencyResult{}, err
+	}
+	// The collector pins overridden entry dependencies too, so they are indexed at their pinned versions
+	jobCollection.DependencyJobs, err = transitiveDep.ApplyOverrides(jobCollection.DependencyJobs, d.Overrides)
+	if err != nil {
+		return TransitiveDependencyResult{}, errors.NewBadRequestError(err.Error(), nil)
 	}
 	depGraph := transitiveDep.NewDepGraph()
-	entryDependenciesIndex := d.createEntryDependenciesIndex(jobCollection.DependencyJobs)
+	entryDependencies := d.createEntryDependencies(jobCollection.DependencyJobs)
+	entryDependenciesIndex := d.createEntryDependenciesIndex(entryDependencies)
 	// Increase the max response size to account for entry dependencies that will be filtered out later
 	responseSize := jobCollection.ResponseLimit + len(entryDependenciesIndex)
 	dependencyCollectorCfg := transitiveDep.DependencyCollectorCfg{
@@ -111,32 +187,57 @@ func (d TransitiveDependencyUseCase) GetTransitiveDependencies(s *zap.SugaredLog
 		MaxQueueLimit: responseSize,
 		TimeOut:       d.config.TransitiveResources.TimeOut,
 	}
+	var eventHandler func(transitiveDep.GraphEvent)
+	if onEvent != nil {
+		eventHandler = func(event transitiveDep.GraphEvent) {
+			if _, isEntry := entryDependenciesIndex[event.Dependency.Purl+"@"+event.Dependency.Version]; isEntry &&
+				event.Type == transitiveDep.GraphEventNode {
+				return
+			}
+			onEvent(event)
+		}
+	}
 	transitiveDependencyCollector := transitiveDep.NewDependencyCollector(
 		d.ctx,
-		transitiveDep.ProcessCollectorResult(d.S, depGraph, responseSize),
+		transitiveDep.StreamCollectorResult(d.S, depGraph, responseSize, eventHandler),
 		dependencyCollectorCfg,
-		models.NewDependencyModel(d.ctx, d.S, d.db),
+		d.dependencyModel,
 		d.S)
+	transitiveDependencyCollector.ProgressHandler = d.ProgressHandler
+	transitiveDependencyCollector.SetOverrides(d.Overrides)
+	transitiveDependencyCollector.SetPins(pins)
+	if transitiveDependencyDTO.Ecosystem == "maven" {
+		transitiveDependencyCollector.RegisterRequirementResolver("maven",
+			transitiveDep.NewMavenResolver(models.NewMavenMetadataModel(d.ctx, d.S, d.db), d.S))
+	}
 
 	err = transitiveDependencyCollector.InitJobs(jobCollection.DependencyJobs)
 	if err != nil {
 		s.Errorf("Error initializing transitive dependencies jobs: %v", err)
 		// Default to internal error for unknown errors
-		return nil, errors.NewInternalError("failed to initialize dependency jobs", err)
+		return TransitiveDependencyResult{}, errors.NewInternalError("failed to initialize dependency jobs", err)
 	}
 
 	transitiveDependencyCollector.Start()
-	var transitiveDependencies []transitiveDep.Dependency
-	for _, dep := range depGraph.Flatten() {
-		if _, ok := entryDependenciesIndex[dep.Purl+"@"+dep.Version]; !ok {
-			transitiveDependencies = append(transitiveDependencies, dep)
-		}
-	}
+	transitiveDependencies := excludeEntryDependencies(depGraph.Flatten(), entryDependenciesIndex)
+	unresolved := depGraph.Unresolved()
 
-	// Check if we found any dependencies
-	if len(transitiveDependencies) == 0 {
-		return nil, errors.NewNotFoundError("transitive dependencies for the given components")
+	// Check if we found any dependencies (resolved or not)
+	if len(transitiveDependencies) == 0 && len(unresolved) == 0 {
+		return TransitiveDependencyResult{}, errors.NewNotFoundError("transitive dependencies for the given components")
 	}
+	// Work out what the ecosystem's package manager would actually install
+	strategy := transitiveDep.GetResolutionStrategy(transitiveDependencyDTO.Ecosystem)
+	resolved := excludeEntryDependencies(strategy.Resolve(depGraph, entryDependencies), entryDependenciesIndex)
 
-	return transitiveDependencies, nil
+	return TransitiveDependencyResult{
+		Dependencies: transitiveDependencies,
+		Resolved:     resolved,
+		Strategy:     strategy.Name(),
+		Unresolved:   unresolved,
+		Curated:      depGraph.Curated(),
+		Completion:   transitiveDependencyCollector.Completion(),
+		Graph:        depGraph,
+		Entries:      entryDependencies,
+	}, nil
 }
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	componentHelper "github.com/scanoss/go-component-helper/componenthelper"
+	purlutils "github.com/scanoss/go-purl-helper/pkg"
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+)
+
+// DiffTransitiveDependencies collects the transitive dependencies of the "before" and "after" component sets
+// (concurrently), and compares the sets their package managers would resolve. Each version added, removed or
+// changed is decorated with its licenses. An empty set, or one without any transitive dependencies, is compared as empty.
+func (d TransitiveDependencyUseCase) DiffTransitiveDependencies(s *zap.SugaredLogger, before, after dtos.TransitiveDependencyDTO) (dtos.TransitiveDiffOutput, error) {
+	results, err := collectTransitivePair(s, [2]TransitiveDependencyUseCase{d, d}, [2]dtos.TransitiveDependencyDTO{before, after})
+	if err != nil {
+		return dtos.TransitiveDiffOutput{}, err
+	}
+	diff := transitiveDep.DiffDependencies(results[0].Resolved, results[1].Resolved)
+	var versions []transitiveDep.Dependency
+	for _, changes := range [][]transitiveDep.DependencyChange{diff.Added, diff.Removed, diff.Changed} {
+		for _, change := range changes {
+			for _, version := range slices.Concat(change.Before, change.After) {
+				versions = append(versions, transitiveDep.Dependency{Purl: change.Purl, Version: version})
+			}
+		}
+	}
+	licenses := NewDependencies(d.ctx, d.S, d.db, d.config, nil).getDependencyLicenses(versions)
+	return diffOutput(results, diff, licenses), nil
+}
+
+// collectTransitivePair runs the given use cases on the given component sets concurrently. An empty set,
+// or one without any transitive dependencies, gives an empty (complete) result.
+func collectTransitivePair(s *zap.SugaredLogger, useCases [2]TransitiveDependencyUseCase,
+	sets [2]dtos.TransitiveDependencyDTO) ([2]TransitiveDependencyResult, error) {
+	var results [2]TransitiveDependencyResult
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i, dto := range sets {
+		if len(dto.Components) == 0 {
+			results[i] = TransitiveDependencyResult{Completion: transitiveDep.CollectionComplete}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = useCases[i].GetTransitiveDependencies(s, dto)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if serviceErr, ok := errors.GetServiceError(err); ok && serviceErr.GetHTTPCode() == http.StatusNotFound {
+			results[i] = TransitiveDependencyResult{Completion: transitiveDep.CollectionComplete}
+		} else if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// diffOutput converts the differences between two transitive dependency results, counting the license changes.
+func diffOutput(results [2]TransitiveDependencyResult, diff transitiveDep.DependencyDiff,
+	licenses map[transitiveDep.Dependency][]dtos.DependencyLicense) dtos.TransitiveDiffOutput {
+	output := dtos.TransitiveDiffOutput{
+		Added:    diffComponentOutputs(diff.Added, licenses),
+		Removed:  diffComponentOutputs(diff.Removed, licenses),
+		Changed:  diffComponentOutputs(diff.Changed, licenses),
+		Complete: results[0].Completion == transitiveDep.CollectionComplete && results[1].Completion == transitiveDep.CollectionComplete,
+	}
+	for _, changed := range output.Changed {
+		if changed.LicenseChanged {
+			output.LicenseChanges++
+		}
+	}
+	return output
+}
+
+// diffComponentOutputs converts the given package changes, flagging those whose set of (known) licenses changed
+// across all of their versions.
+func diffComponentOutputs(changes []transitiveDep.DependencyChange, licenses map[transitiveDep.Dependency][]dtos.DependencyLicense) []dtos.TransitiveDiffComponentOutput {
+	outputs := make([]dtos.TransitiveDiffComponentOutput, 0, len(changes))
+	for _, change := range changes {
+		output := dtos.TransitiveDiffComponentOutput{Purl: change.Purl}
+		beforeKeys, afterKeys := map[string]bool{}, map[string]bool{}
+		for _, version := range change.Before {
+			versionLicenses := licenses[transitiveDep.Dependency{Purl: change.Purl, Version: version}]
+			output.Before = append(output.Before, dtos.TransitiveDiffVersionOutput{Version: version, Licenses: versionLicenses})
+			beforeKeys[licenseKey(versionLicenses)] = true
+		}
+		for _, version := range change.After {
+			versionLicenses := licenses[transitiveDep.Dependency{Purl: change.Purl, Version: version}]
+			output.After = append(output.After, dtos.TransitiveDiffVersionOutput{Version: version, Licenses: versionLicenses})
+			afterKeys[licenseKey(versionLicenses)] = true
+		}
+		delete(beforeKeys, "") // Versions without a known license are not compared
+		delete(afterKeys, "")
+		output.LicenseChanged = len(beforeKeys) > 0 && len(afterKeys) > 0 && !maps.Equal(beforeKeys, afterKeys)
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+// getDependencyLicenses looks up the (curated) licenses of the given transitive dependencies, in a batch and then
+// on the decoration worker pool. Dependencies without a known license are left out.
+func (d DependencyUseCase) getDependencyLicenses(dependencies []transitiveDep.Dependency) map[transitiveDep.Dependency][]dtos.DependencyLicense {
+	components := make([]componentHelper.Component, len(dependencies))
+	for i, dependency := range dependencies {
+		purl, err := purlutils.PurlFromString(dependency.Purl)
+		if err != nil {
+			d.s.Warnf("Skipping license lookup of invalid purl %v: %v", dependency.Purl, err)
+			continue
+		}
+		purlName, err := purlutils.PurlNameFromString(dependency.Purl)
+		if err != nil {
+			d.s.Warnf("Skipping license lookup of invalid purl %v: %v", dependency.Purl, err)
+			continue
+		}
+		components[i] = componentHelper.Component{Name: purlName, PurlType: purl.Type, Version: dependency.Version}
+	}
+	batch, err := d.allUrls.GetURLsBatch(components)
+	if err != nil {
+		d.s.Warnf("Problem looking up dependency licenses in a batch: %v", err)
+	}
+	found := make([][]dtos.DependencyLicense, len(components))
+	d.runWorkers(len(components), func(i int) {
+		if len(components[i].Name) == 0 {
+			return
+		}
+		url, err := d.allUrls.GetURLsByPurlStringFromBatch(components[i], batch)
+		if err != nil {
+			d.s.Warnf("Problem looking up the licenses of %v@%v: %v", dependencies[i].Purl, dependencies[i].Version, err)
+			return
+		}
+		if len(url.License) > 0 {
+			found[i] = d.resolveLicenses(url)
+		}
+	})
+	licenses := make(map[transitiveDep.Dependency][]dtos.DependencyLicense, len(dependencies))
+	for i, dependency := range dependencies {
+		if len(found[i]) > 0 {
+			licenses[dependency] = found[i]
+		}
+	}
+	return licenses
+}
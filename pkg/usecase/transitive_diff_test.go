@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"testing"
+
+	"scanoss.com/dependencies/pkg/dtos"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+)
+
+func TestDiffComponentOutputs(t *testing.T) {
+	mit := []dtos.DependencyLicense{{Name: "MIT", SpdxID: "MIT", IsSpdx: true}}
+	busl := []dtos.DependencyLicense{{Name: "Business Source License 1.1", SpdxID: "BUSL-1.1"}}
+	licenses := map[transitiveDep.Dependency][]dtos.DependencyLicense{
+		{Purl: "pkg:npm/relicensed", Version: "1.0.0"}: mit,
+		{Purl: "pkg:npm/relicensed", Version: "2.0.0"}: busl,
+		{Purl: "pkg:npm/bumped", Version: "1.0.0"}:     mit,
+		{Purl: "pkg:npm/bumped", Version: "1.1.0"}:     mit,
+		{Purl: "pkg:npm/unknown", Version: "1.0.0"}:    mit,
+	}
+	tests := []struct {
+		name               string
+		change             transitiveDep.DependencyChange
+		wantLicenseChanged bool
+	}{
+		{name: "relicensed", change: transitiveDep.DependencyChange{Purl: "pkg:npm/relicensed", Before: []string{"1.0.0"}, After: []string{"2.0.0"}},
+			wantLicenseChanged: true},
+		{name: "same license", change: transitiveDep.DependencyChange{Purl: "pkg:npm/bumped", Before: []string{"1.0.0"}, After: []string{"1.1.0"}}},
+		{name: "unknown license", change: transitiveDep.DependencyChange{Purl: "pkg:npm/unknown", Before: []string{"1.0.0"}, After: []string{"2.0.0"}}},
+		{name: "added", change: transitiveDep.DependencyChange{Purl: "pkg:npm/relicensed", After: []string{"2.0.0"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs := diffComponentOutputs([]transitiveDep.DependencyChange{tt.change}, licenses)
+			if len(outputs) != 1 || len(outputs[0].Before) != len(tt.change.Before) || len(outputs[0].After) != len(tt.change.After) {
+				t.Fatalf("diffComponentOutputs() expected the versions of %v, got %+v", tt.change, outputs)
+			}
+			if outputs[0].LicenseChanged != tt.wantLicenseChanged {
+				t.Errorf("diffComponentOutputs() license changed = %v, want %v", outputs[0].LicenseChanged, tt.wantLicenseChanged)
+			}
+		})
+	}
+}
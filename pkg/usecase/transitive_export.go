@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/dtos"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+)
+
+// ExportTransitiveDependencies collects the transitive dependencies of the given components, and returns their graph
+// (including the requested components) ready to be exported, with the depth and licenses of each node.
+func (d TransitiveDependencyUseCase) ExportTransitiveDependencies(s *zap.SugaredLogger, dto dtos.TransitiveDependencyDTO) (transitiveDep.GraphExport, error) {
+	result, err := d.GetTransitiveDependencies(s, dto)
+	if err != nil {
+		return transitiveDep.GraphExport{}, err
+	}
+	licenses := NewDependencies(d.ctx, d.S, d.db, d.config, nil).getDependencyLicenses(result.Graph.Flatten())
+	return transitiveDep.NewGraphExport(result.Graph, result.Entries, func(dependency transitiveDep.Dependency) []string {
+		return licenseList(licenses[dependency])
+	}), nil
+}
+
+// licenseList returns the sorted, de-duplicated SPDX IDs (or names) of a set of licenses.
+func licenseList(licenses []dtos.DependencyLicense) []string {
+	ids := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		if id := licenseIDs([]dtos.DependencyLicense{license}); len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	return slices.CompactFunc(ids, strings.EqualFold)
+}
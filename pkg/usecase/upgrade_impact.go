@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	purlutils "github.com/scanoss/go-purl-helper/pkg"
+	"go.uber.org/zap"
+	"scanoss.com/dependencies/pkg/dtos"
+	"scanoss.com/dependencies/pkg/errors"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+)
+
+// SimulateUpgrade collects the transitive dependencies of the given components twice (concurrently): as requested, and
+// with the given packages (purl -> version or constraint) pinned wherever they appear in the graph. It returns the
+// differences between the resolved sets, and the policy impact of the overrides: the licenses gained and lost across
+// the whole resolved graph, and the package versions under a denied license that were introduced or resolved.
+func (d TransitiveDependencyUseCase) SimulateUpgrade(s *zap.SugaredLogger, dto dtos.TransitiveDependencyDTO,
+	overrides map[string]string, denyLicenses []string) (dtos.UpgradeImpactOutput, error) {
+	if len(overrides) == 0 {
+		return dtos.UpgradeImpactOutput{}, errors.NewBadRequestError("no overrides to simulate", nil)
+	}
+	simulated := d
+	simulated.Overrides = make(map[string]string, len(overrides))
+	for purl, override := range overrides {
+		override = strings.TrimSpace(override)
+		if len(override) == 0 {
+			return dtos.UpgradeImpactOutput{}, errors.NewBadRequestError(fmt.Sprintf("empty override for %v", purl), nil)
+		}
+		parsed, err := purlutils.PurlFromString(purl)
+		if err != nil {
+			return dtos.UpgradeImpactOutput{}, errors.NewBadRequestError(fmt.Sprintf("invalid override purl: %v", purl), err)
+		}
+		if parsed.Type != dto.Ecosystem {
+			return dtos.UpgradeImpactOutput{}, errors.NewBadRequestError(
+				fmt.Sprintf("override %v does not belong to the %v ecosystem", purl, dto.Ecosystem), nil)
+		}
+		purlName, err := transitiveDep.ExtractPackageIdentifierFromPurl(purl)
+		if err != nil {
+			return dtos.UpgradeImpactOutput{}, errors.NewBadRequestError(fmt.Sprintf("invalid override purl: %v", purl), err)
+		}
+		simulated.Overrides[purlName] = override
+	}
+	results, err := collectTransitivePair(s, [2]TransitiveDependencyUseCase{d, simulated}, [2]dtos.TransitiveDependencyDTO{dto, dto})
+	if err != nil {
+		return dtos.UpgradeImpactOutput{}, err
+	}
+	diff := transitiveDep.DiffDependencies(results[0].Resolved, results[1].Resolved)
+	// Policy impact covers the whole resolved graphs, so look up the licenses of every version in either
+	versions := slices.Concat(results[0].Resolved, results[1].Resolved)
+	slices.SortFunc(versions, compareDependencies)
+	versions = slices.Compact(versions)
+	licenses := NewDependencies(d.ctx, d.S, d.db, d.config, nil).getDependencyLicenses(versions)
+
+	output := dtos.UpgradeImpactOutput{
+		TransitiveDiffOutput: diffOutput(results, diff, licenses),
+		Overrides:            overrideOutputs(overrides, dto, results[1].Resolved),
+		PolicyImpact:         policyImpact(results[0].Resolved, results[1].Resolved, licenses, denyLicenses),
+	}
+	return output, nil
+}
+
+// compareDependencies orders dependencies by purl and then version.
+func compareDependencies(a, b transitiveDep.Dependency) int {
+	if c := strings.Compare(a.Purl, b.Purl); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Version, b.Version)
+}
+
+// overrideOutputs describes the requested overrides (sorted by purl), flagging those whose package was found among
+// the entry components or in the simulated graph.
+func overrideOutputs(overrides map[string]string, dto dtos.TransitiveDependencyDTO, resolved []transitiveDep.Dependency) []dtos.OverrideOutput {
+	found := make(map[string]bool, len(dto.Components)+len(resolved))
+	for _, component := range dto.Components {
+		if purlName, err := transitiveDep.ExtractPackageIdentifierFromPurl(component.Purl); err == nil {
+			found[purlName] = true
+		}
+	}
+	for _, dependency := range resolved {
+		if purlName, err := transitiveDep.ExtractPackageIdentifierFromPurl(dependency.Purl); err == nil {
+			found[purlName] = true
+		}
+	}
+	outputs := make([]dtos.OverrideOutput, 0, len(overrides))
+	for purl, override := range overrides {
+		purlName, _ := transitiveDep.ExtractPackageIdentifierFromPurl(purl)
+		outputs = append(outputs, dtos.OverrideOutput{Purl: purl, Override: strings.TrimSpace(override), Applied: found[purlName]})
+	}
+	slices.SortFunc(outputs, func(a, b dtos.OverrideOutput) int { return strings.Compare(a.Purl, b.Purl) })
+	return outputs
+}
+
+// policyImpact compares the licenses (SPDX IDs or names) found across the "before" and "after" resolved sets,
+// and the package versions under any of the denied licenses (matched case-insensitively).
+func policyImpact(before, after []transitiveDep.Dependency, licenses map[transitiveDep.Dependency][]dtos.DependencyLicense,
+	denyLicenses []string) dtos.PolicyImpactOutput {
+	denied := make(map[string]bool, len(denyLicenses))
+	for _, license := range denyLicenses {
+		if license = strings.ToLower(strings.TrimSpace(license)); len(license) > 0 {
+			denied[license] = true
+		}
+	}
+	beforeLicenses, beforeViolations := graphLicenses(before, licenses, denied)
+	afterLicenses, afterViolations := graphLicenses(after, licenses, denied)
+	return dtos.PolicyImpactOutput{
+		NewLicenses:          missingKeys(afterLicenses, beforeLicenses),
+		RemovedLicenses:      missingKeys(beforeLicenses, afterLicenses),
+		IntroducedViolations: missingViolations(afterViolations, beforeViolations),
+		ResolvedViolations:   missingViolations(beforeViolations, afterViolations),
+	}
+}
+
+// graphLicenses returns the licenses found in a resolved set (keyed by lowercased ID, as first seen),
+// and its versions under a denied license.
+func graphLicenses(dependencies []transitiveDep.Dependency, licenses map[transitiveDep.Dependency][]dtos.DependencyLicense,
+	denied map[string]bool) (map[string]string, map[dtos.PolicyViolationOutput]bool) {
+	found := map[string]string{}
+	violations := map[dtos.PolicyViolationOutput]bool{}
+	for _, dependency := range dependencies {
+		for _, license := range licenses[dependency] {
+			id := licenseIDs([]dtos.DependencyLicense{license})
+			if len(id) == 0 {
+				continue
+			}
+			key := strings.ToLower(id)
+			if _, seen := found[key]; !seen {
+				found[key] = id
+			}
+			if denied[key] || denied[strings.ToLower(strings.TrimSpace(license.Name))] {
+				violations[dtos.PolicyViolationOutput{Purl: dependency.Purl, Version: dependency.Version, License: id}] = true
+			}
+		}
+	}
+	return found, violations
+}
+
+// missingKeys returns the (sorted) values of a whose keys are not in b.
+func missingKeys(a, b map[string]string) []string {
+	missing := []string{}
+	for key, value := range a {
+		if _, found := b[key]; !found {
+			missing = append(missing, value)
+		}
+	}
+	slices.SortFunc(missing, func(x, y string) int { return strings.Compare(strings.ToLower(x), strings.ToLower(y)) })
+	return missing
+}
+
+// missingViolations returns the (sorted) violations in a that are not in b.
+func missingViolations(a, b map[dtos.PolicyViolationOutput]bool) []dtos.PolicyViolationOutput {
+	missing := []dtos.PolicyViolationOutput{}
+	for violation := range a {
+		if !b[violation] {
+			missing = append(missing, violation)
+		}
+	}
+	slices.SortFunc(missing, func(x, y dtos.PolicyViolationOutput) int {
+		if c := strings.Compare(x.Purl, y.Purl); c != 0 {
+			return c
+		}
+		if c := strings.Compare(x.Version, y.Version); c != 0 {
+			return c
+		}
+		return strings.Compare(x.License, y.License)
+	})
+	return missing
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2018-2026 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"scanoss.com/dependencies/pkg/dtos"
+	transitiveDep "scanoss.com/dependencies/pkg/transdep"
+)
+
+func TestPolicyImpact(t *testing.T) {
+	mit := []dtos.DependencyLicense{{Name: "MIT", SpdxID: "MIT", IsSpdx: true}}
+	gpl := []dtos.DependencyLicense{{Name: "GNU General Public License v3.0 only", SpdxID: "GPL-3.0-only", IsSpdx: true}}
+	apache := []dtos.DependencyLicense{{Name: "Apache License 2.0", SpdxID: "Apache-2.0", IsSpdx: true}}
+	licenses := map[transitiveDep.Dependency][]dtos.DependencyLicense{
+		{Purl: "pkg:npm/stable", Version: "1.0.0"}:     mit,
+		{Purl: "pkg:npm/relicensed", Version: "1.0.0"}: gpl,
+		{Purl: "pkg:npm/relicensed", Version: "2.0.0"}: apache,
+		{Purl: "pkg:npm/copyleft", Version: "1.0.0"}:   gpl,
+	}
+	before := []transitiveDep.Dependency{{Purl: "pkg:npm/stable", Version: "1.0.0"}, {Purl: "pkg:npm/relicensed", Version: "1.0.0"}}
+	tests := []struct {
+		name         string
+		after        []transitiveDep.Dependency
+		denyLicenses []string
+		want         dtos.PolicyImpactOutput
+	}{
+		{name: "unchanged", after: before, denyLicenses: []string{"GPL-3.0-only"},
+			want: dtos.PolicyImpactOutput{NewLicenses: []string{}, RemovedLicenses: []string{},
+				IntroducedViolations: []dtos.PolicyViolationOutput{}, ResolvedViolations: []dtos.PolicyViolationOutput{}}},
+		{name: "violation resolved", denyLicenses: []string{"gpl-3.0-only"},
+			after: []transitiveDep.Dependency{{Purl: "pkg:npm/stable", Version: "1.0.0"}, {Purl: "pkg:npm/relicensed", Version: "2.0.0"}},
+			want: dtos.PolicyImpactOutput{NewLicenses: []string{"Apache-2.0"}, RemovedLicenses: []string{"GPL-3.0-only"},
+				IntroducedViolations: []dtos.PolicyViolationOutput{},
+				ResolvedViolations:   []dtos.PolicyViolationOutput{{Purl: "pkg:npm/relicensed", Version: "1.0.0", License: "GPL-3.0-only"}}}},
+		{name: "violation moved", denyLicenses: []string{"GNU General Public License v3.0 only"},
+			after: []transitiveDep.Dependency{{Purl: "pkg:npm/relicensed", Version: "2.0.0"}, {Purl: "pkg:npm/copyleft", Version: "1.0.0"}},
+			want: dtos.PolicyImpactOutput{NewLicenses: []string{"Apache-2.0"}, RemovedLicenses: []string{"MIT"},
+				IntroducedViolations: []dtos.PolicyViolationOutput{{Purl: "pkg:npm/copyleft", Version: "1.0.0", License: "GPL-3.0-only"}},
+				ResolvedViolations:   []dtos.PolicyViolationOutput{{Purl: "pkg:npm/relicensed", Version: "1.0.0", License: "GPL-3.0-only"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policyImpact(before, tt.after, licenses, tt.denyLicenses)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("policyImpact() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}